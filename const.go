@@ -0,0 +1,167 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/zeldovich/go-rpcgen/spec"
+)
+
+var (
+	minInt32  = big.NewInt(math.MinInt32)
+	maxInt32  = big.NewInt(math.MaxInt32)
+	maxUint32 = big.NewInt(math.MaxUint32)
+	minInt64  = big.NewInt(math.MinInt64)
+	maxInt64  = big.NewInt(math.MaxInt64)
+	maxUint64 = new(big.Int).SetUint64(math.MaxUint64)
+	zero      = big.NewInt(0)
+	one       = big.NewInt(1)
+)
+
+// intRange returns the range of values of a Go integer type, or false
+// if typ is not one.
+func intRange(typ string) (*big.Int, *big.Int, bool) {
+	switch typ {
+	case "int8":
+		return big.NewInt(math.MinInt8), big.NewInt(math.MaxInt8), true
+	case "int16":
+		return big.NewInt(math.MinInt16), big.NewInt(math.MaxInt16), true
+	case "int32":
+		return minInt32, maxInt32, true
+	case "int64", "int":
+		return minInt64, maxInt64, true
+	case "uint8", "byte":
+		return zero, big.NewInt(math.MaxUint8), true
+	case "uint16":
+		return zero, big.NewInt(math.MaxUint16), true
+	case "uint32":
+		return zero, maxUint32, true
+	case "uint64", "uint":
+		return zero, maxUint64, true
+	}
+	return nil, nil, false
+}
+
+// importedConst reports whether name refers to a constant from a spec
+// given with -import, rather than one defined in f.
+func (f *specFile) importedConst(name string) (importedConst, bool) {
+	if _, ok := f.Consts[name]; ok {
+		return importedConst{}, false
+	}
+	c, ok := importedConsts[name]
+	return c, ok
+}
+
+// goValue returns the Go rendering of v.  Expressions other than a
+// single literal or identifier are rendered as their value.
+func (f *specFile) goValue(v spec.Value) string {
+	if v.Ident {
+		if c, ok := f.importedConst(v.Text); ok {
+			return c.goName
+		}
+		return constName(v.Text)
+	}
+	if v.Text == "" {
+		return v.Int.String()
+	}
+	return v.Text
+}
+
+// checkedValue checks that v lies within [min, max], and returns its
+// Go rendering.  what describes the use of v for error messages.
+func (f *specFile) checkedValue(v spec.Value, min, max *big.Int, what string) string {
+	// The value of an imported constant may not be known.
+	n := f.Value(v)
+	if n != nil && (n.Cmp(min) < 0 || n.Cmp(max) > 0) {
+		f.errorf(v.Pos, "%s %s out of range [%s, %s]", what, n, min, max)
+	}
+	return f.goValue(v)
+}
+
+// sizeValue checks an array or string bound.
+func (f *specFile) sizeValue(v spec.Value) string {
+	return f.checkedValue(v, zero, maxUint32, "size")
+}
+
+// maxSizeValue checks an optional maximum size, returning "" if there
+// is none.
+func (f *specFile) maxSizeValue(v *spec.Value) string {
+	if v == nil {
+		return ""
+	}
+	return f.sizeValue(*v)
+}
+
+// enumRange returns the range of enum values.
+func enumRange() (*big.Int, *big.Int) {
+	if *unsignedEnumFlag {
+		return zero, maxUint32
+	}
+	return minInt32, maxInt32
+}
+
+// enumValue checks the value of an enum item.
+func (f *specFile) enumValue(v spec.Value) string {
+	min, max := enumRange()
+	return f.checkedValue(v, min, max, "enum value")
+}
+
+// constValue checks the value of a const definition against the type
+// given by -const-type.
+func (f *specFile) constValue(v spec.Value) string {
+	min, max, ok := intRange(strings.TrimSpace(*constTypeFlag))
+	if !ok {
+		min, max = minInt64, maxUint64
+	}
+	return f.checkedValue(v, min, max, "constant")
+}
+
+// caseRange returns the values that a union discriminant of type t
+// can take.  If t is not known, such as a type from a spec given with
+// -import, it allows any 32-bit value.
+func (f *specFile) caseRange(t spec.Type) (*big.Int, *big.Int) {
+	seen := make(map[string]bool)
+	for {
+		n, ok := t.(spec.NamedType)
+		if !ok || seen[n.Name] {
+			break
+		}
+		seen[n.Name] = true
+		t = nil
+		switch d := f.TypeDef(n.Name).(type) {
+		case spec.TypedefDef:
+			if d.Decl.Kind == spec.DeclPlain {
+				t = d.Decl.Type
+			}
+		case spec.EnumDef:
+			t = spec.EnumType{}
+		}
+	}
+
+	switch t := t.(type) {
+	case spec.IntType:
+		if t.Unsigned {
+			return zero, maxUint32
+		}
+		return minInt32, maxInt32
+	case spec.BoolType:
+		return zero, one
+	case spec.EnumType:
+		return enumRange()
+	}
+	return minInt32, maxUint32
+}
+
+// caseValue checks a union case label against the range of the
+// discriminant type t.
+// Case labels are usually enum items, so identifiers are given their
+// Go names.
+func (f *specFile) caseValue(v spec.Value, t spec.Type) string {
+	min, max := f.caseRange(t)
+	text := f.checkedValue(v, min, max, "case label")
+	if _, ok := f.importedConst(v.Text); v.Ident && !ok {
+		return i(v.Text)
+	}
+	return text
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"reflect"
+
+	"github.com/zeldovich/go-rpcgen/spec"
+)
+
+// A specFile is a parsed spec, together with the definitions that are
+// generated from it.
+type specFile struct {
+	*spec.Spec
+
+	// defs holds the definitions of the spec, in the order of
+	// Defs, with constant values rendered as Go expressions.
+	defs []definition
+
+	errs scanner.ErrorList
+}
+
+func (f *specFile) errorf(pos token.Pos, format string, args ...interface{}) {
+	f.errs.Add(f.Fset.Position(pos), fmt.Sprintf(format, args...))
+}
+
+// convert fills in f.defs from the syntax tree, checking that each
+// constant value fits its use in Go.
+func (f *specFile) convert() {
+	for _, d := range f.Defs {
+		var def definition
+		switch d := d.(type) {
+		case spec.ConstDef:
+			def = constDef{d.Name, f.constValue(d.Value)}
+			docs[d.Name] = d.Doc
+		case spec.TypedefDef:
+			def = typedefDef{f.decl(d.Decl)}
+			docs[d.Decl.Name] = d.Decl.Doc
+		case spec.EnumDef:
+			def = enumDef{d.Name, f.enumItems(d.Items)}
+			docs[d.Name] = d.Doc
+		case spec.StructDef:
+			def = structDef{d.Name, f.decls(d.Fields)}
+			docs[d.Name] = d.Doc
+		case spec.UnionDef:
+			def = unionDef{d.Name, f.union(d.Union)}
+			docs[d.Name] = d.Doc
+		case spec.ProgramDef:
+			def = f.program(d)
+		}
+		f.defs = append(f.defs, def)
+	}
+}
+
+// goDef returns the definition converted from d.  Definitions cannot
+// be told apart by position, since those that come from one macro all
+// have the position of its use.
+func (f *specFile) goDef(d spec.Def) definition {
+	for i, sd := range f.Defs {
+		if reflect.DeepEqual(sd, d) {
+			return f.defs[i]
+		}
+	}
+	return nil
+}
+
+func (f *specFile) decl(d spec.Decl) decl {
+	var t declType
+	switch d.Kind {
+	case spec.DeclVoid:
+		return declVoid{}
+	case spec.DeclPlain:
+		t = declTypeTypespec{f.typespec(d.Type)}
+	case spec.DeclFixedArray:
+		t = declTypeArray{f.typespec(d.Type), f.sizeValue(*d.Size)}
+	case spec.DeclVarArray:
+		t = declTypeVarArray{f.typespec(d.Type), f.maxSizeValue(d.Size), f.fixedSize(d.Type)}
+	case spec.DeclFixedOpaque:
+		t = declTypeOpaqueArray{f.sizeValue(*d.Size)}
+	case spec.DeclVarOpaque:
+		t = declTypeOpaqueVarArray{f.maxSizeValue(d.Size)}
+	case spec.DeclString:
+		t = declTypeString{f.maxSizeValue(d.Size)}
+	case spec.DeclOptional:
+		t = declTypePtr{f.typespec(d.Type)}
+	}
+	return declName{t, d.Name, d.Doc, f.declFixedSize(d)}
+}
+
+func (f *specFile) decls(ds []spec.Decl) []decl {
+	var res []decl
+	for _, d := range ds {
+		res = append(res, f.decl(d))
+	}
+	return res
+}
+
+func (f *specFile) enumItems(items []spec.EnumItem) []enumItem {
+	var res []enumItem
+	for _, item := range items {
+		res = append(res, enumItem{item.Name, f.enumValue(item.Value)})
+		docs[item.Name] = item.Doc
+	}
+	return res
+}
+
+func (f *specFile) union(u spec.UnionType) typeUnion {
+	var cases []unionCaseDecl
+	for _, c := range u.Cases {
+		var labels []string
+		for _, v := range c.Values {
+			labels = append(labels, f.caseValue(v, u.Switch.Type))
+		}
+		cases = append(cases, unionCaseDecl{labels, f.decl(c.Decl)})
+	}
+
+	var def decl
+	if u.Default != nil {
+		def = f.decl(*u.Default)
+	}
+
+	return typeUnion{
+		switchDecl: f.decl(u.Switch),
+		cases:      unionCasesDef{cases, def},
+	}
+}
+
+func (f *specFile) typespec(t spec.Type) typespec {
+	switch t := t.(type) {
+	case spec.IntType:
+		return typeInt{t.Unsigned}
+	case spec.HyperType:
+		return typeHyper{t.Unsigned}
+	case spec.FloatType:
+		return typeFloat{}
+	case spec.DoubleType:
+		return typeDouble{}
+	case spec.QuadrupleType:
+		return typeQuadruple{}
+	case spec.BoolType:
+		return typeBool{}
+	case spec.EnumType:
+		return typeEnum{f.enumItems(t.Items)}
+	case spec.StructType:
+		return typeStruct{f.decls(t.Fields)}
+	case spec.UnionType:
+		return f.union(t)
+	case spec.NamedType:
+		return typeIdent{t.Name, t.NamePos}
+	}
+	panic(fmt.Sprintf("unexpected type %T", t))
+}
+
+func (f *specFile) typespecOpt(t spec.Type) typespecOpt {
+	if t == nil {
+		return typespecOpt{isVoid: true}
+	}
+	return typespecOpt{t: f.typespec(t)}
+}
+
+func (f *specFile) program(d spec.ProgramDef) progDef {
+	docs[d.Name] = d.Doc
+	var vers []progVer
+	for _, v := range d.Versions {
+		docs[v.Name] = v.Doc
+		var calls []progCall
+		for _, p := range v.Procs {
+			calls = append(calls, progCall{p.Name, f.typespecOpt(p.Arg), f.typespecOpt(p.Result), p.Value.Text})
+			docs[p.Name] = p.Doc
+		}
+		vers = append(vers, progVer{v.Name, calls, v.Value.Text})
+	}
+	return progDef{d.Name, vers, d.Value.Text}
+}
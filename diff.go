@@ -0,0 +1,546 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"math/big"
+
+	"github.com/zeldovich/go-rpcgen/spec"
+)
+
+// diff implements go-rpcgen diff old.x new.x.  It compares the types
+// and programs of two versions of a spec, and classifies each change
+// by whether peers using the old spec can still talk to peers using
+// the new one.  Types are matched by name, and programs, versions and
+// procedures by number, since that is what appears on the wire.
+
+// A compat classifies a change to a spec.
+type compat int
+
+const (
+	compatible compat = iota // same encoding
+	caveat                   // same encoding, but some values may be rejected or misread
+	breaking                 // different encoding
+)
+
+func (c compat) String() string {
+	switch c {
+	case compatible:
+		return "wire-compatible"
+	case caveat:
+		return "compatible-with-caveats"
+	}
+	return "breaking"
+}
+
+type change struct {
+	pos    token.Position
+	compat compat
+	msg    string
+}
+
+type differ struct {
+	old, new *specFile
+	changes  []change
+
+	// visiting holds the pairs of old and new type names being
+	// compared, so that recursive types terminate.
+	visiting map[[2]string]bool
+}
+
+func diff(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: go-rpcgen diff [flags] old.x new.x")
+	}
+
+	err := loadNames()
+	if err != nil {
+		return err
+	}
+
+	old, err := parseFile(args[0])
+	if err != nil {
+		return err
+	}
+	new, err := parseFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	d := &differ{old: old, new: new, visiting: make(map[[2]string]bool)}
+	d.types()
+	d.programs()
+
+	nbreaking := 0
+	for _, c := range d.changes {
+		fmt.Printf("%s: %s: %s\n", c.pos, c.compat, c.msg)
+		if c.compat == breaking {
+			nbreaking++
+		}
+	}
+	if nbreaking > 0 {
+		return fmt.Errorf("%d breaking changes", nbreaking)
+	}
+	return nil
+}
+
+// report records a change at pos in the new spec.
+func (d *differ) report(pos token.Pos, c compat, format string, args ...interface{}) {
+	d.changes = append(d.changes, change{d.new.Fset.Position(pos), c, fmt.Sprintf(format, args...)})
+}
+
+// reportOld records a change at pos in the old spec, for definitions
+// that were removed.
+func (d *differ) reportOld(pos token.Pos, c compat, format string, args ...interface{}) {
+	d.changes = append(d.changes, change{d.old.Fset.Position(pos), c, fmt.Sprintf(format, args...)})
+}
+
+// typeDecl returns a declaration of the type defined by d, and its
+// name, or false if d does not define a type.
+func typeDecl(d spec.Def) (spec.Decl, bool) {
+	switch d := d.(type) {
+	case spec.TypedefDef:
+		return d.Decl, d.Decl.Kind != spec.DeclVoid
+	case spec.EnumDef:
+		return spec.Decl{Kind: spec.DeclPlain, NamePos: d.NamePos, Name: d.Name, Type: spec.EnumType{Items: d.Items}}, true
+	case spec.StructDef:
+		return spec.Decl{Kind: spec.DeclPlain, NamePos: d.NamePos, Name: d.Name, Type: spec.StructType{Fields: d.Fields}}, true
+	case spec.UnionDef:
+		return spec.Decl{Kind: spec.DeclPlain, NamePos: d.NamePos, Name: d.Name, Type: d.Union}, true
+	}
+	return spec.Decl{}, false
+}
+
+// types compares the types defined by both specs.
+func (d *differ) types() {
+	for _, od := range d.old.Defs {
+		o, ok := typeDecl(od)
+		if !ok {
+			continue
+		}
+		n, ok := typeDecl(d.new.TypeDef(o.Name))
+		if !ok {
+			d.reportOld(o.NamePos, breaking, "type %s removed", o.Name)
+			continue
+		}
+		d.decl(o.Name, n.NamePos, o, n)
+	}
+
+	for _, nd := range d.new.Defs {
+		n, ok := typeDecl(nd)
+		if ok && d.old.TypeDef(n.Name) == nil {
+			d.report(n.NamePos, compatible, "type %s added", n.Name)
+		}
+	}
+}
+
+// expand replaces a reference to a typedef, enum, struct or union
+// defined in s by its definition.
+func expand(s *specFile, t spec.Decl) spec.Decl {
+	for depth := 0; depth < len(s.Defs); depth++ {
+		named, ok := t.Type.(spec.NamedType)
+		if !ok || t.Kind != spec.DeclPlain {
+			return t
+		}
+		def, ok := typeDecl(s.TypeDef(named.Name))
+		if !ok {
+			return t
+		}
+		def.NamePos, def.Name = t.NamePos, t.Name
+		t = def
+	}
+	return t
+}
+
+// decl compares the encodings of two declarations.  where names the
+// declaration in messages, and pos is its position in the new spec.
+func (d *differ) decl(where string, pos token.Pos, o, n spec.Decl) {
+	on, ook := o.Type.(spec.NamedType)
+	nn, nok := n.Type.(spec.NamedType)
+	if ook && nok && o.Kind == spec.DeclPlain && n.Kind == spec.DeclPlain {
+		// A named type that is defined in both specs is
+		// compared by itself.
+		if on.Name == nn.Name && d.old.TypeDef(on.Name) != nil && d.new.TypeDef(nn.Name) != nil {
+			return
+		}
+		key := [2]string{on.Name, nn.Name}
+		if d.visiting[key] {
+			return
+		}
+		d.visiting[key] = true
+		defer delete(d.visiting, key)
+	}
+
+	o = expand(d.old, o)
+	n = expand(d.new, n)
+
+	if o.Kind != n.Kind {
+		if isBytes(o.Kind) && isBytes(n.Kind) {
+			d.report(pos, caveat, "%s changed from %s to %s, which have the same encoding", where, declKind(o), declKind(n))
+			d.bound(where, pos, o.Size, n.Size)
+			return
+		}
+		d.report(pos, breaking, "%s changed from %s to %s", where, declKind(o), declKind(n))
+		return
+	}
+
+	switch o.Kind {
+	case spec.DeclPlain, spec.DeclOptional:
+		d.typ(where, pos, o.Type, n.Type)
+	case spec.DeclFixedArray, spec.DeclFixedOpaque:
+		ov, nv := d.old.Value(*o.Size), d.new.Value(*n.Size)
+		if ov == nil || nv == nil || ov.Cmp(nv) != 0 {
+			d.report(pos, breaking, "%s length changed from %s to %s", where, valueString(ov), valueString(nv))
+		}
+		if o.Kind == spec.DeclFixedArray {
+			d.decl(where+"[]", pos, spec.Decl{Kind: spec.DeclPlain, Type: o.Type}, spec.Decl{Kind: spec.DeclPlain, Type: n.Type})
+		}
+	case spec.DeclVarArray, spec.DeclVarOpaque, spec.DeclString:
+		d.bound(where, pos, o.Size, n.Size)
+		if o.Kind == spec.DeclVarArray {
+			d.decl(where+"[]", pos, spec.Decl{Kind: spec.DeclPlain, Type: o.Type}, spec.Decl{Kind: spec.DeclPlain, Type: n.Type})
+		}
+	}
+}
+
+func isBytes(k spec.DeclKind) bool {
+	return k == spec.DeclVarOpaque || k == spec.DeclString
+}
+
+func declKind(d spec.Decl) string {
+	switch d.Kind {
+	case spec.DeclVoid:
+		return "void"
+	case spec.DeclPlain:
+		return typeKind(d.Type)
+	case spec.DeclFixedArray:
+		return "fixed-length array"
+	case spec.DeclVarArray:
+		return "variable-length array"
+	case spec.DeclFixedOpaque:
+		return "fixed-length opaque"
+	case spec.DeclVarOpaque:
+		return "variable-length opaque"
+	case spec.DeclString:
+		return "string"
+	case spec.DeclOptional:
+		return "optional data"
+	}
+	return ""
+}
+
+func typeKind(t spec.Type) string {
+	switch t := t.(type) {
+	case spec.IntType:
+		if t.Unsigned {
+			return "unsigned int"
+		}
+		return "int"
+	case spec.HyperType:
+		if t.Unsigned {
+			return "unsigned hyper"
+		}
+		return "hyper"
+	case spec.FloatType:
+		return "float"
+	case spec.DoubleType:
+		return "double"
+	case spec.QuadrupleType:
+		return "quadruple"
+	case spec.BoolType:
+		return "bool"
+	case spec.EnumType:
+		return "enum"
+	case spec.StructType:
+		return "struct"
+	case spec.UnionType:
+		return "union"
+	case spec.NamedType:
+		return t.Name
+	}
+	return ""
+}
+
+func valueString(n *big.Int) string {
+	if n == nil {
+		return "unknown"
+	}
+	return n.String()
+}
+
+// bound compares the maximum sizes of variable-length data.
+func (d *differ) bound(where string, pos token.Pos, o, n *spec.Value) {
+	var ov, nv *big.Int
+	if o != nil {
+		ov = d.old.Value(*o)
+	}
+	if n != nil {
+		nv = d.new.Value(*n)
+	}
+
+	switch {
+	case o == nil && n == nil:
+	case o == nil:
+		d.report(pos, breaking, "%s bound narrowed from unbounded to %s", where, valueString(nv))
+	case n == nil:
+		d.report(pos, caveat, "%s bound widened from %s to unbounded; old peers reject longer values", where, valueString(ov))
+	case ov == nil || nv == nil:
+		if o.Text != n.Text {
+			d.report(pos, caveat, "%s bound changed from %s to %s", where, o.Text, n.Text)
+		}
+	case nv.Cmp(ov) < 0:
+		d.report(pos, breaking, "%s bound narrowed from %s to %s", where, ov, nv)
+	case nv.Cmp(ov) > 0:
+		d.report(pos, caveat, "%s bound widened from %s to %s; old peers reject longer values", where, ov, nv)
+	}
+}
+
+// typ compares two types, which have been expanded by decl.
+func (d *differ) typ(where string, pos token.Pos, o, n spec.Type) {
+	ok, nk := typeKind(o), typeKind(n)
+	if ok != nk {
+		if is32(o) && is32(n) || is64(o) && is64(n) {
+			d.report(pos, caveat, "%s changed from %s to %s, which have the same encoding", where, ok, nk)
+			return
+		}
+		d.report(pos, breaking, "%s changed from %s to %s", where, ok, nk)
+		return
+	}
+
+	switch o := o.(type) {
+	case spec.EnumType:
+		d.enum(where, pos, o, n.(spec.EnumType))
+	case spec.StructType:
+		d.structFields(where, pos, o, n.(spec.StructType))
+	case spec.UnionType:
+		d.union(where, pos, o, n.(spec.UnionType))
+	}
+}
+
+// is32 and is64 report whether values of t are encoded as 4 or 8
+// byte integers.
+func is32(t spec.Type) bool {
+	switch t.(type) {
+	case spec.IntType, spec.EnumType, spec.BoolType:
+		return true
+	}
+	return false
+}
+
+func is64(t spec.Type) bool {
+	_, ok := t.(spec.HyperType)
+	return ok
+}
+
+func (d *differ) enum(where string, pos token.Pos, o, n spec.EnumType) {
+	newItems := make(map[string]spec.EnumItem)
+	for _, item := range n.Items {
+		if v := d.new.Value(item.Value); v != nil {
+			newItems[v.String()] = item
+		}
+	}
+
+	oldItems := make(map[string]bool)
+	for _, item := range o.Items {
+		v := d.old.Value(item.Value)
+		if v == nil {
+			continue
+		}
+		oldItems[v.String()] = true
+
+		ni, ok := newItems[v.String()]
+		if !ok {
+			d.report(pos, breaking, "%s value %s (%s) removed", where, item.Name, v)
+		} else if ni.Name != item.Name {
+			d.report(ni.NamePos, compatible, "%s value %s renamed to %s", where, item.Name, ni.Name)
+		}
+	}
+
+	for _, item := range n.Items {
+		v := d.new.Value(item.Value)
+		if v != nil && !oldItems[v.String()] {
+			d.report(item.NamePos, caveat, "%s value %s (%s) added; old peers may reject it", where, item.Name, v)
+		}
+	}
+}
+
+func (d *differ) structFields(where string, pos token.Pos, o, n spec.StructType) {
+	if len(o.Fields) != len(n.Fields) {
+		d.report(pos, breaking, "%s has %d fields instead of %d", where, len(n.Fields), len(o.Fields))
+		return
+	}
+
+	for idx, of := range o.Fields {
+		nf := n.Fields[idx]
+		if of.Name != nf.Name {
+			d.report(nf.NamePos, compatible, "%s field %s renamed to %s", where, of.Name, nf.Name)
+		}
+		d.decl(where+"."+nf.Name, nf.NamePos, of, nf)
+	}
+}
+
+// unionArms maps the values of the case labels of a union onto their
+// arms.
+func unionArms(s *specFile, u spec.UnionType) (map[string]spec.Decl, []string) {
+	arms := make(map[string]spec.Decl)
+	var keys []string
+	for _, c := range u.Cases {
+		for _, v := range c.Values {
+			key := v.Text
+			if n := s.Value(v); n != nil {
+				key = n.String()
+			}
+			arms[key] = c.Decl
+			keys = append(keys, key)
+		}
+	}
+	return arms, keys
+}
+
+func (d *differ) union(where string, pos token.Pos, o, n spec.UnionType) {
+	d.decl(where+" discriminant", n.Switch.NamePos, o.Switch, n.Switch)
+
+	oldArms, oldKeys := unionArms(d.old, o)
+	newArms, newKeys := unionArms(d.new, n)
+
+	for _, key := range oldKeys {
+		oa := oldArms[key]
+		na, ok := newArms[key]
+		switch {
+		case ok:
+			d.decl(fmt.Sprintf("%s case %s", where, key), na.NamePos, oa, na)
+		case n.Default != nil:
+			d.decl(fmt.Sprintf("%s case %s", where, key), n.Default.NamePos, oa, *n.Default)
+		default:
+			d.report(pos, breaking, "%s case %s removed", where, key)
+		}
+	}
+
+	for _, key := range newKeys {
+		if _, ok := oldArms[key]; ok {
+			continue
+		}
+		na := newArms[key]
+		if o.Default != nil {
+			d.decl(fmt.Sprintf("%s case %s", where, key), na.NamePos, *o.Default, na)
+		} else {
+			d.report(na.NamePos, caveat, "%s case %s added; old peers reject it", where, key)
+		}
+	}
+
+	switch {
+	case o.Default != nil && n.Default != nil:
+		d.decl(where+" default", n.Default.NamePos, *o.Default, *n.Default)
+	case o.Default != nil:
+		d.report(pos, breaking, "%s default arm removed", where)
+	case n.Default != nil:
+		d.report(n.Default.NamePos, caveat, "%s default arm added; old peers reject other values", where)
+	}
+}
+
+// programs compares the programs, versions and procedures of both
+// specs, matching them by number.
+func (d *differ) programs() {
+	oldProgs := programsByNumber(d.old)
+	newProgs := programsByNumber(d.new)
+
+	for _, op := range d.old.Defs {
+		op, ok := op.(spec.ProgramDef)
+		if !ok {
+			continue
+		}
+		np, ok := newProgs[valueString(d.old.Value(op.Value))]
+		if !ok {
+			d.reportOld(op.NamePos, breaking, "program %s removed", op.Name)
+			continue
+		}
+		if np.Name != op.Name {
+			d.report(np.NamePos, compatible, "program %s renamed to %s", op.Name, np.Name)
+		}
+		d.versions(np.Name, op, np)
+	}
+
+	for _, np := range d.new.Defs {
+		np, ok := np.(spec.ProgramDef)
+		if ok && oldProgs[valueString(d.new.Value(np.Value))] == nil {
+			d.report(np.NamePos, compatible, "program %s added", np.Name)
+		}
+	}
+}
+
+func programsByNumber(s *specFile) map[string]*spec.ProgramDef {
+	res := make(map[string]*spec.ProgramDef)
+	for _, d := range s.Defs {
+		if p, ok := d.(spec.ProgramDef); ok {
+			res[valueString(s.Value(p.Value))] = &p
+		}
+	}
+	return res
+}
+
+func (d *differ) versions(prog string, op spec.ProgramDef, np *spec.ProgramDef) {
+	newVers := make(map[string]spec.Version)
+	for _, v := range np.Versions {
+		newVers[valueString(d.new.Value(v.Value))] = v
+	}
+	oldVers := make(map[string]bool)
+
+	for _, ov := range op.Versions {
+		key := valueString(d.old.Value(ov.Value))
+		oldVers[key] = true
+		nv, ok := newVers[key]
+		if !ok {
+			d.reportOld(ov.NamePos, breaking, "version %s of %s removed", ov.Name, prog)
+			continue
+		}
+		if nv.Name != ov.Name {
+			d.report(nv.NamePos, compatible, "version %s of %s renamed to %s", ov.Name, prog, nv.Name)
+		}
+		d.procs(prog+"/"+nv.Name, ov, nv)
+	}
+
+	for _, nv := range np.Versions {
+		if !oldVers[valueString(d.new.Value(nv.Value))] {
+			d.report(nv.NamePos, compatible, "version %s of %s added", nv.Name, prog)
+		}
+	}
+}
+
+func (d *differ) procs(vers string, ov, nv spec.Version) {
+	newProcs := make(map[string]spec.Proc)
+	for _, p := range nv.Procs {
+		newProcs[valueString(d.new.Value(p.Value))] = p
+	}
+	oldProcs := make(map[string]bool)
+
+	for _, op := range ov.Procs {
+		key := valueString(d.old.Value(op.Value))
+		oldProcs[key] = true
+		np, ok := newProcs[key]
+		if !ok {
+			d.reportOld(op.NamePos, breaking, "procedure %s of %s removed", op.Name, vers)
+			continue
+		}
+		if np.Name != op.Name {
+			d.report(np.NamePos, compatible, "procedure %s of %s renamed to %s", op.Name, vers, np.Name)
+		}
+		where := vers + "/" + np.Name
+		d.decl(where+" argument", np.NamePos, procDecl(op.Arg), procDecl(np.Arg))
+		d.decl(where+" result", np.NamePos, procDecl(op.Result), procDecl(np.Result))
+	}
+
+	for _, np := range nv.Procs {
+		if !oldProcs[valueString(d.new.Value(np.Value))] {
+			d.report(np.NamePos, compatible, "procedure %s of %s added", np.Name, vers)
+		}
+	}
+}
+
+// procDecl returns a declaration of a procedure's argument or result
+// type, which is nil for void.
+func procDecl(t spec.Type) spec.Decl {
+	if t == nil {
+		return spec.Decl{Kind: spec.DeclVoid}
+	}
+	return spec.Decl{Kind: spec.DeclPlain, Type: t}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	dir, err := ioutil.TempDir("", "diff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	specs := map[string]string{
+		"old.x": `struct s { int a; string n<8>; };
+enum e { A = 1 };
+program P { version V { s GET(int) = 1; } = 1; } = 7;
+`,
+		"compat.x": `struct s { int a; string n<16>; };
+enum e { A = 1, B = 2 };
+program P { version V { s GET(int) = 1; void PUT(s) = 2; } = 1; } = 7;
+`,
+		"breaking.x": `struct s { hyper a; string n<8>; };
+enum e { A = 1 };
+program P { version V { s GET(int) = 1; } = 1; } = 7;
+`,
+	}
+	for name, src := range specs {
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(src), 0666)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := filepath.Join(dir, "old.x")
+
+	out, err := rpcgen("diff", old, filepath.Join(dir, "compat.x"))
+	if err != nil {
+		t.Errorf("compatible changes reported as breaking: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"compat.x:1:26: compatible-with-caveats: s.n bound widened from 8 to 16",
+		"compat.x:2:17: compatible-with-caveats: e value B (2) added",
+		"compat.x:3:46: wire-compatible: procedure PUT of P/V added",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+
+	out, err = rpcgen("diff", old, filepath.Join(dir, "breaking.x"))
+	if err == nil {
+		t.Error("breaking change not reported")
+	}
+	if !strings.Contains(out, "breaking.x:1:18: breaking: s.a changed from int to hyper") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	out, err = rpcgen("diff", old, old)
+	if err != nil || out != "" {
+		t.Errorf("identical specs: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,573 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zeldovich/go-rpcgen/spec"
+)
+
+// doc implements go-rpcgen doc file.x.  It writes a reference for the
+// spec to the -o file, or to standard output: a procedure table for
+// each version of each program, the wire layout of each type with the
+// offsets and sizes of its fields, and the constants.  Type names and
+// constants link to their definitions.  -doc-format selects markdown
+// or html.
+func doc(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: go-rpcgen doc [flags] file.x")
+	}
+
+	var newFormat func(w io.Writer) docFormat
+	switch *docFormatFlag {
+	case "markdown":
+		newFormat = func(w io.Writer) docFormat { return &markdownDoc{w} }
+	case "html":
+		newFormat = func(w io.Writer) docFormat { return &htmlDoc{w} }
+	default:
+		return fmt.Errorf("unknown -doc-format %q", *docFormatFlag)
+	}
+
+	err := loadNames()
+	if err != nil {
+		return err
+	}
+
+	f, err := parseFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	d := &docWriter{f: f, fm: newFormat(&buf), anchors: make(map[string]string)}
+	d.write(filepath.Base(args[0]))
+
+	if *outputFile == "" {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return ioutil.WriteFile(*outputFile, buf.Bytes(), 0666)
+}
+
+// A docSpan is a piece of text, which links to anchor if it is set.
+type docSpan struct {
+	text   string
+	anchor string
+}
+
+// docText is text with links, such as the rendering of a type.
+type docText []docSpan
+
+func plain(s string) docText {
+	return docText{{text: s}}
+}
+
+// A docRow is a table row.  If id is set, the row is the target of
+// links to id.
+type docRow struct {
+	id    string
+	cells []docText
+}
+
+// A docFormat renders the parts of a reference document.
+type docFormat interface {
+	begin(title string)
+	heading(level int, id string, text string)
+	para(text docText)
+	table(header []string, rows []docRow)
+	end()
+}
+
+type docWriter struct {
+	f  *specFile
+	fm docFormat
+
+	// anchors maps the names of types, constants and enum items
+	// onto the anchor that references to them link to.
+	anchors map[string]string
+}
+
+func (d *docWriter) write(title string) {
+	var consts []spec.ConstDef
+	var progs []spec.ProgramDef
+	var types []spec.Decl
+	var typeDocs []string
+	for _, def := range d.f.Defs {
+		switch def := def.(type) {
+		case spec.ConstDef:
+			consts = append(consts, def)
+			d.anchors[def.Name] = def.Name
+		case spec.ProgramDef:
+			progs = append(progs, def)
+		case spec.EnumDef:
+			for _, item := range def.Items {
+				d.anchors[item.Name] = def.Name
+			}
+		}
+		if t, ok := typeDecl(def); ok {
+			types = append(types, t)
+			typeDocs = append(typeDocs, defDoc(def))
+			d.anchors[t.Name] = t.Name
+			if e, ok := t.Type.(spec.EnumType); ok && t.Kind == spec.DeclPlain {
+				for _, item := range e.Items {
+					d.anchors[item.Name] = t.Name
+				}
+			}
+		}
+	}
+
+	d.fm.begin(title)
+	if len(progs) > 0 {
+		d.fm.heading(2, "", "Programs")
+		for _, p := range progs {
+			d.program(p)
+		}
+	}
+	if len(types) > 0 {
+		d.fm.heading(2, "", "Types")
+		for idx, t := range types {
+			d.typ(3, t.Name, typeDocs[idx], t)
+		}
+	}
+	if len(consts) > 0 {
+		d.fm.heading(2, "", "Constants")
+		var rows []docRow
+		for _, c := range consts {
+			rows = append(rows, docRow{c.Name, []docText{plain(c.Name), plain(d.number(c.Value)), plain(c.Doc)}})
+		}
+		d.fm.table([]string{"Name", "Value", "Description"}, rows)
+	}
+	d.fm.end()
+}
+
+// defDoc returns the comment attached to a type definition.
+func defDoc(d spec.Def) string {
+	switch d := d.(type) {
+	case spec.TypedefDef:
+		return d.Decl.Doc
+	case spec.EnumDef:
+		return d.Doc
+	case spec.StructDef:
+		return d.Doc
+	case spec.UnionDef:
+		return d.Doc
+	}
+	return ""
+}
+
+// number returns the value of v, or its text if the value is not
+// known.
+func (d *docWriter) number(v spec.Value) string {
+	if n := d.f.Value(v); n != nil {
+		return n.String()
+	}
+	return v.Text
+}
+
+func (d *docWriter) program(p spec.ProgramDef) {
+	d.fm.heading(3, p.Name, p.Name)
+	d.fm.para(plain(fmt.Sprintf("Program number %s.", d.number(p.Value))))
+	if p.Doc != "" {
+		d.fm.para(plain(p.Doc))
+	}
+
+	for _, v := range p.Versions {
+		d.fm.heading(4, v.Name, fmt.Sprintf("%s, version %s", v.Name, d.number(v.Value)))
+		if v.Doc != "" {
+			d.fm.para(plain(v.Doc))
+		}
+
+		var rows []docRow
+		for _, c := range v.Procs {
+			rows = append(rows, docRow{c.Name, []docText{
+				plain(d.number(c.Value)),
+				plain(c.Name),
+				d.procType(c.Arg),
+				d.procType(c.Result),
+				plain(c.Doc),
+			}})
+		}
+		d.fm.table([]string{"Number", "Procedure", "Argument", "Result", "Description"}, rows)
+	}
+}
+
+func (d *docWriter) procType(t spec.Type) docText {
+	if t == nil {
+		return plain("void")
+	}
+	return d.typeText(t, "")
+}
+
+// value returns v, linked to its definition if it is a name.
+func (d *docWriter) value(v spec.Value) docText {
+	if v.Ident {
+		return docText{{v.Text, d.anchors[v.Text]}}
+	}
+	return plain(v.Text)
+}
+
+// typeText returns the XDR rendering of t.  Anonymous enums, structs
+// and unions link to anchor, where they are described.
+func (d *docWriter) typeText(t spec.Type, anchor string) docText {
+	switch t := t.(type) {
+	case spec.IntType:
+		if t.Unsigned {
+			return plain("unsigned int")
+		}
+		return plain("int")
+	case spec.HyperType:
+		if t.Unsigned {
+			return plain("unsigned hyper")
+		}
+		return plain("hyper")
+	case spec.FloatType:
+		return plain("float")
+	case spec.DoubleType:
+		return plain("double")
+	case spec.QuadrupleType:
+		return plain("quadruple")
+	case spec.BoolType:
+		return plain("bool")
+	case spec.EnumType:
+		return docText{{"enum", anchor}}
+	case spec.StructType:
+		return docText{{"struct", anchor}}
+	case spec.UnionType:
+		return docText{{"union", anchor}}
+	case spec.NamedType:
+		return docText{{t.Name, d.anchors[t.Name]}}
+	}
+	panic(fmt.Sprintf("unexpected type %T", t))
+}
+
+// declText returns the XDR rendering of the type declared by v.
+func (d *docWriter) declText(v spec.Decl, anchor string) docText {
+	bound := func(open, close string) docText {
+		res := plain(open)
+		if v.Size != nil {
+			res = append(res, d.value(*v.Size)...)
+		}
+		return append(res, docSpan{text: close})
+	}
+
+	switch v.Kind {
+	case spec.DeclVoid:
+		return plain("void")
+	case spec.DeclFixedArray:
+		return append(d.typeText(v.Type, anchor), bound("[", "]")...)
+	case spec.DeclVarArray:
+		return append(d.typeText(v.Type, anchor), bound("<", ">")...)
+	case spec.DeclFixedOpaque:
+		return append(plain("opaque"), bound("[", "]")...)
+	case spec.DeclVarOpaque:
+		return append(plain("opaque"), bound("<", ">")...)
+	case spec.DeclString:
+		return append(plain("string"), bound("<", ">")...)
+	case spec.DeclOptional:
+		return append(d.typeText(v.Type, anchor), docSpan{text: " *"})
+	}
+	return d.typeText(v.Type, anchor)
+}
+
+// sizeText describes the size of the encoding of v: its size if it is
+// fixed, or else how it is made up.
+func (d *docWriter) sizeText(v spec.Decl) string {
+	if n, ok := d.f.DeclFixedSize(v); ok {
+		return n.String()
+	}
+
+	var max string
+	if v.Size != nil {
+		if n := d.f.Value(*v.Size); n != nil {
+			max = n.String()
+		} else {
+			max = v.Size.Text
+		}
+	}
+
+	bound := ""
+	if max != "" {
+		bound = ", n ≤ " + max
+	}
+
+	elem, elemFixed := d.f.FixedSize(v.Type)
+	switch v.Kind {
+	case spec.DeclFixedArray:
+		return fmt.Sprintf("%s elements", max)
+	case spec.DeclVarArray:
+		if elemFixed {
+			return fmt.Sprintf("4 + n × %s%s", elem, bound)
+		}
+		return fmt.Sprintf("4 + n elements%s", bound)
+	case spec.DeclVarOpaque, spec.DeclString:
+		return fmt.Sprintf("4 + n padded to 4%s", bound)
+	case spec.DeclOptional:
+		if elemFixed {
+			return fmt.Sprintf("4 + %s if present", elem)
+		}
+		return "4 + value if present"
+	}
+	return "variable"
+}
+
+// typ describes the type declared by t, and any anonymous types nested
+// in it, which are named after the path of fields that leads to them,
+// such as rpc_msg.body.
+func (d *docWriter) typ(level int, name string, doc string, t spec.Decl) {
+	d.fm.heading(level, name, name)
+	if doc != "" {
+		d.fm.para(plain(doc))
+	}
+
+	size := "Variable size."
+	if n, ok := d.f.DeclFixedSize(t); ok {
+		size = fmt.Sprintf("Fixed size: %s bytes.", n)
+	}
+
+	var nested []spec.Decl
+	field := func(v spec.Decl) docText {
+		switch v.Type.(type) {
+		case spec.EnumType, spec.StructType, spec.UnionType:
+			nested = append(nested, v)
+		}
+		return d.declText(v, name+"."+v.Name)
+	}
+
+	switch tt := t.Type.(type) {
+	case spec.EnumType:
+		if t.Kind != spec.DeclPlain {
+			break
+		}
+		d.fm.para(plain("Enum. " + size))
+		var rows []docRow
+		for _, item := range tt.Items {
+			rows = append(rows, docRow{"", []docText{plain(item.Name), plain(d.number(item.Value)), plain(item.Doc)}})
+		}
+		d.fm.table([]string{"Name", "Value", "Description"}, rows)
+		return
+
+	case spec.StructType:
+		if t.Kind != spec.DeclPlain {
+			break
+		}
+		d.fm.para(plain("Struct. " + size))
+		// Offsets are known up to the first field whose size
+		// varies.
+		var rows []docRow
+		offset := new(big.Int)
+		for _, v := range tt.Fields {
+			off := ""
+			if offset != nil {
+				off = offset.String()
+			}
+			rows = append(rows, docRow{"", []docText{plain(off), plain(v.Name), field(v), plain(d.sizeText(v)), plain(v.Doc)}})
+			if n, ok := d.f.DeclFixedSize(v); ok && offset != nil {
+				offset.Add(offset, n)
+			} else {
+				offset = nil
+			}
+		}
+		d.fm.table([]string{"Offset", "Field", "Type", "Size", "Description"}, rows)
+		d.nested(level, name, nested)
+		return
+
+	case spec.UnionType:
+		if t.Kind != spec.DeclPlain {
+			break
+		}
+		d.fm.para(plain("Union: the discriminant selects the arm that follows it. " + size))
+		sw := tt.Switch
+		armOffset := d.sizeText(sw)
+		rows := []docRow{{"", []docText{plain("discriminant"), plain("0"), plain(sw.Name), field(sw), plain(armOffset), plain(sw.Doc)}}}
+		arm := func(labels docText, v spec.Decl) {
+			name := plain("")
+			if v.Kind != spec.DeclVoid {
+				name = plain(v.Name)
+			}
+			rows = append(rows, docRow{"", []docText{labels, plain(armOffset), name, field(v), plain(d.sizeText(v)), plain(v.Doc)}})
+		}
+		for _, c := range tt.Cases {
+			var labels docText
+			for idx, v := range c.Values {
+				if idx > 0 {
+					labels = append(labels, docSpan{text: ", "})
+				}
+				labels = append(labels, d.value(v)...)
+			}
+			arm(labels, c.Decl)
+		}
+		if tt.Default != nil {
+			arm(plain("default"), *tt.Default)
+		}
+		d.fm.table([]string{"Case", "Offset", "Field", "Type", "Size", "Description"}, rows)
+		d.nested(level, name, nested)
+		return
+	}
+
+	d.fm.para(plain("Typedef. " + size))
+	d.fm.table([]string{"Type", "Size"}, []docRow{{"", []docText{field(t), plain(d.sizeText(t))}}})
+	d.nested(level, name, nested)
+}
+
+// nested describes the anonymous types declared by the fields of type
+// name.
+func (d *docWriter) nested(level int, name string, fields []spec.Decl) {
+	for _, v := range fields {
+		d.typ(level+1, name+"."+v.Name, "", spec.Decl{Kind: spec.DeclPlain, Name: v.Name, Type: v.Type})
+	}
+}
+
+// markdownDoc renders a reference as Markdown, with HTML anchors for
+// the targets of links.
+type markdownDoc struct {
+	w io.Writer
+}
+
+func (m *markdownDoc) begin(title string) {
+	fmt.Fprintf(m.w, "# %s\n", markdownEscape(title))
+}
+
+func (m *markdownDoc) heading(level int, id string, text string) {
+	fmt.Fprintf(m.w, "\n%s ", strings.Repeat("#", level))
+	if id != "" {
+		fmt.Fprintf(m.w, "<a id=\"%s\"></a>", html.EscapeString(id))
+	}
+	fmt.Fprintf(m.w, "%s\n", markdownEscape(text))
+}
+
+func (m *markdownDoc) para(text docText) {
+	fmt.Fprintf(m.w, "\n%s\n", m.text(text))
+}
+
+func (m *markdownDoc) table(header []string, rows []docRow) {
+	fmt.Fprintf(m.w, "\n|")
+	for _, h := range header {
+		fmt.Fprintf(m.w, " %s |", h)
+	}
+	fmt.Fprintf(m.w, "\n|")
+	for range header {
+		fmt.Fprintf(m.w, " --- |")
+	}
+	fmt.Fprintf(m.w, "\n")
+
+	for _, r := range rows {
+		fmt.Fprintf(m.w, "|")
+		for idx, c := range r.cells {
+			cell := strings.Replace(m.text(c), "\n", " ", -1)
+			if idx == 0 && r.id != "" {
+				cell = fmt.Sprintf("<a id=\"%s\"></a>%s", html.EscapeString(r.id), cell)
+			}
+			fmt.Fprintf(m.w, " %s |", cell)
+		}
+		fmt.Fprintf(m.w, "\n")
+	}
+}
+
+func (m *markdownDoc) end() {}
+
+func (m *markdownDoc) text(text docText) string {
+	var res string
+	for _, s := range text {
+		if s.anchor != "" {
+			res += fmt.Sprintf("[%s](#%s)", markdownEscape(s.text), s.anchor)
+		} else {
+			res += markdownEscape(s.text)
+		}
+	}
+	return res
+}
+
+// markdownEscape escapes the characters that Markdown would otherwise
+// interpret, including | in tables and < in HTML tags.
+func markdownEscape(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch c {
+		case '\\', '`', '*', '[', ']', '|':
+			b.WriteRune('\\')
+			b.WriteRune(c)
+		case '<':
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		case '&':
+			b.WriteString("&amp;")
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// htmlDoc renders a reference as a standalone HTML page.
+type htmlDoc struct {
+	w io.Writer
+}
+
+func (h *htmlDoc) begin(title string) {
+	fmt.Fprintf(h.w, "<!DOCTYPE html>\n")
+	fmt.Fprintf(h.w, "<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(h.w, "<title>%s</title>\n", html.EscapeString(title))
+	fmt.Fprintf(h.w, "<style>\n")
+	fmt.Fprintf(h.w, "table { border-collapse: collapse; }\n")
+	fmt.Fprintf(h.w, "th, td { border: 1px solid #ccc; padding: 2px 6px; text-align: left; vertical-align: top; }\n")
+	fmt.Fprintf(h.w, "</style>\n")
+	fmt.Fprintf(h.w, "</head>\n<body>\n")
+	fmt.Fprintf(h.w, "<h1>%s</h1>\n", html.EscapeString(title))
+}
+
+func (h *htmlDoc) heading(level int, id string, text string) {
+	if id != "" {
+		fmt.Fprintf(h.w, "<h%d id=\"%s\">%s</h%d>\n", level, html.EscapeString(id), html.EscapeString(text), level)
+	} else {
+		fmt.Fprintf(h.w, "<h%d>%s</h%d>\n", level, html.EscapeString(text), level)
+	}
+}
+
+func (h *htmlDoc) para(text docText) {
+	fmt.Fprintf(h.w, "<p>%s</p>\n", h.text(text))
+}
+
+func (h *htmlDoc) table(header []string, rows []docRow) {
+	fmt.Fprintf(h.w, "<table>\n<tr>")
+	for _, c := range header {
+		fmt.Fprintf(h.w, "<th>%s</th>", html.EscapeString(c))
+	}
+	fmt.Fprintf(h.w, "</tr>\n")
+
+	for _, r := range rows {
+		if r.id != "" {
+			fmt.Fprintf(h.w, "<tr id=\"%s\">", html.EscapeString(r.id))
+		} else {
+			fmt.Fprintf(h.w, "<tr>")
+		}
+		for _, c := range r.cells {
+			fmt.Fprintf(h.w, "<td>%s</td>", h.text(c))
+		}
+		fmt.Fprintf(h.w, "</tr>\n")
+	}
+	fmt.Fprintf(h.w, "</table>\n")
+}
+
+func (h *htmlDoc) end() {
+	fmt.Fprintf(h.w, "</body>\n</html>\n")
+}
+
+func (h *htmlDoc) text(text docText) string {
+	var res string
+	for _, s := range text {
+		if s.anchor != "" {
+			res += fmt.Sprintf("<a href=\"#%s\">%s</a>", html.EscapeString(s.anchor), html.EscapeString(s.text))
+		} else {
+			res += html.EscapeString(s.text)
+		}
+	}
+	return res
+}
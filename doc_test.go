@@ -0,0 +1,71 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDoc(t *testing.T) {
+	dir, err := ioutil.TempDir("", "doc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	x := filepath.Join(dir, "spec.x")
+	err = ioutil.WriteFile(x, []byte(`const MAX = 8;
+/* A color. */
+enum color { RED = 0, GREEN = 1 };
+struct point { int x; color c; opaque tag[MAX]; string name<MAX>; };
+program P { version V { point GET(int) = 1; } = 1; } = 0x20000001;
+`), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	md := filepath.Join(dir, "spec.md")
+	out, err := rpcgen("doc", "-o", md, x)
+	if err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	data, err := ioutil.ReadFile(md)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"| <a id=\"GET\"></a>1 | GET | int | [point](#point) |  |",
+		"A color.",
+		"Enum. Fixed size: 4 bytes.",
+		"| 8 | tag | opaque\\[[MAX](#MAX)\\] | 8 |  |",
+		"| 16 | name | string&lt;[MAX](#MAX)&gt; | 4 + n padded to 4, n ≤ 8 |  |",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("missing %q in:\n%s", want, data)
+		}
+	}
+
+	html := filepath.Join(dir, "spec.html")
+	out, err = rpcgen("doc", "-doc-format", "html", "-o", html, x)
+	if err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	data, err = ioutil.ReadFile(html)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `<a href="#point">point</a>`) {
+		t.Errorf("no link to point in:\n%s", data)
+	}
+
+	err = ioutil.WriteFile(x, []byte("struct s { nosuch n; };\n"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err = rpcgen("doc", "-o", md, x)
+	if err == nil || !strings.Contains(out, "undefined type nosuch") {
+		t.Errorf("error in spec not reported: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/zeldovich/go-rpcgen/spec"
+)
+
+// With -emit-ast json, go-rpcgen writes the checked spec as JSON
+// instead of generating Go, for tools in other languages.  Constant
+// values are resolved to numbers, and named types keep their names.
+
+type jsonSpec struct {
+	File     string        `json:"file"`
+	Consts   []jsonConst   `json:"consts"`
+	Types    []jsonTypeDef `json:"types"`
+	Programs []jsonProgram `json:"programs"`
+}
+
+type jsonConst struct {
+	Name  string   `json:"name"`
+	Pos   string   `json:"pos"`
+	Value *big.Int `json:"value"`
+}
+
+type jsonTypeDef struct {
+	Name string    `json:"name"`
+	Pos  string    `json:"pos"`
+	Type *jsonType `json:"type"`
+}
+
+// A jsonType describes a type, or the type declared by a field or
+// typedef.  Kind is one of int, unsigned int, hyper, unsigned hyper,
+// float, double, quadruple, bool, enum, struct, union, named, array,
+// var_array, opaque, var_opaque, string and optional.
+type jsonType struct {
+	Kind string `json:"kind"`
+
+	// Name is the referenced type, for named.
+	Name string `json:"name,omitempty"`
+
+	// Elem is the element type of array, var_array and optional.
+	Elem *jsonType `json:"elem,omitempty"`
+
+	// Length is the length of array and opaque; Max is the bound of
+	// var_array, var_opaque and string, if they have one.
+	Length *jsonValue `json:"length,omitempty"`
+	Max    *jsonValue `json:"max,omitempty"`
+
+	Items   []jsonValue `json:"items,omitempty"`
+	Fields  []jsonField `json:"fields,omitempty"`
+	Switch  *jsonField  `json:"switch,omitempty"`
+	Cases   []jsonCase  `json:"cases,omitempty"`
+	Default *jsonField  `json:"default,omitempty"`
+
+	// Size is the size of the encoding, if it is the same for every
+	// value of the type.
+	Size *big.Int `json:"size,omitempty"`
+}
+
+// A jsonField is a struct field or union arm.  Type is null for void.
+type jsonField struct {
+	Name string    `json:"name,omitempty"`
+	Type *jsonType `json:"type"`
+}
+
+type jsonCase struct {
+	Values []jsonValue `json:"values"`
+	Arm    jsonField   `json:"arm"`
+}
+
+// A jsonValue is a constant value, with the name of the constant or
+// enum item that it refers to, if any.  Value is null if it is not
+// known, as for some constants from -import specs.
+type jsonValue struct {
+	Name  string   `json:"name,omitempty"`
+	Value *big.Int `json:"value"`
+}
+
+type jsonProgram struct {
+	Name     string        `json:"name"`
+	Pos      string        `json:"pos"`
+	Number   *big.Int      `json:"number"`
+	Versions []jsonVersion `json:"versions"`
+}
+
+type jsonVersion struct {
+	Name       string          `json:"name"`
+	Pos        string          `json:"pos"`
+	Number     *big.Int        `json:"number"`
+	Procedures []jsonProcedure `json:"procedures"`
+}
+
+// A jsonProcedure has a null Arg or Result for void.
+type jsonProcedure struct {
+	Name   string    `json:"name"`
+	Pos    string    `json:"pos"`
+	Number *big.Int  `json:"number"`
+	Arg    *jsonType `json:"arg"`
+	Result *jsonType `json:"result"`
+}
+
+// emitJSON writes the spec as JSON to filename.
+func (f *specFile) emitJSON(filename string) error {
+	js := jsonSpec{
+		File:     *inputFile,
+		Consts:   []jsonConst{},
+		Types:    []jsonTypeDef{},
+		Programs: []jsonProgram{},
+	}
+
+	for _, d := range f.Defs {
+		switch d := d.(type) {
+		case spec.ConstDef:
+			js.Consts = append(js.Consts, jsonConst{d.Name, f.pos(d.NamePos), f.Value(d.Value)})
+		case spec.TypedefDef:
+			if d.Decl.Kind != spec.DeclVoid {
+				js.Types = append(js.Types, jsonTypeDef{d.Decl.Name, f.pos(d.Decl.NamePos), f.jsonDecl(d.Decl)})
+			}
+		case spec.EnumDef:
+			js.Types = append(js.Types, jsonTypeDef{d.Name, f.pos(d.NamePos), f.jsonType(spec.EnumType{Items: d.Items})})
+		case spec.StructDef:
+			js.Types = append(js.Types, jsonTypeDef{d.Name, f.pos(d.NamePos), f.jsonType(spec.StructType{Fields: d.Fields})})
+		case spec.UnionDef:
+			js.Types = append(js.Types, jsonTypeDef{d.Name, f.pos(d.NamePos), f.jsonType(d.Union)})
+		case spec.ProgramDef:
+			js.Programs = append(js.Programs, f.jsonProgram(d))
+		}
+	}
+
+	buf, err := json.MarshalIndent(js, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, append(buf, '\n'), 0666)
+}
+
+func (f *specFile) pos(p token.Pos) string {
+	return f.Fset.Position(p).String()
+}
+
+func (f *specFile) jsonValue(v spec.Value) jsonValue {
+	jv := jsonValue{Value: f.Value(v)}
+	if v.Ident {
+		jv.Name = v.Text
+	}
+	return jv
+}
+
+func (f *specFile) jsonSize(v *spec.Value) *jsonValue {
+	if v == nil {
+		return nil
+	}
+	jv := f.jsonValue(*v)
+	return &jv
+}
+
+func (f *specFile) jsonDecl(d spec.Decl) *jsonType {
+	var t *jsonType
+	switch d.Kind {
+	case spec.DeclVoid:
+		return nil
+	case spec.DeclPlain:
+		return f.jsonType(d.Type)
+	case spec.DeclFixedArray:
+		t = &jsonType{Kind: "array", Elem: f.jsonType(d.Type), Length: f.jsonSize(d.Size)}
+	case spec.DeclVarArray:
+		t = &jsonType{Kind: "var_array", Elem: f.jsonType(d.Type), Max: f.jsonSize(d.Size)}
+	case spec.DeclFixedOpaque:
+		t = &jsonType{Kind: "opaque", Length: f.jsonSize(d.Size)}
+	case spec.DeclVarOpaque:
+		t = &jsonType{Kind: "var_opaque", Max: f.jsonSize(d.Size)}
+	case spec.DeclString:
+		t = &jsonType{Kind: "string", Max: f.jsonSize(d.Size)}
+	case spec.DeclOptional:
+		t = &jsonType{Kind: "optional", Elem: f.jsonType(d.Type)}
+	}
+	t.Size, _ = f.DeclFixedSize(d)
+	return t
+}
+
+func (f *specFile) jsonField(d spec.Decl) jsonField {
+	return jsonField{d.Name, f.jsonDecl(d)}
+}
+
+func (f *specFile) jsonType(t spec.Type) *jsonType {
+	if t == nil {
+		return nil
+	}
+
+	var res *jsonType
+	switch t := t.(type) {
+	case spec.IntType:
+		res = &jsonType{Kind: "int"}
+		if t.Unsigned {
+			res.Kind = "unsigned int"
+		}
+	case spec.HyperType:
+		res = &jsonType{Kind: "hyper"}
+		if t.Unsigned {
+			res.Kind = "unsigned hyper"
+		}
+	case spec.FloatType:
+		res = &jsonType{Kind: "float"}
+	case spec.DoubleType:
+		res = &jsonType{Kind: "double"}
+	case spec.QuadrupleType:
+		res = &jsonType{Kind: "quadruple"}
+	case spec.BoolType:
+		res = &jsonType{Kind: "bool"}
+	case spec.EnumType:
+		res = &jsonType{Kind: "enum"}
+		for _, item := range t.Items {
+			res.Items = append(res.Items, jsonValue{item.Name, f.Value(item.Value)})
+		}
+	case spec.StructType:
+		res = &jsonType{Kind: "struct"}
+		for _, d := range t.Fields {
+			res.Fields = append(res.Fields, f.jsonField(d))
+		}
+	case spec.UnionType:
+		sw := f.jsonField(t.Switch)
+		res = &jsonType{Kind: "union", Switch: &sw}
+		for _, c := range t.Cases {
+			var values []jsonValue
+			for _, v := range c.Values {
+				values = append(values, f.jsonValue(v))
+			}
+			res.Cases = append(res.Cases, jsonCase{values, f.jsonField(c.Decl)})
+		}
+		if t.Default != nil {
+			def := f.jsonField(*t.Default)
+			res.Default = &def
+		}
+	case spec.NamedType:
+		res = &jsonType{Kind: "named", Name: t.Name}
+	default:
+		panic(fmt.Sprintf("unexpected type %T", t))
+	}
+	res.Size, _ = f.FixedSize(t)
+	return res
+}
+
+func (f *specFile) jsonProgram(d spec.ProgramDef) jsonProgram {
+	p := jsonProgram{d.Name, f.pos(d.NamePos), f.Value(d.Value), []jsonVersion{}}
+	for _, v := range d.Versions {
+		jv := jsonVersion{v.Name, f.pos(v.NamePos), f.Value(v.Value), []jsonProcedure{}}
+		for _, c := range v.Procs {
+			jv.Procedures = append(jv.Procedures, jsonProcedure{
+				Name:   c.Name,
+				Pos:    f.pos(c.NamePos),
+				Number: f.Value(c.Value),
+				Arg:    f.jsonType(c.Arg),
+				Result: f.jsonType(c.Result),
+			})
+		}
+		p.Versions = append(p.Versions, jv)
+	}
+	return p
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Each declType and typespec can compare and copy its values.  goEqual
+// returns statements that return false if the values at a and b have
+// different XDR encodings.  goClone returns statements that deep-copy
+// the value at src into dst.
+
+func scalarEqual(a, b string) string {
+	return fmt.Sprintf("if *(%s) != *(%s) {\nreturn false\n}\n", a, b)
+}
+
+func scalarClone(dst, src string) string {
+	return fmt.Sprintf("*(%s) = *(%s)\n", dst, src)
+}
+
+func (t declTypeTypespec) goEqual(a, b string) string {
+	return t.t.goEqual(a, b)
+}
+
+func (t declTypeTypespec) goClone(dst, src string) string {
+	return t.t.goClone(dst, src)
+}
+
+func (t declTypeArray) goEqual(a, b string) string {
+	var res string
+	res += fmt.Sprintf("for i := range *(%s) {\n", a)
+	res += t.t.goEqual(fmt.Sprintf("&((*(%s))[i])", a), fmt.Sprintf("&((*(%s))[i])", b))
+	res += fmt.Sprintf("}\n")
+	return res
+}
+
+func (t declTypeArray) goClone(dst, src string) string {
+	var res string
+	res += fmt.Sprintf("for i := range *(%s) {\n", src)
+	res += t.t.goClone(fmt.Sprintf("&((*(%s))[i])", dst), fmt.Sprintf("&((*(%s))[i])", src))
+	res += fmt.Sprintf("}\n")
+	return res
+}
+
+// Nil and empty var-arrays have the same encoding, so they are equal,
+// but Clone preserves the difference.
+func (t declTypeVarArray) goEqual(a, b string) string {
+	var res string
+	res += fmt.Sprintf("if len(*(%s)) != len(*(%s)) {\nreturn false\n}\n", a, b)
+	res += fmt.Sprintf("for i := range *(%s) {\n", a)
+	res += t.t.goEqual(fmt.Sprintf("&((*(%s))[i])", a), fmt.Sprintf("&((*(%s))[i])", b))
+	res += fmt.Sprintf("}\n")
+	return res
+}
+
+func (t declTypeVarArray) goClone(dst, src string) string {
+	var res string
+	res += fmt.Sprintf("if *(%s) != nil {\n", src)
+	res += fmt.Sprintf("*(%s) = make([]%s, len(*(%s)))\n", dst, t.t.goType(), src)
+	res += fmt.Sprintf("for i := range *(%s) {\n", src)
+	res += t.t.goClone(fmt.Sprintf("&((*(%s))[i])", dst), fmt.Sprintf("&((*(%s))[i])", src))
+	res += fmt.Sprintf("}\n")
+	res += fmt.Sprintf("}\n")
+	return res
+}
+
+func (t declTypeOpaqueArray) goEqual(a, b string) string {
+	return scalarEqual(a, b)
+}
+
+func (t declTypeOpaqueArray) goClone(dst, src string) string {
+	return scalarClone(dst, src)
+}
+
+func (t declTypeOpaqueVarArray) goEqual(a, b string) string {
+	return fmt.Sprintf("if !bytes.Equal(*(%s), *(%s)) {\nreturn false\n}\n", a, b)
+}
+
+func (t declTypeOpaqueVarArray) goClone(dst, src string) string {
+	var res string
+	res += fmt.Sprintf("if *(%s) != nil {\n", src)
+	res += fmt.Sprintf("*(%s) = append([]byte{}, *(%s)...)\n", dst, src)
+	res += fmt.Sprintf("}\n")
+	return res
+}
+
+func (t declTypeString) goEqual(a, b string) string {
+	return scalarEqual(a, b)
+}
+
+func (t declTypeString) goClone(dst, src string) string {
+	return scalarClone(dst, src)
+}
+
+func (t declTypePtr) goEqual(a, b string) string {
+	var res string
+	if isListSlice(t.t) {
+		res += fmt.Sprintf("if len(*(%s)) != len(*(%s)) {\nreturn false\n}\n", a, b)
+		res += fmt.Sprintf("for idx := range *(%s) {\n", a)
+		res += t.t.goEqual(fmt.Sprintf("&(*(%s))[idx]", a), fmt.Sprintf("&(*(%s))[idx]", b))
+		res += fmt.Sprintf("}\n")
+		return res
+	}
+
+	res += fmt.Sprintf("if (*(%s) == nil) != (*(%s) == nil) {\nreturn false\n}\n", a, b)
+	res += fmt.Sprintf("if *(%s) != nil {\n", a)
+	res += t.t.goEqual(fmt.Sprintf("*(%s)", a), fmt.Sprintf("*(%s)", b))
+	res += fmt.Sprintf("}\n")
+	return res
+}
+
+func (t declTypePtr) goClone(dst, src string) string {
+	var res string
+	if isListSlice(t.t) {
+		res += fmt.Sprintf("if *(%s) != nil {\n", src)
+		res += fmt.Sprintf("*(%s) = make([]%s, len(*(%s)))\n", dst, t.t.goType(), src)
+		res += fmt.Sprintf("for idx := range *(%s) {\n", src)
+		res += t.t.goClone(fmt.Sprintf("&(*(%s))[idx]", dst), fmt.Sprintf("&(*(%s))[idx]", src))
+		res += fmt.Sprintf("}\n")
+		res += fmt.Sprintf("}\n")
+		return res
+	}
+
+	res += fmt.Sprintf("if *(%s) != nil {\n", src)
+	res += fmt.Sprintf("*(%s) = new(%s)\n", dst, t.t.goType())
+	res += t.t.goClone(fmt.Sprintf("*(%s)", dst), fmt.Sprintf("*(%s)", src))
+	res += fmt.Sprintf("}\n")
+	return res
+}
+
+func (t typeInt) goEqual(a, b string) string       { return scalarEqual(a, b) }
+func (t typeHyper) goEqual(a, b string) string     { return scalarEqual(a, b) }
+func (t typeQuadruple) goEqual(a, b string) string { return scalarEqual(a, b) }
+func (t typeBool) goEqual(a, b string) string      { return scalarEqual(a, b) }
+func (t typeEnum) goEqual(a, b string) string      { return scalarEqual(a, b) }
+
+// Floats are compared by their encoding, so that NaNs are equal to
+// themselves and 0 differs from -0.
+func (t typeFloat) goEqual(a, b string) string {
+	return fmt.Sprintf("if math.Float32bits(float32(*(%s))) != math.Float32bits(float32(*(%s))) {\nreturn false\n}\n", a, b)
+}
+
+func (t typeDouble) goEqual(a, b string) string {
+	return fmt.Sprintf("if math.Float64bits(float64(*(%s))) != math.Float64bits(float64(*(%s))) {\nreturn false\n}\n", a, b)
+}
+
+func (t typeInt) goClone(dst, src string) string       { return scalarClone(dst, src) }
+func (t typeHyper) goClone(dst, src string) string     { return scalarClone(dst, src) }
+func (t typeFloat) goClone(dst, src string) string     { return scalarClone(dst, src) }
+func (t typeDouble) goClone(dst, src string) string    { return scalarClone(dst, src) }
+func (t typeQuadruple) goClone(dst, src string) string { return scalarClone(dst, src) }
+func (t typeBool) goClone(dst, src string) string      { return scalarClone(dst, src) }
+func (t typeEnum) goClone(dst, src string) string      { return scalarClone(dst, src) }
+
+func (t typeStruct) goEqual(a, b string) string {
+	var res string
+	for _, v := range t.items {
+		switch v := v.(type) {
+		case declName:
+			res += v.t.goEqual(fmt.Sprintf("&((%s).%s)", a, i(v.n)), fmt.Sprintf("&((%s).%s)", b, i(v.n)))
+		}
+	}
+	return res
+}
+
+func (t typeStruct) goClone(dst, src string) string {
+	var res string
+	for _, v := range t.items {
+		switch v := v.(type) {
+		case declName:
+			res += v.t.goClone(fmt.Sprintf("&((%s).%s)", dst, i(v.n)), fmt.Sprintf("&((%s).%s)", src, i(v.n)))
+		}
+	}
+	return res
+}
+
+// Only the arm selected by the discriminant is compared or copied.
+func (t typeUnion) goEqual(a, b string) string {
+	return t.armStmts(a, b, func(v declName, a, b string) string {
+		return v.t.goEqual(a, b)
+	})
+}
+
+func (t typeUnion) goClone(dst, src string) string {
+	// The switch is on the discriminant of the first argument, so
+	// pass src first.
+	return t.armStmts(src, dst, func(v declName, src, dst string) string {
+		return v.t.goClone(dst, src)
+	})
+}
+
+// armStmts applies fn to the discriminants of x and y, and then to
+// the arms selected by the discriminant of x.
+func (t typeUnion) armStmts(x, y string, fn func(v declName, x, y string) string) string {
+	sw, ok := t.switchDecl.(declName)
+	if !ok {
+		panic("void union switch")
+	}
+
+	field := func(v declName, val string) string {
+		return fmt.Sprintf("&((%s).%s)", val, i(v.n))
+	}
+
+	var res string
+	res += fn(sw, field(sw, x), field(sw, y))
+	res += fmt.Sprintf("switch (%s).%s {\n", x, i(sw.n))
+	for _, c := range t.cases.cases {
+		res += fmt.Sprintf("case %s:\n", strings.Join(c.cases, ", "))
+		if v, ok := c.body.(declName); ok {
+			res += fn(v, field(v, x), field(v, y))
+		}
+	}
+	if v, ok := t.cases.def.(declName); ok {
+		res += "default:\n"
+		res += fn(v, field(v, x), field(v, y))
+	}
+	res += "}\n"
+	return res
+}
+
+func (t typeIdent) goEqual(a, b string) string {
+	return fmt.Sprintf("if !(*%s)(%s).Equal((*%s)(%s)) {\nreturn false\n}\n", t.goType(), a, t.goType(), b)
+}
+
+func (t typeIdent) goClone(dst, src string) string {
+	return fmt.Sprintf("*(*%s)(%s) = (*%s)(%s).Clone()\n", t.goType(), dst, t.goType(), src)
+}
+
+// emitEqualClone emits the Equal and Clone methods of a named type,
+// given the statements that compare v with o and copy v into c.
+func emitEqualClone(ident string, equal string, clone string) {
+	fmt.Fprintf(out, "func (v *%s) Equal(o *%s) bool {\n", i(ident), i(ident))
+	fmt.Fprintf(out, "if v == nil || o == nil {\n")
+	fmt.Fprintf(out, "return v == o\n")
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "%s", equal)
+	fmt.Fprintf(out, "return true\n")
+	fmt.Fprintf(out, "}\n")
+
+	fmt.Fprintf(out, "func (v *%s) Clone() (c %s) {\n", i(ident), i(ident))
+	fmt.Fprintf(out, "%s", clone)
+	fmt.Fprintf(out, "return\n")
+	fmt.Fprintf(out, "}\n")
+}
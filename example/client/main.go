@@ -1,55 +1,52 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"strconv"
 
 	"github.com/zeldovich/go-rpcgen/rfc1057"
 	"github.com/zeldovich/go-rpcgen/rfc1813"
 	"github.com/zeldovich/go-rpcgen/xdr"
 )
 
-func pmap_client(host string, prog, vers uint32) *rfc1057.Client {
-	var cred rfc1057.Opaque_auth
-	cred.Flavor = rfc1057.AUTH_NONE
-
-	pmapc, err := net.Dial("tcp", fmt.Sprintf("%s:%d", host, rfc1057.PMAP_PORT))
+func pmap_client(ctx context.Context, host string, prog, vers uint32) *rfc1057.Client {
+	pmapc, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(rfc1057.PMAP_PORT))))
 	if err != nil {
 		panic(err)
 	}
 	defer pmapc.Close()
-	pmap := rfc1057.MakeClient(pmapc, rfc1057.PMAP_PROG, rfc1057.PMAP_VERS)
+	pmap := rfc1057.MakePMAP_PROG_PMAP_VERS_Client(rfc1057.MakeClient(pmapc, rfc1057.PMAP_PROG, rfc1057.PMAP_VERS))
 
 	arg := rfc1057.Mapping{
 		Prog: prog,
 		Vers: vers,
 		Prot: rfc1057.IPPROTO_TCP,
 	}
-	var res xdr.Uint32
-	err = pmap.Call(rfc1057.PMAPPROC_GETPORT, cred, cred, &arg, &res)
+	port, err := pmap.PMAPPROC_GETPORT(ctx, arg)
 	if err != nil {
 		panic(err)
 	}
 
-	svcc, err := net.Dial("tcp", fmt.Sprintf("%s:%d", host, res))
+	svcc, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(port))))
 	if err != nil {
 		panic(err)
 	}
 	return rfc1057.MakeClient(svcc, prog, vers)
 }
 
-func lookup(c *rfc1057.Client, cred, verf rfc1057.Opaque_auth, fh rfc1813.Nfs_fh3, name string) rfc1813.Nfs_fh3 {
+func lookup(ctx context.Context, nfs *rfc1813.NFS_PROGRAM_NFS_V3_Client, fh rfc1813.Nfs_fh3, name string) rfc1813.Nfs_fh3 {
 	var arg rfc1813.LOOKUP3args
-	var res rfc1813.LOOKUP3res
 	arg.What.Dir = fh
 	arg.What.Name = rfc1813.Filename3(name)
-	err := c.Call(rfc1813.NFSPROC3_LOOKUP, cred, verf, &arg, &res)
+	res, err := nfs.NFSPROC3_LOOKUP(ctx, arg)
 	if err != nil {
 		panic(err)
 	}
 
 	if res.Status != rfc1813.NFS3_OK {
-		panic(fmt.Sprintf("lookup status %d", res.Status))
+		panic(fmt.Sprintf("lookup status %v", res.Status))
 	}
 
 	return res.Resok.Object
@@ -57,6 +54,7 @@ func lookup(c *rfc1057.Client, cred, verf rfc1057.Opaque_auth, fh rfc1813.Nfs_fh
 
 func main() {
 	var err error
+	ctx := context.Background()
 
 	var unix rfc1057.Auth_unix
 	var cred_unix rfc1057.Opaque_auth
@@ -69,42 +67,42 @@ func main() {
 	var cred_none rfc1057.Opaque_auth
 	cred_none.Flavor = rfc1057.AUTH_NONE
 
-	mnt := pmap_client("localhost", rfc1813.MOUNT_PROGRAM, rfc1813.MOUNT_V3)
+	mntc := pmap_client(ctx, "localhost", rfc1813.MOUNT_PROGRAM, rfc1813.MOUNT_V3)
+	mnt := rfc1813.MakeMOUNT_PROGRAM_MOUNT_V3_Client(mntc)
 
-	arg := rfc1813.Dirpath3("/")
-	var res rfc1813.Mountres3
-	err = mnt.Call(rfc1813.MOUNTPROC3_MNT, cred_none, cred_none, &arg, &res)
+	res, err := mnt.MOUNTPROC3_MNT(ctx, rfc1813.Dirpath3("/"))
 	if err != nil {
 		panic(err)
 	}
 
 	if res.Fhs_status != rfc1813.MNT3_OK {
-		panic(fmt.Sprintf("mount status %d", res.Fhs_status))
+		panic(fmt.Sprintf("mount status %v", res.Fhs_status))
 	}
 
 	var root_fh rfc1813.Nfs_fh3
 	root_fh.Data = res.Mountinfo.Fhandle
 
 	for _, flavor := range res.Mountinfo.Auth_flavors {
-		fmt.Printf("flavor %d\n", flavor)
+		fmt.Printf("flavor %v\n", flavor)
 	}
 
 	fmt.Printf("root fh %v\n", root_fh)
 
-	nfs := pmap_client("localhost", rfc1813.NFS_PROGRAM, rfc1813.NFS_V3)
+	nfsc := pmap_client(ctx, "localhost", rfc1813.NFS_PROGRAM, rfc1813.NFS_V3)
+	nfsc.SetAuth(cred_unix, cred_none)
+	nfs := rfc1813.MakeNFS_PROGRAM_NFS_V3_Client(nfsc)
 
-	foo := lookup(nfs, cred_unix, cred_none, root_fh, "foo")
-	bar := lookup(nfs, cred_unix, cred_none, foo, "bar")
+	foo := lookup(ctx, nfs, root_fh, "foo")
+	bar := lookup(ctx, nfs, foo, "bar")
 
 	fmt.Printf("bar = %v\n", bar)
 
 	var arg1 rfc1813.RENAME3args
-	var res1 rfc1813.RENAME3res
 	arg1.From.Dir = root_fh
 	arg1.From.Name = "foo"
 	arg1.To.Dir = bar
 	arg1.To.Name = "foonew"
-	err = nfs.Call(rfc1813.NFSPROC3_RENAME, cred_unix, cred_none, &arg1, &res1)
+	res1, err := nfs.NFSPROC3_RENAME(ctx, arg1)
 	if err != nil {
 		panic(err)
 	}
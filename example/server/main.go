@@ -1,24 +1,23 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net"
 
 	"github.com/zeldovich/go-rpcgen/rfc1057"
 	"github.com/zeldovich/go-rpcgen/rfc1813"
-	"github.com/zeldovich/go-rpcgen/xdr"
 )
 
 func pmap_set_unset(prog, vers, port uint32, setit bool) bool {
-	var cred rfc1057.Opaque_auth
-	cred.Flavor = rfc1057.AUTH_NONE
+	ctx := context.Background()
 
 	pmapc, err := net.Dial("tcp", fmt.Sprintf("localhost:%d", rfc1057.PMAP_PORT))
 	if err != nil {
 		panic(err)
 	}
 	defer pmapc.Close()
-	pmap := rfc1057.MakeClient(pmapc, rfc1057.PMAP_PROG, rfc1057.PMAP_VERS)
+	pmap := rfc1057.MakePMAP_PROG_PMAP_VERS_Client(rfc1057.MakeClient(pmapc, rfc1057.PMAP_PROG, rfc1057.PMAP_VERS))
 
 	arg := rfc1057.Mapping{
 		Prog: prog,
@@ -27,15 +26,12 @@ func pmap_set_unset(prog, vers, port uint32, setit bool) bool {
 		Port: port,
 	}
 
-	var res xdr.Bool
-	var proc uint32
+	var res rfc1057.Xbool
 	if setit {
-		proc = rfc1057.PMAPPROC_SET
+		res, err = pmap.PMAPPROC_SET(ctx, arg)
 	} else {
-		proc = rfc1057.PMAPPROC_UNSET
+		res, err = pmap.PMAPPROC_UNSET(ctx, arg)
 	}
-
-	err = pmap.Call(proc, cred, cred, &arg, &res)
 	if err != nil {
 		panic(err)
 	}
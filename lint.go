@@ -0,0 +1,268 @@
+package main
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+
+	"github.com/zeldovich/go-rpcgen/spec"
+)
+
+// lint implements go-rpcgen lint file.x...  On top of the errors that
+// code generation reports, it checks for union case labels that are not
+// members of the discriminant's enum, enum items with the same value,
+// unbounded strings and opaque data in procedure arguments, unused
+// typedefs, and identifiers that have the same Go name.  Diagnostics
+// are returned as a scanner.ErrorList.
+func lint(files []string) error {
+	if len(files) == 0 {
+		return fmt.Errorf("usage: go-rpcgen lint [flags] file.x...")
+	}
+
+	err := loadNames()
+	if err != nil {
+		return err
+	}
+
+	var errs scanner.ErrorList
+	for _, filename := range files {
+		f, err := parseFile(filename)
+		if list, ok := err.(scanner.ErrorList); ok {
+			errs = append(errs, list...)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		checkNames(f)
+		f.lintEnums()
+		f.lintUnions()
+		f.lintArgs()
+		f.lintTypedefs()
+		errs = append(errs, f.errs...)
+	}
+
+	errs.Sort()
+	return errs.Err()
+}
+
+// walkTypes calls fn for every type in d, including the types of
+// nested declarations, and the enum, struct or union that d defines.
+func walkTypes(d spec.Def, fn func(t spec.Type)) {
+	switch d := d.(type) {
+	case spec.TypedefDef:
+		walkDeclTypes(d.Decl, fn)
+	case spec.EnumDef:
+		fn(spec.EnumType{Items: d.Items})
+	case spec.StructDef:
+		walkTypeTypes(spec.StructType{Fields: d.Fields}, fn)
+	case spec.UnionDef:
+		walkTypeTypes(d.Union, fn)
+	case spec.ProgramDef:
+		for _, v := range d.Versions {
+			for _, p := range v.Procs {
+				walkTypeTypes(p.Arg, fn)
+				walkTypeTypes(p.Result, fn)
+			}
+		}
+	}
+}
+
+func walkDeclTypes(d spec.Decl, fn func(t spec.Type)) {
+	walkTypeTypes(d.Type, fn)
+}
+
+func walkTypeTypes(t spec.Type, fn func(t spec.Type)) {
+	if t == nil {
+		return
+	}
+
+	fn(t)
+	switch t := t.(type) {
+	case spec.StructType:
+		for _, d := range t.Fields {
+			walkDeclTypes(d, fn)
+		}
+	case spec.UnionType:
+		walkDeclTypes(t.Switch, fn)
+		for _, c := range t.Cases {
+			walkDeclTypes(c.Decl, fn)
+		}
+		if t.Default != nil {
+			walkDeclTypes(*t.Default, fn)
+		}
+	}
+}
+
+// lintEnums reports enum items that have the same value as an earlier
+// item of the same enum.
+func (f *specFile) lintEnums() {
+	for _, d := range f.Defs {
+		walkTypes(d, func(t spec.Type) {
+			e, ok := t.(spec.EnumType)
+			if !ok {
+				return
+			}
+
+			seen := make(map[string]string)
+			for _, item := range e.Items {
+				n := f.Value(item.Value)
+				if n == nil {
+					continue
+				}
+				if other, ok := seen[n.String()]; ok {
+					f.errorf(item.NamePos, "enum item %s has the same value %s as %s", item.Name, n, other)
+					continue
+				}
+				seen[n.String()] = item.Name
+			}
+		})
+	}
+}
+
+// enumOf returns the items of the enum that t refers to, and its name,
+// or false if t is not an enum defined in the spec.
+func (f *specFile) enumOf(t spec.Type) ([]spec.EnumItem, string, bool) {
+	// Follow typedefs, as in typedef color paint, but not around
+	// a cycle.
+	for depth := 0; depth < len(f.Defs); depth++ {
+		switch tt := t.(type) {
+		case spec.EnumType:
+			return tt.Items, "", true
+		case spec.NamedType:
+			switch d := f.TypeDef(tt.Name).(type) {
+			case spec.EnumDef:
+				return d.Items, d.Name, true
+			case spec.TypedefDef:
+				if d.Decl.Kind != spec.DeclPlain {
+					return nil, "", false
+				}
+				t = d.Decl.Type
+				continue
+			}
+		}
+		return nil, "", false
+	}
+	return nil, "", false
+}
+
+// lintUnions reports case labels that are not members of the enum that
+// the union switches on.
+func (f *specFile) lintUnions() {
+	for _, d := range f.Defs {
+		walkTypes(d, func(t spec.Type) {
+			u, ok := t.(spec.UnionType)
+			if !ok {
+				return
+			}
+			items, name, ok := f.enumOf(u.Switch.Type)
+			if !ok {
+				return
+			}
+			if name == "" {
+				name = u.Switch.Name
+			}
+
+			names := make(map[string]bool)
+			values := make(map[string]bool)
+			for _, item := range items {
+				names[item.Name] = true
+				if n := f.Value(item.Value); n != nil {
+					values[n.String()] = true
+				}
+			}
+
+			for _, c := range u.Cases {
+				for _, v := range c.Values {
+					if v.Ident {
+						if !names[v.Text] {
+							f.errorf(v.Pos, "case %s is not a member of enum %s", v.Text, name)
+						}
+						continue
+					}
+					if n := f.Value(v); n != nil && !values[n.String()] {
+						f.errorf(v.Pos, "case %s is not a value of enum %s", n, name)
+					}
+				}
+			}
+		})
+	}
+}
+
+// lintArgs reports strings and opaque data without a maximum size that
+// can be sent as procedure arguments, since a server would accept
+// arbitrarily large requests.
+func (f *specFile) lintArgs() {
+	reported := make(map[token.Pos]bool)
+	for _, d := range f.Defs {
+		prog, ok := d.(spec.ProgramDef)
+		if !ok {
+			continue
+		}
+		for _, v := range prog.Versions {
+			for _, p := range v.Procs {
+				visited := make(map[string]bool)
+				var check func(t spec.Type)
+				checkDecl := func(d spec.Decl) {
+					if (d.Kind == spec.DeclString || d.Kind == spec.DeclVarOpaque) && d.Size == nil && !reported[d.NamePos] {
+						reported[d.NamePos] = true
+						what := "string"
+						if d.Kind == spec.DeclVarOpaque {
+							what = "opaque"
+						}
+						f.errorf(d.NamePos, "unbounded %s %s in arguments of %s", what, d.Name, p.Name)
+					}
+					check(d.Type)
+				}
+				check = func(t spec.Type) {
+					switch t := t.(type) {
+					case spec.NamedType:
+						if visited[t.Name] {
+							return
+						}
+						visited[t.Name] = true
+						switch d := f.TypeDef(t.Name).(type) {
+						case spec.TypedefDef:
+							checkDecl(d.Decl)
+						case spec.StructDef:
+							check(spec.StructType{Fields: d.Fields})
+						case spec.UnionDef:
+							check(d.Union)
+						}
+					case spec.StructType:
+						for _, d := range t.Fields {
+							checkDecl(d)
+						}
+					case spec.UnionType:
+						for _, c := range t.Cases {
+							checkDecl(c.Decl)
+						}
+						if t.Default != nil {
+							checkDecl(*t.Default)
+						}
+					}
+				}
+				check(p.Arg)
+			}
+		}
+	}
+}
+
+// lintTypedefs reports typedefs that no other definition refers to.
+func (f *specFile) lintTypedefs() {
+	used := make(map[string]bool)
+	for _, d := range f.Defs {
+		walkTypes(d, func(t spec.Type) {
+			if t, ok := t.(spec.NamedType); ok {
+				used[t.Name] = true
+			}
+		})
+	}
+
+	for _, d := range f.Defs {
+		if d, ok := d.(spec.TypedefDef); ok && d.Decl.Kind != spec.DeclVoid && !used[d.Decl.Name] {
+			f.errorf(d.Decl.NamePos, "typedef %s is unused", d.Decl.Name)
+		}
+	}
+}
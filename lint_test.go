@@ -0,0 +1,65 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	bad := filepath.Join(dir, "bad.x")
+	err = ioutil.WriteFile(bad, []byte(`enum color { RED = 0, GREEN = 0 };
+enum shape { SQUARE = 0, CIRCLE = 1 };
+union u switch (shape s) {
+case RED:
+  int a;
+case CIRCLE:
+  void;
+};
+typedef int unused_t;
+struct args { string name<>; };
+struct point { int x; };
+struct Point { int y; };
+program P { version V { void A(args) = 1; void B(point) = 2; void C(Point) = 3; } = 1; } = 0x20000001;
+`), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	good := filepath.Join(dir, "good.x")
+	err = ioutil.WriteFile(good, []byte("struct ok { int a; string s<16>; };\nprogram P { version V { void A(ok) = 1; } = 1; } = 0x20000001;\n"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := rpcgen("lint", good)
+	if err != nil {
+		t.Errorf("lint of a clean spec failed: %v\n%s", err, out)
+	}
+
+	out, err = rpcgen("lint", good, bad)
+	if err == nil {
+		t.Fatal("lint did not fail")
+	}
+	for _, want := range []string{
+		"bad.x:1:23: enum item GREEN has the same value 0 as RED",
+		"bad.x:4:6: case RED is not a member of enum shape",
+		"bad.x:9:13: typedef unused_t is unused",
+		"bad.x:10:22: unbounded string name in arguments of A",
+		"bad.x:12:8: point and Point both have Go name Point",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "good.x") {
+		t.Errorf("problems reported in clean spec:\n%s", out)
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+)
+
+// A listLink describes an XDR optional-data list: a struct whose last
+// field is a pointer to the struct itself, either directly or through
+// a pointer typedef (as in typedef elem *list).
+type listLink struct {
+	field string
+
+	// viaTypedef is set if the field is declared with a pointer
+	// typedef, which is generated as a struct holding P.
+	viaTypedef bool
+}
+
+// listLinks maps the names of list structs onto their link fields.
+var listLinks = make(map[string]listLink)
+
+// findLists fills in listLinks.  With -list-slices, a list struct
+// becomes the element type of a slice, so it is an error to refer to
+// it other than through a pointer.
+func findLists(f *specFile) {
+	ptrTypedefs := make(map[string]string)
+	for _, d := range f.defs {
+		if d, ok := d.(typedefDef); ok {
+			if v, ok := d.d.(declName); ok {
+				if p, ok := v.t.(declTypePtr); ok {
+					if t, ok := p.t.(typeIdent); ok {
+						ptrTypedefs[v.n] = t.n
+					}
+				}
+			}
+		}
+	}
+
+	for _, d := range f.defs {
+		s, ok := d.(structDef)
+		if !ok || len(s.items) == 0 {
+			continue
+		}
+		last, ok := s.items[len(s.items)-1].(declName)
+		if !ok {
+			continue
+		}
+
+		switch t := last.t.(type) {
+		case declTypePtr:
+			if ti, ok := t.t.(typeIdent); ok && ti.n == s.name {
+				listLinks[s.name] = listLink{field: last.n}
+			}
+		case declTypeTypespec:
+			if ti, ok := t.t.(typeIdent); ok && ptrTypedefs[ti.n] == s.name {
+				listLinks[s.name] = listLink{field: last.n, viaTypedef: true}
+			}
+		}
+	}
+
+	if !*listSlicesFlag {
+		return
+	}
+	for _, d := range f.defs {
+		walkTypeIdents(d, func(t typeIdent, ptr bool) {
+			if _, ok := listLinks[t.n]; ok && !ptr {
+				f.errorf(t.pos, "list type %s can only be used through a pointer with -list-slices", t.n)
+			}
+		})
+	}
+}
+
+// isListSlice reports whether a pointer to t is generated as a slice.
+func isListSlice(t typespec) bool {
+	if !*listSlicesFlag {
+		return false
+	}
+	ti, ok := t.(typeIdent)
+	if !ok {
+		return false
+	}
+	_, ok = listLinks[ti.n]
+	return ok
+}
+
+// listSliceXdr encodes or decodes the slice at valPtr as the XDR list
+// whose elements have type t.
+func listSliceXdr(t typespec, valPtr string) string {
+	var res string
+	res += fmt.Sprintf("if xs.Encoding() {\n")
+	res += fmt.Sprintf("for idx := range *(%s) {\n", valPtr)
+	res += fmt.Sprintf("opted := true\n")
+	res += typeBool{}.goXdr("&opted")
+	res += t.goXdr(fmt.Sprintf("&(*(%s))[idx]", valPtr))
+	res += fmt.Sprintf("}\n")
+	res += fmt.Sprintf("opted := false\n")
+	res += typeBool{}.goXdr("&opted")
+	res += fmt.Sprintf("}\n")
+
+	res += fmt.Sprintf("if xs.Decoding() {\n")
+	res += fmt.Sprintf("*(%s) = nil\n", valPtr)
+	res += fmt.Sprintf("for {\n")
+	res += fmt.Sprintf("var opted bool\n")
+	res += typeBool{}.goXdr("&opted")
+	res += fmt.Sprintf("if !opted || xs.Error() != nil {\n")
+	res += fmt.Sprintf("break\n")
+	res += fmt.Sprintf("}\n")
+	res += fmt.Sprintf("var elem %s\n", t.goType())
+	res += t.goXdr("&elem")
+	res += fmt.Sprintf("*(%s) = append(*(%s), elem)\n", valPtr, valPtr)
+	res += fmt.Sprintf("}\n")
+	res += fmt.Sprintf("}\n")
+	return res
+}
+
+// emitListStruct emits a list struct.  Its Xdr method walks the list
+// in a loop rather than recursing through the link field, so that long
+// lists cannot exhaust the stack.  With -list-slices, the struct is
+// only the element type and the link field is left out.
+func emitListStruct(ident string, val []decl, link listLink) {
+	items := val
+	if *listSlicesFlag {
+		items = val[:len(val)-1]
+	}
+
+	emitDoc(tout, docs[ident])
+	fmt.Fprintf(tout, "type %s struct {\n", i(ident))
+	for _, v := range items {
+		switch v := v.(type) {
+		case declName:
+			emitDoc(tout, v.doc)
+			fmt.Fprintf(tout, "  %s %s;\n", i(v.n), v.t.goType())
+		}
+	}
+	fmt.Fprintf(tout, "}\n")
+
+	fmt.Fprintf(out, "func (v *%s) Xdr(xs *xdr.XdrState) {\n", i(ident))
+	if *listSlicesFlag {
+		fmt.Fprintf(out, "%s", typeStruct{items}.goXdr("v"))
+		fmt.Fprintf(out, "}\n")
+		emitXdrSize(ident, typeStruct{items}.goSize("v"))
+		emitEqualClone(ident, typeStruct{items}.goEqual("v", "o"), typeStruct{items}.goClone("&c", "v"))
+		return
+	}
+
+	next := fmt.Sprintf("v.%s", i(link.field))
+	if link.viaTypedef {
+		next += ".P"
+	}
+
+	fmt.Fprintf(out, "for {\n")
+	fmt.Fprintf(out, "%s", typeStruct{val[:len(val)-1]}.goXdr("v"))
+	fmt.Fprintf(out, "var opted bool\n")
+	fmt.Fprintf(out, "if xs.Encoding() {\n")
+	fmt.Fprintf(out, "opted = %s != nil\n", next)
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "%s", typeBool{}.goXdr("&opted"))
+	fmt.Fprintf(out, "if !opted || xs.Error() != nil {\n")
+	fmt.Fprintf(out, "return\n")
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "if xs.Decoding() {\n")
+	fmt.Fprintf(out, "%s = new(%s)\n", next, i(ident))
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "v = %s\n", next)
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "}\n")
+
+	fmt.Fprintf(out, "func (v *%s) XdrSize() (n int) {\n", i(ident))
+	fmt.Fprintf(out, "for v != nil {\n")
+	fmt.Fprintf(out, "%s", typeStruct{val[:len(val)-1]}.goSize("v"))
+	fmt.Fprintf(out, "n += 4\n")
+	fmt.Fprintf(out, "v = %s\n", next)
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "return\n")
+	fmt.Fprintf(out, "}\n")
+	body := typeStruct{val[:len(val)-1]}
+	onext := "o" + next[1:]
+	dnext := "d" + next[1:]
+
+	fmt.Fprintf(out, "func (v *%s) Equal(o *%s) bool {\n", i(ident), i(ident))
+	fmt.Fprintf(out, "for {\n")
+	fmt.Fprintf(out, "if v == nil || o == nil {\n")
+	fmt.Fprintf(out, "return v == o\n")
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "%s", body.goEqual("v", "o"))
+	fmt.Fprintf(out, "v, o = %s, %s\n", next, onext)
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "}\n")
+
+	fmt.Fprintf(out, "func (v *%s) Clone() (c %s) {\n", i(ident), i(ident))
+	fmt.Fprintf(out, "d := &c\n")
+	fmt.Fprintf(out, "for {\n")
+	fmt.Fprintf(out, "%s", body.goClone("d", "v"))
+	fmt.Fprintf(out, "if %s == nil {\n", next)
+	fmt.Fprintf(out, "return\n")
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "%s = new(%s)\n", dnext, i(ident))
+	fmt.Fprintf(out, "d, v = %s, %s\n", dnext, next)
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "}\n")
+}
@@ -1,13 +1,23 @@
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
+	"go/ast"
 	"go/format"
+	"go/parser"
+	"go/scanner"
 	"go/token"
 	"io"
 	"io/ioutil"
+	"math/big"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zeldovich/go-rpcgen/spec"
 )
 
 var inputFile = flag.String("i", "", "Input file (.x)")
@@ -16,12 +26,72 @@ var outputPackage = flag.String("p", "main", "Output package name")
 var typesFile = flag.String("t", "", "Output file for separate type definitions (optional")
 var debugFlag = flag.Bool("d", false, "Debug parsing")
 var unsignedEnumFlag = flag.Bool("unsigned-enum", false, "Unsigned integer enum types")
+var validateEnumFlag = flag.Bool("validate-enum", false, "Reject undeclared enum values when encoding and decoding")
+var handlerContextFlag = flag.Bool("handler-context", false, "Generate handlers that take a context and call information, and return an error")
+var sumUnionsFlag = flag.Bool("sum-unions", false, "Represent unions as a discriminant and an interface with one type per arm")
+var listSlicesFlag = flag.Bool("list-slices", false, "Represent optional-data lists (struct T { ...; T *next; }) as []T")
+var camelCaseFlag = flag.Bool("camel-case", false, "Convert snake_case and ALL_CAPS identifiers to CamelCase Go names")
+var nameMapFlag = flag.String("name-map", "", "File of \"xdr_name GoName\" lines overriding generated Go names (optional)")
+var templateFlag = flag.String("template", "", "Generate the output file from a text/template, which can call xdr for the built-in code (optional)")
+var emitASTFlag = flag.String("emit-ast", "", "Write the parsed spec to the output file in the given format (json) instead of Go code")
+var docFormatFlag = flag.String("doc-format", "markdown", "Format of the reference written by go-rpcgen doc (markdown or html)")
 var constTypeFlag = flag.String("const-type", "", "Optional type for const definitions")
+var includeFlag stringList
+var defineFlag stringList
+var importFlag stringList
+
+func init() {
+	flag.Var(&includeFlag, "I", "Add directory to #include search path (repeatable)")
+	flag.Var(&defineFlag, "D", "Define preprocessor macro, as NAME or NAME=VALUE (repeatable)")
+	flag.Var(&importFlag, "import", "Use types and constants from another spec, as spec.x=go/import/path (repeatable)")
+}
+
+// stringList is a flag.Value that accumulates repeated flags.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
 
 var out io.Writer
 var tout io.Writer
 
+// goPackages maps the package names that generated code refers to onto
+// their import paths.  Each output file imports the packages it uses.
+var goPackages = map[string]string{
+	"bytes":   "bytes",
+	"context": "context",
+	"fmt":     "fmt",
+	"math":    "math",
+	"xdr":     "github.com/zeldovich/go-rpcgen/xdr",
+}
+
+// commands are the subcommands, run as go-rpcgen command [flags] args.
+// They take the same flags as code generation.
+var commands = map[string]func(args []string) error{
+	"diff": diff,
+	"doc":  doc,
+	"lint": lint,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			flag.CommandLine.Parse(os.Args[2:])
+			err := cmd(flag.Args())
+			if err != nil {
+				scanner.PrintError(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	flag.Parse()
 
 	if *inputFile == "" {
@@ -34,69 +104,190 @@ func main() {
 		os.Exit(1)
 	}
 
-	src, err := ioutil.ReadFile(*inputFile)
+	err := generate()
 	if err != nil {
-		panic(err)
+		scanner.PrintError(os.Stderr, err)
+		os.Exit(1)
 	}
+}
 
-	fset := token.NewFileSet()
-	f := fset.AddFile(*inputFile, -1, len(src))
+// generate compiles the input file.  Errors in the input are returned
+// as a scanner.ErrorList, so that each is reported with its position.
+func generate() error {
+	if *emitASTFlag != "" && *emitASTFlag != "json" {
+		return fmt.Errorf("unknown -emit-ast format %q", *emitASTFlag)
+	}
 
-	var l lexer
-	l.s.Init(f, src, nil, 0)
+	err := loadNames()
+	if err != nil {
+		return err
+	}
 
-	outTmp := *outputFile + ".tmp"
-	outf, err := os.OpenFile(outTmp, os.O_WRONLY|os.O_EXCL|os.O_CREATE|os.O_TRUNC, 0666)
+	f, err := parseFile(*inputFile)
 	if err != nil {
-		panic(err)
+		return err
+	}
+
+	resolve(f)
+	findLists(f)
+	checkNames(f)
+	if len(f.errs) > 0 {
+		f.errs.Sort()
+		return f.errs.Err()
+	}
+
+	if *emitASTFlag == "json" {
+		return f.emitJSON(*outputFile)
+	}
+
+	var outBody, toutBody bytes.Buffer
+	out = &outBody
+	if *typesFile != "" {
+		tout = &toutBody
+	} else {
+		tout = out
 	}
 
-	defer os.Remove(outTmp)
-	out = outf
+	err = f.execTemplate(*templateFlag)
+	if err != nil {
+		return err
+	}
 
-	fmt.Fprintf(out, "package %s\n", *outputPackage)
-	fmt.Fprintf(out, "import \"github.com/zeldovich/go-rpcgen/xdr\"\n")
+	err = writeGoFile(*outputFile, outBody.Bytes())
+	if err != nil {
+		return err
+	}
 
-	var toutTmp string
-	var toutf *os.File
 	if *typesFile != "" {
-		toutTmp = *typesFile + ".tmp"
-		toutf, err = os.OpenFile(toutTmp, os.O_WRONLY|os.O_EXCL|os.O_CREATE|os.O_TRUNC, 0666)
+		err = writeGoFile(*typesFile, toutBody.Bytes())
 		if err != nil {
-			panic(err)
+			return err
 		}
+	}
 
-		defer os.Remove(toutTmp)
-		tout = toutf
+	return nil
+}
 
-		fmt.Fprintf(tout, "package %s\n", *outputPackage)
-	} else {
-		tout = outf
+// loadNames loads the -name-map file and the specs given with -import.
+func loadNames() error {
+	if *nameMapFlag != "" {
+		err := loadNameMap(*nameMapFlag)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, imp := range importFlag {
+		err := importSpec(imp)
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	xdrParse(&l)
-	outf.Close()
-	refmt(outTmp, *outputFile)
+// parseFile parses and checks a .x file, and converts its definitions.
+// It may refer to the types and constants of specs given with -import.
+func parseFile(filename string) (*specFile, error) {
+	defines := make(map[string]string)
+	for _, d := range defineFlag {
+		kv := strings.SplitN(d, "=", 2)
+		if len(kv) == 2 {
+			defines[kv[0]] = kv[1]
+		} else {
+			defines[kv[0]] = "1"
+		}
+	}
 
-	if *typesFile != "" {
-		toutf.Close()
-		refmt(toutTmp, *typesFile)
+	consts := make(map[string]*big.Int)
+	for name, c := range importedConsts {
+		consts[name] = c.n
+	}
+	types := make(map[string]bool)
+	for name := range importedTypes {
+		types[name] = true
+	}
+
+	cfg := &spec.Config{
+		IncludePath: includeFlag,
+		Defines:     defines,
+		Consts:      consts,
+		Types:       types,
+		Debug:       *debugFlag,
+	}
+	// Check the definitions that parsed even if others did not, to
+	// report all the errors at once.
+	s, err := spec.ParseFile(filename, cfg)
+	errs, ok := err.(scanner.ErrorList)
+	if err != nil && (!ok || s == nil) {
+		return nil, err
+	}
+	err = spec.Check(s, cfg)
+	if list, ok := err.(scanner.ErrorList); ok {
+		errs = append(errs, list...)
+	} else if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		errs.Sort()
+		return nil, errs.Err()
 	}
+
+	f := &specFile{Spec: s}
+	f.convert()
+	return f, nil
 }
 
-func refmt(infile string, outfile string) {
-	buf, err := ioutil.ReadFile(infile)
+// writeGoFile formats the generated code in body and writes it to
+// filename, adding imports for the packages in goPackages that the
+// code refers to.
+func writeGoFile(filename string, body []byte) error {
+	header := fmt.Sprintf("package %s\n", *outputPackage)
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, header+string(body), 0)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("generated code for %s: %v", filename, err)
 	}
 
-	buf, err = format.Source(buf)
-	if err != nil {
-		panic(err)
+	// Templates may import packages themselves.
+	imported := make(map[string]bool)
+	for _, imp := range f.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err == nil {
+			imported[path] = true
+		}
+	}
+
+	used := make(map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				if path, ok := goPackages[id.Name]; ok && !imported[path] {
+					used[path] = true
+				}
+			}
+		}
+		return true
+	})
+
+	var imports []string
+	for path := range used {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	for _, path := range imports {
+		fmt.Fprintf(&buf, "import %q\n", path)
 	}
+	buf.Write(body)
 
-	err = ioutil.WriteFile(outfile, buf, 0666)
+	src, err := format.Source(buf.Bytes())
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("formatting %s: %v", filename, err)
 	}
+
+	return ioutil.WriteFile(filename, src, 0666)
 }
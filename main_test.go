@@ -0,0 +1,1252 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMain runs go-rpcgen itself when GO_RPCGEN_MAIN is set, so that
+// tests can run the generator in a fresh process.
+func TestMain(m *testing.M) {
+	if os.Getenv("GO_RPCGEN_MAIN") != "" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// rpcgen runs go-rpcgen with args, and returns its output.
+func rpcgen(args ...string) (string, error) {
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), "GO_RPCGEN_MAIN=1")
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+// modulePath is the import path of this module.
+const modulePath = "github.com/zeldovich/go-rpcgen"
+
+// tempPackage returns a new directory for a Go package inside the
+// module, so that generated code in it can import xdr.  The directory
+// is removed when the test ends.
+func tempPackage(t *testing.T) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir(".", "testpkg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	return dir
+}
+
+// genSpec writes the spec src to dir/spec.x, and runs go-rpcgen on it
+// with the given flags to generate dir/xdr.go in package testpkg.
+func genSpec(t *testing.T, dir string, src string, flags ...string) {
+	t.Helper()
+
+	x := filepath.Join(dir, "spec.x")
+	err := ioutil.WriteFile(x, []byte(src), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := append([]string{"-i", x, "-o", filepath.Join(dir, "xdr.go"), "-p", "testpkg"}, flags...)
+	out, err := rpcgen(args...)
+	if err != nil {
+		t.Fatalf("go-rpcgen %v: %v\n%s", flags, err, out)
+	}
+}
+
+// compileSpec generates code for the spec src with the given flags,
+// and checks that it compiles.
+func compileSpec(t *testing.T, src string, flags ...string) {
+	t.Helper()
+
+	dir := tempPackage(t)
+	genSpec(t, dir, src, flags...)
+
+	cmd := exec.Command("go", "vet", "./"+filepath.Base(dir))
+	vet, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("generated code does not compile: %v\n%s", err, vet)
+	}
+}
+
+// testSpec generates code for the spec src with the given flags, and
+// runs the tests in test, the source of a test file for package
+// testpkg, against it.
+func testSpec(t *testing.T, src string, test string, flags ...string) {
+	t.Helper()
+
+	dir := tempPackage(t)
+	genSpec(t, dir, src, flags...)
+	runTests(t, dir, test)
+}
+
+// runTests adds the test file test to the package in dir and runs
+// its tests.
+func runTests(t *testing.T, dir string, test string) {
+	t.Helper()
+
+	err := ioutil.WriteFile(filepath.Join(dir, "spec_test.go"), []byte(test), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command("go", "test", "./"+filepath.Base(dir))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("tests of generated code failed: %v\n%s", err, out)
+	}
+}
+
+// Fixed-size arrays of types other than opaque are encoded element by
+// element, indexing through the pointer to the array.
+func TestFixedArrays(t *testing.T) {
+	compileSpec(t, `
+struct point {
+  int xy[2];
+  float f[3];
+  unsigned hyper h[2];
+};
+typedef point triangle[3];
+`)
+}
+
+// Anonymous enums in structs, unions and typedefs become named types
+// with their own constants.
+func TestInlineEnums(t *testing.T) {
+	src := `
+struct parent {
+  enum { RED = 0, GREEN = 1 } kind;
+  union switch (enum { A = 1, B = 2 } d) {
+  case A:
+    int a;
+  case B:
+    void;
+  } u;
+};
+typedef enum { X = 5, Y = 6 } letter;
+`
+	test := `package testpkg
+
+import (
+	"testing"
+
+	"github.com/zeldovich/go-rpcgen/xdr"
+)
+
+func TestRoundTrip(t *testing.T) {
+	var kind Parent_kind = GREEN
+	var d Parent_u_d = A
+	var l Letter = Y
+
+	in := Parent{Kind: kind}
+	in.U.D = d
+	in.U.A = 7
+	buf, err := xdr.EncodeBuf(&in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out Parent
+	err = xdr.DecodeBuf(buf, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("decoded %+v, want %+v", out, in)
+	}
+
+	buf, err = xdr.EncodeBuf(&l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf[3] != 6 {
+		t.Errorf("letter Y encoded as %v", buf)
+	}
+}
+`
+	testSpec(t, src, test)
+	compileSpec(t, src, "-unsigned-enum")
+}
+
+// With -validate-enum, undeclared enum values are rejected when
+// encoding and decoding; without it, they are passed through.
+func TestValidateEnum(t *testing.T) {
+	src := `
+enum color { RED = 0, GREEN = 1 };
+struct paint { color c; };
+`
+	test := `package testpkg
+
+import (
+	"testing"
+
+	"github.com/zeldovich/go-rpcgen/xdr"
+)
+
+func TestValid(t *testing.T) {
+	if !GREEN.Valid() || Color(7).Valid() {
+		t.Error("Valid does not match the declared values")
+	}
+
+	_, err := xdr.EncodeBuf(&Paint{C: 7})
+	if (err != nil) != validate {
+		t.Errorf("encoding color 7 returned %v", err)
+	}
+
+	var p Paint
+	err = xdr.DecodeBuf([]byte{0, 0, 0, 7}, &p)
+	if (err != nil) != validate {
+		t.Errorf("decoding color 7 returned %v", err)
+	}
+
+	buf, err := xdr.EncodeBuf(&Paint{C: GREEN})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = xdr.DecodeBuf(buf, &p)
+	if err != nil || p.C != GREEN {
+		t.Errorf("decoding GREEN returned %v, %v", p.C, err)
+	}
+}
+`
+	testSpec(t, src, test+"\nconst validate = true\n", "-validate-enum")
+	testSpec(t, src, test+"\nconst validate = false\n")
+}
+
+// Enums have String methods, and ProcName names the procedures of
+// every program.
+func TestNames(t *testing.T) {
+	src := `
+enum color { RED = 0, GREEN = 1 };
+program P {
+  version V {
+    void NULLPROC(void) = 0;
+    color GET(color) = 1;
+  } = 1;
+} = 0x20000001;
+`
+	test := `package testpkg
+
+import (
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	for _, c := range []struct {
+		got, want string
+	}{
+		{GREEN.String(), "GREEN"},
+		{Color(7).String(), "Color(7)"},
+		{ProcName(P, V, GET), "P/V/GET"},
+		{ProcName(P, V, 9), "P/V/9"},
+		{ProcName(P, 2, 1), "P/2/1"},
+		{ProcName(9, 1, 1), "9/1/1"},
+	} {
+		if c.got != c.want {
+			t.Errorf("got %q, want %q", c.got, c.want)
+		}
+	}
+}
+`
+	testSpec(t, src, test)
+}
+
+// Constants can be written in hex, octal or with a sign, and combined
+// in expressions, which are evaluated when generating code.
+func TestConstExprs(t *testing.T) {
+	src := `
+const A = 0x10;
+const B = 010;
+const C = -1;
+const D = A + B * 2;
+const E = (1 << 4) - 1;
+enum level { E1 = D - 1, E2 = -E };
+struct s {
+  opaque x[D];
+  int y[E + 1];
+};
+`
+	test := `package testpkg
+
+import (
+	"testing"
+)
+
+func TestValues(t *testing.T) {
+	var v S
+	if A != 16 || B != 8 || C != -1 || D != 32 || E != 15 {
+		t.Errorf("got %d %d %d %d %d", A, B, C, D, E)
+	}
+	if E1 != 31 || E2 != -15 {
+		t.Errorf("got enum values %d %d", E1, E2)
+	}
+	if len(v.X) != 32 || len(v.Y) != 16 {
+		t.Errorf("got array lengths %d %d", len(v.X), len(v.Y))
+	}
+}
+`
+	testSpec(t, src, test)
+
+	dir := tempPackage(t)
+	x := filepath.Join(dir, "bad.x")
+	for _, c := range []struct {
+		src, want string
+	}{
+		{"const F = 0x7fffffffffffffff * 4;\n", "bad.x:1:11: constant 36893488147419103228 out of range"},
+		{"const G = 1 / 0;\n", "bad.x:1:15: division by zero"},
+	} {
+		err := ioutil.WriteFile(x, []byte(c.src), 0666)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := rpcgen("-i", x, "-o", filepath.Join(dir, "bad.go"))
+		if err == nil || !strings.Contains(out, c.want) {
+			t.Errorf("invalid constant not reported: %v\n%s", err, out)
+		}
+	}
+}
+
+// Case labels must fit the type of the union discriminant.
+func TestCaseLabelRange(t *testing.T) {
+	compileSpec(t, `
+enum e { A = 1 };
+union u switch (e d) { case -1: void; case A: int a; };
+union v switch (int d) { case -2147483648: void; };
+union w switch (unsigned int d) { case 4294967295: void; };
+`)
+
+	dir := tempPackage(t)
+	x := filepath.Join(dir, "bad.x")
+	for _, c := range []struct {
+		src, want string
+	}{
+		{"union u switch (unsigned int d) { case -1: void; };\n", "bad.x:1:40: case label -1 out of range [0, 4294967295]"},
+		{"typedef unsigned int u32;\nunion u switch (u32 d) { case -1: void; };\n", "bad.x:2:31: case label -1 out of range [0, 4294967295]"},
+		{"union u switch (int d) { case 4294967295: void; };\n", "bad.x:1:31: case label 4294967295 out of range [-2147483648, 2147483647]"},
+		{"union u switch (bool d) { case 2: void; };\n", "bad.x:1:32: case label 2 out of range [0, 1]"},
+	} {
+		err := ioutil.WriteFile(x, []byte(c.src), 0666)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := rpcgen("-i", x, "-o", filepath.Join(dir, "bad.go"))
+		if err == nil || !strings.Contains(out, c.want) {
+			t.Errorf("%s: got %v\n%s\nwant %s", c.src, err, out, c.want)
+		}
+	}
+}
+
+// A spec can use the types and constants of a spec compiled into
+// another package.
+func TestImport(t *testing.T) {
+	base := tempPackage(t)
+	pkg := filepath.Base(base)
+	genSpec(t, base, `
+const MAXNAME = 16;
+typedef unsigned int uid;
+enum kind { K1 = 1, K2 = 2 };
+struct point { int x; int y; };
+`, "-p", pkg)
+
+	dir := tempPackage(t)
+	imp := fmt.Sprintf("-import=%s=%s/%s", filepath.Join(base, "spec.x"), modulePath, pkg)
+	genSpec(t, dir, `
+struct rec {
+  uid u;
+  kind k;
+  point p;
+  opaque name[MAXNAME + 1];
+};
+`, imp)
+
+	runTests(t, dir, strings.Replace(`package testpkg
+
+import (
+	"testing"
+
+	"github.com/zeldovich/go-rpcgen/xdr"
+	"github.com/zeldovich/go-rpcgen/PKG"
+)
+
+func TestRoundTrip(t *testing.T) {
+	in := Rec{U: 7, K: PKG.K2, P: PKG.Point{X: 1, Y: 2}}
+	if len(in.Name) != PKG.MAXNAME+1 {
+		t.Errorf("name has length %d", len(in.Name))
+	}
+	buf, err := xdr.EncodeBuf(&in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out Rec
+	err = xdr.DecodeBuf(buf, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("decoded %+v, want %+v", out, in)
+	}
+}
+`, "PKG", pkg, -1))
+
+	x := filepath.Join(dir, "bad.x")
+	err := ioutil.WriteFile(x, []byte("struct bad { uid u; nosuch n; };\n"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := rpcgen("-i", x, "-o", filepath.Join(dir, "bad.go"), imp)
+	if err == nil || !strings.Contains(out, "nosuch") {
+		t.Errorf("undefined type not reported: %v\n%s", err, out)
+	}
+
+	// Errors in the imported spec are reported too.
+	badBase := filepath.Join(dir, "badbase.x")
+	err = ioutil.WriteFile(badBase, []byte("typedef opaque big[0x100000000];\n"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(x, []byte("struct bad { big b; };\n"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	badImp := fmt.Sprintf("-import=%s=%s/%s", badBase, modulePath, pkg)
+	out, err = rpcgen("-i", x, "-o", filepath.Join(dir, "bad.go"), badImp)
+	if err == nil || !strings.Contains(out, "badbase.x:1:20: size 4294967296 out of range") {
+		t.Errorf("error in imported spec not reported: %v\n%s", err, out)
+	}
+}
+
+// With -handler-context, handlers get the call information, and their
+// errors and undecodable arguments are reported to the caller.
+func TestHandlerContext(t *testing.T) {
+	src := `
+typedef int num;
+program P {
+  version V {
+    num DOUBLE(num) = 1;
+  } = 1;
+} = 0x20000001;
+`
+	test := `package testpkg
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/zeldovich/go-rpcgen/rfc1057"
+	"github.com/zeldovich/go-rpcgen/xdr"
+)
+
+type handler struct{}
+
+func (handler) DOUBLE(ctx context.Context, call *xdr.CallInfo, arg Num) (Num, error) {
+	if call.Prog != P || call.Vers != V || call.Proc != DOUBLE {
+		return 0, errors.New("wrong call information")
+	}
+	if arg < 0 {
+		return 0, errors.New("negative argument")
+	}
+	return 2 * arg, nil
+}
+
+func TestHandler(t *testing.T) {
+	srv := rfc1057.MakeServer()
+	srv.RegisterMany(P_V_regs(handler{}))
+	cc, sc := net.Pipe()
+	defer cc.Close()
+	defer sc.Close()
+	go srv.Run(sc)
+
+	rc := rfc1057.MakeClient(cc, P, V)
+	c := MakeP_V_Client(rc)
+	res, err := c.DOUBLE(context.Background(), 21)
+	if err != nil || res != 42 {
+		t.Errorf("DOUBLE(21) returned %v, %v", res, err)
+	}
+
+	_, err = c.DOUBLE(context.Background(), -1)
+	if err == nil || !strings.Contains(err.Error(), "SYSTEM_ERR") {
+		t.Errorf("handler error returned %v, want SYSTEM_ERR", err)
+	}
+
+	var none rfc1057.Opaque_auth
+	err = rc.Call(DOUBLE, none, none, &xdr.Void{}, &res)
+	if err == nil || !strings.Contains(err.Error(), "GARBAGE_ARGS") {
+		t.Errorf("missing argument returned %v, want GARBAGE_ARGS", err)
+	}
+}
+`
+	testSpec(t, src, test, "-handler-context")
+}
+
+// -camel-case and -name-map choose the Go names of XDR identifiers,
+// and distinct identifiers that would get the same Go name are
+// reported.
+func TestNaming(t *testing.T) {
+	src := `
+struct nfs_fh3 { opaque data<64>; };
+enum nfsstat3 { NFS3_OK = 0, NFS3ERR_IO = 5 };
+struct LOOKUP3res { nfsstat3 status; nfs_fh3 object; };
+`
+	dir := tempPackage(t)
+	nameMap := filepath.Join(dir, "names")
+	err := ioutil.WriteFile(nameMap, []byte("# Overrides.\nnfs_fh3 Handle\n"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genSpec(t, dir, src, "-camel-case", "-name-map", nameMap)
+	runTests(t, dir, `package testpkg
+
+import (
+	"testing"
+)
+
+func TestNames(t *testing.T) {
+	res := LOOKUP3res{Status: Nfs3errIo, Object: Handle{Data: []byte{1}}}
+	if res.Status.String() != "NFS3ERR_IO" || Nfs3Ok != 0 {
+		t.Errorf("got %v", res)
+	}
+}
+`)
+
+	for _, c := range []struct {
+		src     string
+		names   string
+		flag    string
+		wantErr string
+	}{
+		{"struct foo_bar { int a; };\nstruct FooBar { int b; };\n", "", "-camel-case",
+			"2:8: foo_bar and FooBar both have Go name FooBar"},
+		{"struct a { int x; };\nstruct b { int y; };\n", "a Same\nb Same\n", "",
+			"2:8: a and b both have Go name Same"},
+	} {
+		x := filepath.Join(dir, "clash.x")
+		err := ioutil.WriteFile(x, []byte(c.src), 0666)
+		if err != nil {
+			t.Fatal(err)
+		}
+		args := []string{"-i", x, "-o", filepath.Join(dir, "clash.go")}
+		if c.flag != "" {
+			args = append(args, c.flag)
+		}
+		if c.names != "" {
+			err = ioutil.WriteFile(nameMap, []byte(c.names), 0666)
+			if err != nil {
+				t.Fatal(err)
+			}
+			args = append(args, "-name-map", nameMap)
+		}
+		out, err := rpcgen(args...)
+		if err == nil || !strings.Contains(out, c.wantErr) {
+			t.Errorf("clash not reported: %v\n%s", err, out)
+		}
+	}
+}
+
+// With -sum-unions, the arm of a union is an interface holding one type
+// per arm, which must match the discriminant.
+func TestSumUnions(t *testing.T) {
+	src := `
+union u switch (int d) {
+case 1:
+  int a;
+case 2:
+case 3:
+  void;
+default:
+  hyper h;
+};
+struct holder { u val; };
+`
+	test := `package testpkg
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zeldovich/go-rpcgen/xdr"
+)
+
+func TestArms(t *testing.T) {
+	for _, in := range []U{
+		{D: 1, Arm: &U_A{A: 5}},
+		{D: 3, Arm: &U_Void{}},
+		{D: 9, Arm: &U_H{H: -1}},
+	} {
+		buf, err := xdr.EncodeBuf(&Holder{Val: in})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out Holder
+		err = xdr.DecodeBuf(buf, &out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(out.Val, in) {
+			t.Errorf("decoded %+v, want %+v", out.Val, in)
+		}
+	}
+
+	// A void arm may also be left nil.
+	_, err := xdr.EncodeBuf(&U{D: 2})
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = xdr.EncodeBuf(&U{D: 1, Arm: &U_H{}})
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("mismatched arm returned %v", err)
+	}
+}
+`
+	testSpec(t, src, test, "-sum-unions")
+}
+
+// A union without a default arm rejects discriminants that match none
+// of its cases, when encoding and when decoding.
+func TestInvalidDiscriminant(t *testing.T) {
+	src := `
+union u switch (int d) {
+case 1:
+  int a;
+case 2:
+  void;
+};
+`
+	test := `package testpkg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zeldovich/go-rpcgen/xdr"
+)
+
+func TestDiscriminant(t *testing.T) {
+	_, err := xdr.EncodeBuf(&U{D: 3})
+	if err == nil || !strings.Contains(err.Error(), "invalid U discriminant 3") {
+		t.Errorf("encoding returned %v", err)
+	}
+
+	var u U
+	err = xdr.DecodeBuf([]byte{0, 0, 0, 3}, &u)
+	if err == nil || !strings.Contains(err.Error(), "invalid U discriminant 3") {
+		t.Errorf("decoding returned %v", err)
+	}
+}
+`
+	testSpec(t, src, test)
+	testSpec(t, src, test, "-sum-unions")
+}
+
+// Optional-data lists are encoded iteratively, and with -list-slices
+// they are slices with the same encoding.
+func TestLists(t *testing.T) {
+	src := `
+struct entry { int v; entry *next; };
+struct dir { int n; entry *entries; };
+`
+	test := `package testpkg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/zeldovich/go-rpcgen/xdr"
+)
+
+const n = 100000
+
+// want returns the encoding of a dir holding entries 0 to n-1.
+func want() []byte {
+	buf := []byte{0, 0, 0, 1}
+	for i := 0; i < n; i++ {
+		buf = append(buf, 0, 0, 0, 1, 0, 0, byte(i>>8), byte(i))
+	}
+	return append(buf, 0, 0, 0, 0)
+}
+
+func TestList(t *testing.T) {
+	in := makeDir()
+	buf, err := xdr.EncodeBuf(&in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf, want()) {
+		t.Fatal("wrong encoding")
+	}
+
+	var out Dir
+	err = xdr.DecodeBuf(buf, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf, err = xdr.EncodeBuf(&out)
+	if err != nil || !bytes.Equal(buf, want()) {
+		t.Fatalf("decoded list encodes differently: %v", err)
+	}
+}
+`
+	testSpec(t, src, test+`
+func makeDir() Dir {
+	d := Dir{N: 1}
+	next := &d.Entries
+	for i := 0; i < n; i++ {
+		*next = &Entry{V: int32(i & 0xffff)}
+		next = &(*next).Next
+	}
+	return d
+}
+`)
+	testSpec(t, src, test+`
+func makeDir() Dir {
+	d := Dir{N: 1}
+	for i := 0; i < n; i++ {
+		d.Entries = append(d.Entries, Entry{V: int32(i & 0xffff)})
+	}
+	return d
+}
+`, "-list-slices")
+}
+
+// With -list-slices, pointers to list types of an imported spec are
+// slices too, and are encoded as lists.
+func TestImportedLists(t *testing.T) {
+	base := tempPackage(t)
+	pkg := filepath.Base(base)
+	genSpec(t, base, `
+struct entry { int v; entry *next; };
+`, "-p", pkg, "-list-slices")
+
+	dir := tempPackage(t)
+	imp := fmt.Sprintf("-import=%s=%s/%s", filepath.Join(base, "spec.x"), modulePath, pkg)
+	genSpec(t, dir, `
+struct dir { int n; entry *entries; };
+`, imp, "-list-slices")
+
+	runTests(t, dir, strings.Replace(`package testpkg
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/zeldovich/go-rpcgen/xdr"
+	"github.com/zeldovich/go-rpcgen/PKG"
+)
+
+func TestRoundTrip(t *testing.T) {
+	in := Dir{N: 1, Entries: []PKG.Entry{{V: 2}, {V: 3}}}
+	buf, err := xdr.EncodeBuf(&in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0, 1, 0, 0, 0, 3, 0, 0, 0, 0}
+	if !bytes.Equal(buf, want) {
+		t.Fatalf("encoded % x, want % x", buf, want)
+	}
+	var out Dir
+	err = xdr.DecodeBuf(buf, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("decoded %+v, want %+v", out, in)
+	}
+}
+`, "PKG", pkg, -1))
+}
+
+// -emit-ast json writes the checked spec, with values and sizes
+// resolved.
+func TestEmitAST(t *testing.T) {
+	dir := tempPackage(t)
+	x := filepath.Join(dir, "spec.x")
+	err := ioutil.WriteFile(x, []byte(`const MAX = 8;
+enum color { RED = 0, GREEN = 1 };
+struct point { int x; color c; opaque tag[MAX]; string name<MAX>; };
+program P { version V { point GET(int) = 1; } = 1; } = 0x20000001;
+`), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	js := filepath.Join(dir, "spec.json")
+	out, err := rpcgen("-i", x, "-o", js, "-emit-ast", "json")
+	if err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	data, err := ioutil.ReadFile(js)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type typ struct {
+		Kind   string
+		Name   string
+		Size   *int
+		Length *struct {
+			Name  string
+			Value int
+		}
+		Max *struct {
+			Name  string
+			Value int
+		}
+		Items []struct {
+			Name  string
+			Value int
+		}
+		Fields []struct {
+			Name string
+			Type typ
+		}
+	}
+	var ast struct {
+		Consts []struct {
+			Name  string
+			Pos   string
+			Value int
+		}
+		Types []struct {
+			Name string
+			Type typ
+		}
+		Programs []struct {
+			Name     string
+			Number   int
+			Versions []struct {
+				Number     int
+				Procedures []struct {
+					Name   string
+					Number int
+					Arg    typ
+					Result typ
+				}
+			}
+		}
+	}
+	err = json.Unmarshal(data, &ast)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ast.Consts) != 1 || ast.Consts[0].Value != 8 || !strings.HasSuffix(ast.Consts[0].Pos, "spec.x:1:7") {
+		t.Errorf("got consts %+v", ast.Consts)
+	}
+	if len(ast.Types) != 2 {
+		t.Fatalf("got types %+v", ast.Types)
+	}
+	color, point := ast.Types[0].Type, ast.Types[1].Type
+	if color.Kind != "enum" || *color.Size != 4 || color.Items[1].Name != "GREEN" || color.Items[1].Value != 1 {
+		t.Errorf("got enum %+v", color)
+	}
+	if point.Kind != "struct" || point.Size != nil || len(point.Fields) != 4 {
+		t.Fatalf("got struct %+v", point)
+	}
+	tag, name := point.Fields[2].Type, point.Fields[3].Type
+	if tag.Kind != "opaque" || tag.Length.Name != "MAX" || tag.Length.Value != 8 || *tag.Size != 8 {
+		t.Errorf("got field tag %+v", tag)
+	}
+	if name.Kind != "string" || name.Max.Value != 8 || name.Size != nil {
+		t.Errorf("got field name %+v", name)
+	}
+	if len(ast.Programs) != 1 || ast.Programs[0].Number != 0x20000001 {
+		t.Fatalf("got programs %+v", ast.Programs)
+	}
+	proc := ast.Programs[0].Versions[0].Procedures[0]
+	if proc.Name != "GET" || proc.Number != 1 || proc.Arg.Kind != "int" || proc.Result.Name != "point" {
+		t.Errorf("got procedure %+v", proc)
+	}
+}
+
+// The _XdrSize constants are the sizes that -emit-ast reports, and
+// the XdrSize methods of variable-size types add up the fixed sizes of
+// their parts.
+func TestFixedSizes(t *testing.T) {
+	src := `
+const N = 3;
+typedef unsigned hyper id;
+typedef id ids[N];
+struct rec {
+  ids x;
+  opaque tag[5];
+  struct { int a; bool b; } in;
+  enum { ON = 1 } state;
+};
+union maybe switch (bool set) {
+case TRUE:
+  rec r;
+case FALSE:
+  void;
+};
+struct many { rec rs<>; };
+`
+	test := `package testpkg
+
+import (
+	"testing"
+
+	"github.com/zeldovich/go-rpcgen/xdr"
+)
+
+func TestSizes(t *testing.T) {
+	if Id_XdrSize != 8 || Ids_XdrSize != 24 || Rec_XdrSize != 44 {
+		t.Errorf("got sizes %d %d %d, want 8 24 44", Id_XdrSize, Ids_XdrSize, Rec_XdrSize)
+	}
+
+	vals := []interface {
+		xdr.Xdrable
+		XdrSize() int
+	}{
+		&Maybe{Set: true},
+		&Maybe{Set: false},
+		&Many{Rs: make([]Rec, 2)},
+	}
+	for _, v := range vals {
+		buf, err := xdr.EncodeBuf(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.XdrSize() != len(buf) {
+			t.Errorf("%T has XdrSize %d, encoded in %d bytes", v, v.XdrSize(), len(buf))
+		}
+	}
+}
+`
+	testSpec(t, src, test)
+}
+
+// Comments in the spec become doc comments on the Go declarations.
+func TestDocComments(t *testing.T) {
+	dir := tempPackage(t)
+	genSpec(t, dir, `
+/* The color of a point. */
+enum color {
+  RED = 0,   /* Warm. */
+  // Cool.
+  GREEN = 1 };
+
+// A point
+// on a plane.
+struct point {
+  int x;     /* Across. */
+  color c;
+};
+
+program P {
+  version V {
+    /* Looks up a point. */
+    point GET(int) = 1;
+  } = 1;
+} = 0x20000001;
+`)
+	data, err := ioutil.ReadFile(filepath.Join(dir, "xdr.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"// The color of a point.\ntype Color int32",
+		"// Warm.\nconst RED Color = 0",
+		"// Cool.\nconst GREEN Color = 1",
+		"// A point\n// on a plane.\ntype Point struct",
+		"\t// Across.\n\tX int32\n",
+		"\t// Looks up a point.\n\tGET(int32) Point\n",
+		"// Looks up a point.\nfunc (c *P_V_Client) GET(",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("missing %q in:\n%s", want, data)
+		}
+	}
+}
+
+// Fields named after the methods that go-rpcgen generates are renamed.
+func TestFieldMethodNames(t *testing.T) {
+	src := `
+struct s {
+  int equal;
+  int clone;
+  int xdrSize;
+  int xdr;
+};
+union u switch (int arm) {
+case 0:
+  int equal;
+case 1:
+  s clone;
+default:
+  void;
+};
+struct node {
+  int xdrSize;
+  node *equal;
+};
+`
+	compileSpec(t, src)
+	compileSpec(t, src, "-sum-unions")
+	compileSpec(t, src, "-list-slices")
+}
+
+// Generated helpers such as the _XdrSize constant of a fixed-size type
+// must not clash with the names of other definitions.
+func TestHelperNameClash(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rpcgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	spec := filepath.Join(dir, "spec.x")
+	err = ioutil.WriteFile(spec, []byte("struct s { int a; };\nstruct s_XdrSize { int b; };\n"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := rpcgen("-i", spec, "-o", filepath.Join(dir, "xdr.go"))
+	if err == nil {
+		t.Fatal("clash with S_XdrSize not reported")
+	}
+	if !strings.Contains(out, "both have Go name S_XdrSize") {
+		t.Errorf("unexpected error: %s", out)
+	}
+}
+
+// Types lifted out of anonymous enums and unions must not clash with
+// the names of other definitions either.
+func TestLiftedNameClash(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rpcgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, c := range []struct {
+		src   string
+		flags []string
+		want  string
+	}{
+		{"struct parent { enum { A = 1, B = 2 } kind; };\ntypedef int parent_kind;\n", nil,
+			"spec.x:2:13: inline enum parent_kind and parent_kind both have Go name Parent_kind"},
+		{"typedef enum { C = 1 } list<>;\nstruct list_elem { int x; };\n", nil,
+			"spec.x:2:8: inline enum list_elem and list_elem both have Go name List_elem"},
+		{"struct p { union switch (int d) { case 1: int a; default: void; } u; };\ntypedef int P_u_A;\n", []string{"-sum-unions"},
+			"spec.x:2:13: arm type of p_u and P_u_A both have Go name P_u_A"},
+	} {
+		spec := filepath.Join(dir, "spec.x")
+		err = ioutil.WriteFile(spec, []byte(c.src), 0666)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := rpcgen(append([]string{"-i", spec, "-o", filepath.Join(dir, "xdr.go")}, c.flags...)...)
+		if err == nil || !strings.Contains(out, c.want) {
+			t.Errorf("%s: got %v\n%s\nwant %s", c.src, err, out, c.want)
+		}
+	}
+}
+
+// Names that Go cannot use, whether they come from the spec, from
+// -camel-case or from -name-map, are escaped.
+func TestEscapedNames(t *testing.T) {
+	src := `
+enum equal { clone = 1, xdr = 2 };
+struct xdr_size { equal e; int len; };
+typedef xdr_size string_;
+union u switch (equal arm) {
+case clone:
+  int v;
+default:
+  void;
+};
+`
+	compileSpec(t, src)
+	compileSpec(t, src, "-camel-case")
+	compileSpec(t, src, "-sum-unions")
+
+	nameMap, err := ioutil.TempFile("", "namemap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(nameMap.Name())
+	fmt.Fprintf(nameMap, "equal int\nclone Clone\nxdr_size bool\nstring_ string\nu xdr\nv Equal\n")
+	nameMap.Close()
+	compileSpec(t, src, "-name-map", nameMap.Name())
+}
+
+// A template can choose which built-in code to keep, and import
+// packages that the built-in code also uses.
+func TestTemplate(t *testing.T) {
+	tmpl, err := ioutil.TempFile("", "tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpl.Name())
+	fmt.Fprintf(tmpl, "%s", `
+import "fmt"
+
+{{range .Defs}}{{$code := xdr .}}{{if ne (kind .) "const"}}{{$code}}{{end}}{{end}}
+{{progNames}}
+
+const C = "c"
+
+var _ = fmt.Sprint
+`)
+	tmpl.Close()
+
+	compileSpec(t, `
+const C = 1;
+struct s { int a; };
+program P {
+  version V {
+    s GET(s) = 1;
+  } = 1;
+} = 0x20000001;
+`, "-template", tmpl.Name())
+}
+
+// The built-in code for each definition is the code for that
+// definition, even if another one has the same position because both
+// come from one macro.
+func TestMacroDefs(t *testing.T) {
+	testSpec(t, `
+#define PAIR struct a { int x; }; struct b { hyper y; };
+PAIR
+`, `package testpkg
+
+import "testing"
+
+func TestDefs(t *testing.T) {
+	_ = A{X: 1}
+	_ = B{Y: 2}
+}
+`)
+}
+
+// Syntax errors do not hide errors in the definitions that parse.
+func TestMixedErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rpcgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	spec := filepath.Join(dir, "spec.x")
+	err = ioutil.WriteFile(spec, []byte(`struct a { int x int y; undefined_t z; };
+union u switch (int d) {
+case 1: int a b;
+case 2: missing_t c;
+};
+const A = 1;
+const A = 2;
+program P {
+  version V {
+    void F(void) = 1 void G(void) = 2;
+    int H(nosuch) = 3;
+  } = 1;
+} = 0x20000001;
+`), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := rpcgen("-i", spec, "-o", filepath.Join(dir, "xdr.go"))
+	if err == nil {
+		t.Fatal("errors not reported")
+	}
+	for _, want := range []string{
+		"spec.x:1:18: syntax error",
+		"spec.x:1:25: undefined type undefined_t",
+		"spec.x:3:15: syntax error",
+		"spec.x:4:9: undefined type missing_t",
+		"spec.x:7:7: A redefined",
+		"spec.x:10:22: syntax error",
+		"spec.x:11:11: undefined type nosuch",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing error %q in:\n%s", want, out)
+		}
+	}
+}
+
+// The rpcsvc specs in testdata, which come from the GNU C library,
+// use rpcgen's extensions to RFC 4506.
+func TestUpstreamSpecs(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "rpcsvc", "*.x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		src, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			compileSpec(t, string(src))
+		})
+	}
+}
+
+// Procedures can take and return built-in types, which the xdr package
+// wraps for encoding.
+func TestBuiltinProcTypes(t *testing.T) {
+	src := `
+program P {
+  version V {
+    int DOUBLE(int) = 1;
+    bool NOT(bool) = 2;
+    unsigned hyper INC(unsigned hyper) = 3;
+  } = 1;
+} = 0x20000001;
+`
+	test := `package testpkg
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/zeldovich/go-rpcgen/rfc1057"
+)
+
+type handler struct{}
+
+func (handler) DOUBLE(arg int32) int32   { return 2 * arg }
+func (handler) NOT(arg bool) bool        { return !arg }
+func (handler) INC(arg uint64) uint64    { return arg + 1 }
+
+func TestHandler(t *testing.T) {
+	srv := rfc1057.MakeServer()
+	srv.RegisterMany(P_V_regs(handler{}))
+	cc, sc := net.Pipe()
+	defer cc.Close()
+	defer sc.Close()
+	go srv.Run(sc)
+
+	c := MakeP_V_Client(rfc1057.MakeClient(cc, P, V))
+	ctx := context.Background()
+	if res, err := c.DOUBLE(ctx, -21); err != nil || res != -42 {
+		t.Errorf("DOUBLE(-21) returned %v, %v", res, err)
+	}
+	if res, err := c.NOT(ctx, true); err != nil || res {
+		t.Errorf("NOT(true) returned %v, %v", res, err)
+	}
+	if res, err := c.INC(ctx, 1<<40); err != nil || res != 1<<40+1 {
+		t.Errorf("INC(1<<40) returned %v, %v", res, err)
+	}
+}
+`
+	testSpec(t, src, test)
+	compileSpec(t, src, "-handler-context")
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"go/token"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/zeldovich/go-rpcgen/spec"
+)
+
+// nameMap holds the Go names given to XDR identifiers by -name-map,
+// which take precedence over the naming mode.
+var nameMap = make(map[string]string)
+
+// loadNameMap reads a -name-map file.  Each line holds an XDR
+// identifier and its Go name, separated by white space; blank lines
+// and lines starting with # are ignored.
+func loadNameMap(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	line := 0
+	for sc.Scan() {
+		line++
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) != 2 || !token.IsIdentifier(fields[1]) {
+			return fmt.Errorf("%s:%d: expected \"xdr_name GoName\"", filename, line)
+		}
+		nameMap[fields[0]] = fields[1]
+	}
+	return sc.Err()
+}
+
+// camelCase converts a snake_case or ALL_CAPS identifier to Go-style
+// CamelCase, as in nfs_fh3 -> NfsFh3 and NFS3ERR_IO -> Nfs3errIo.
+// Words that contain lower-case letters keep their case, apart from
+// the first letter, so LOOKUP3res stays as is.  It returns "" if ident
+// has no letters or digits.
+func camelCase(ident string) string {
+	var b strings.Builder
+	for _, w := range strings.Split(ident, "_") {
+		if w == "" {
+			continue
+		}
+		if strings.ToUpper(w) == w {
+			w = strings.ToLower(w)
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+
+	res := b.String()
+	if res == "" || unicode.IsDigit(rune(res[0])) {
+		return ""
+	}
+	return res
+}
+
+// constName returns the Go name of a const definition.  Without a
+// naming mode these keep their XDR spelling, unlike types and enum
+// items, so that lower-case constants stay unexported; names that Go
+// cannot use are escaped.
+func constName(ident string) string {
+	switch ident {
+	case "TRUE", "FALSE":
+		return ident
+	}
+
+	if n, ok := nameMap[ident]; ok {
+		return escapeName(n)
+	}
+	if *camelCaseFlag {
+		if n := camelCase(ident); n != "" {
+			return escapeName(n)
+		}
+	}
+	return escapeName(ident)
+}
+
+// goReserved holds the predeclared Go identifiers, the names of the
+// local variables and parameters of generated code, and the methods of
+// generated types, which generated names must not shadow.
+var goReserved = map[string]bool{
+	"append": true, "bool": true, "byte": true, "cap": true,
+	"close": true, "complex": true, "complex64": true, "complex128": true,
+	"copy": true, "delete": true, "error": true, "false": true,
+	"float32": true, "float64": true, "imag": true, "int": true,
+	"int8": true, "int16": true, "int32": true, "int64": true,
+	"iota": true, "len": true, "make": true, "new": true,
+	"nil": true, "panic": true, "print": true, "println": true,
+	"real": true, "recover": true, "rune": true, "string": true,
+	"true": true, "uint": true, "uint8": true, "uint16": true,
+	"uint32": true, "uint64": true, "uintptr": true,
+
+	"arg": true, "args": true, "arm": true, "c": true,
+	"call": true, "carm": true, "ctx": true, "d": true,
+	"elem": true, "err": true, "h": true, "i": true,
+	"in": true, "n": true, "o": true, "oarm": true,
+	"ok": true, "opted": true, "out": true, "proc": true,
+	"prog": true, "res": true, "v": true, "vers": true,
+	"w": true, "xs": true, "__arraysz": true,
+
+	"Xdr": true, "XdrSize": true, "Equal": true, "Clone": true,
+}
+
+// escapeName appends an underscore to ident if it is a Go keyword, a
+// name in goReserved, or the name of a package that generated code
+// imports, so that it can be used as a Go identifier.  All Go names
+// that go-rpcgen derives from XDR identifiers pass through it.
+func escapeName(ident string) string {
+	if _, ok := goPackages[ident]; ok || token.IsKeyword(ident) || goReserved[ident] || ident == "_" {
+		return ident + "_"
+	}
+	return ident
+}
+
+// checkNames reports distinct XDR identifiers that the naming mode or
+// -name-map would give the same Go name, and identifiers whose Go name
+// is taken by a helper that go-rpcgen generates, such as the
+// T_XdrSize constant of a fixed-size type T, or by a type lifted out
+// of an anonymous enum or union.  Constants, types, enum items,
+// program, version and procedure names and helpers share one scope;
+// the fields of each struct and union have their own.
+func checkNames(f *specFile) {
+	global := make(map[string]string)
+	helper := func(pos token.Pos, goName string, format string, args ...interface{}) {
+		f.checkName(global, pos, fmt.Sprintf(format, args...), goName)
+	}
+	sizeConst := func(pos token.Pos, name string) {
+		if fixedSizes[name] != "" {
+			helper(pos, i(name)+"_XdrSize", "XdrSize constant of %s", name)
+		}
+	}
+	sumArms := func(pos token.Pos, name string, fields []spec.Decl) {
+		u := i(name)
+		helper(pos, "is"+u+"_Arm", "arm interface of %s", name)
+		for _, arm := range fields[1:] {
+			armName, armPos := u+"_Void", pos
+			if arm.Kind != spec.DeclVoid {
+				armName, armPos = u+"_"+i(arm.Name), arm.NamePos
+			}
+			helper(armPos, armName, "arm type of %s", name)
+		}
+	}
+
+	// liftedTypes checks the names of the types that the anonymous
+	// types in t, which is named name, are lifted into by liftEnums,
+	// and the names that come with them.  liftType also checks the
+	// name of t itself, if it is lifted.
+	var liftedTypes func(pos token.Pos, name string, t spec.Type)
+	liftType := func(pos token.Pos, name string, t spec.Type) {
+		switch t.(type) {
+		case spec.EnumType:
+			helper(pos, i(name), "inline enum %s", name)
+		case spec.UnionType:
+			if *sumUnionsFlag {
+				helper(pos, i(name), "inline union %s", name)
+			}
+		}
+		liftedTypes(pos, name, t)
+	}
+	liftFields := func(prefix string, fields []spec.Decl) {
+		for _, d := range fields {
+			if d.Type != nil {
+				liftType(d.NamePos, prefix+"_"+d.Name, d.Type)
+			}
+		}
+	}
+	liftedTypes = func(pos token.Pos, name string, t spec.Type) {
+		switch t := t.(type) {
+		case spec.EnumType:
+			helper(pos, i(name)+"_XdrSize", "XdrSize constant of %s", name)
+			for _, item := range t.Items {
+				f.checkName(global, item.NamePos, item.Name, i(item.Name))
+			}
+		case spec.StructType:
+			liftFields(name, t.Fields)
+		case spec.UnionType:
+			fields := unionFields(t)
+			if *sumUnionsFlag {
+				sumArms(pos, name, fields)
+			}
+			liftFields(name, fields)
+		}
+	}
+
+	for _, d := range f.Defs {
+		switch d := d.(type) {
+		case spec.ConstDef:
+			f.checkName(global, d.NamePos, d.Name, constName(d.Name))
+		case spec.TypedefDef:
+			if d.Decl.Kind != spec.DeclVoid {
+				f.checkName(global, d.Decl.NamePos, d.Decl.Name, i(d.Decl.Name))
+				sizeConst(d.Decl.NamePos, d.Decl.Name)
+			}
+			if d.Decl.Type == nil {
+				break
+			}
+			if d.Decl.Kind == spec.DeclPlain {
+				liftedTypes(d.Decl.NamePos, d.Decl.Name, d.Decl.Type)
+			} else {
+				liftType(d.Decl.NamePos, d.Decl.Name+"_elem", d.Decl.Type)
+			}
+		case spec.EnumDef:
+			f.checkName(global, d.NamePos, d.Name, i(d.Name))
+			sizeConst(d.NamePos, d.Name)
+			for _, item := range d.Items {
+				f.checkName(global, item.NamePos, item.Name, i(item.Name))
+			}
+		case spec.StructDef:
+			f.checkName(global, d.NamePos, d.Name, i(d.Name))
+			sizeConst(d.NamePos, d.Name)
+			f.checkFields(d.Fields)
+			liftFields(d.Name, d.Fields)
+		case spec.UnionDef:
+			f.checkName(global, d.NamePos, d.Name, i(d.Name))
+			fields := unionFields(d.Union)
+			f.checkFields(fields)
+			if *sumUnionsFlag {
+				sumArms(d.NamePos, d.Name, fields)
+			}
+			liftFields(d.Name, fields)
+		case spec.ProgramDef:
+			f.checkName(global, d.NamePos, d.Name, i(d.Name))
+			helper(d.NamePos, "ProcName", "ProcName function")
+			helper(d.NamePos, i(d.Name)+"_ProcName", "ProcName function of %s", d.Name)
+			for _, v := range d.Versions {
+				f.checkName(global, v.NamePos, v.Name, i(v.Name))
+				pv := i(d.Name) + "_" + i(v.Name)
+				helper(v.NamePos, pv+"_handler", "handler interface of %s", v.Name)
+				helper(v.NamePos, pv+"_handler_wrapper", "handler wrapper of %s", v.Name)
+				helper(v.NamePos, pv+"_regs", "registrations of %s", v.Name)
+				helper(v.NamePos, pv+"_Client", "client of %s", v.Name)
+				helper(v.NamePos, "Make"+pv+"_Client", "client constructor of %s", v.Name)
+				for _, p := range v.Procs {
+					f.checkName(global, p.NamePos, p.Name, i(p.Name))
+				}
+			}
+		}
+	}
+}
+
+// unionFields returns the discriminant and arms of a union.
+func unionFields(u spec.UnionType) []spec.Decl {
+	fields := []spec.Decl{u.Switch}
+	for _, c := range u.Cases {
+		fields = append(fields, c.Decl)
+	}
+	if u.Default != nil {
+		fields = append(fields, *u.Default)
+	}
+	return fields
+}
+
+func (f *specFile) checkFields(fields []spec.Decl) {
+	scope := make(map[string]string)
+	for _, d := range fields {
+		if d.Kind != spec.DeclVoid {
+			f.checkName(scope, d.NamePos, d.Name, i(d.Name))
+		}
+	}
+}
+
+func (f *specFile) checkName(scope map[string]string, pos token.Pos, name string, goName string) {
+	other, ok := scope[goName]
+	if ok && other != name {
+		f.errorf(pos, "%s and %s both have Go name %s", other, name, goName)
+		return
+	}
+	scope[goName] = name
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"go/token"
+	"io"
 	"strings"
 )
 
@@ -11,8 +13,35 @@ func i(ident string) string {
 		return "true"
 	case "FALSE":
 		return "false"
-	default:
-		return strings.ToUpper(ident[:1]) + ident[1:]
+	}
+
+	if n, ok := nameMap[ident]; ok {
+		return escapeName(n)
+	}
+	if *camelCaseFlag {
+		if n := camelCase(ident); n != "" {
+			return escapeName(n)
+		}
+	}
+	return escapeName(strings.ToUpper(ident[:1]) + ident[1:])
+}
+
+// docs maps the names of definitions, enum items, and programs,
+// versions and procedures onto the comments attached to them in the
+// spec.  Fields carry their own comments in declName.
+var docs = make(map[string]string)
+
+// emitDoc writes doc to w as a Go comment.
+func emitDoc(w io.Writer, doc string) {
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		if line == "" {
+			fmt.Fprintf(w, "//\n")
+		} else {
+			fmt.Fprintf(w, "// %s\n", line)
+		}
 	}
 }
 
@@ -22,13 +51,20 @@ type declVoid struct {
 }
 
 type declName struct {
-	t declType
-	n string
+	t   declType
+	n   string
+	doc string
+
+	// size is the encoded size of the field, or "" if it varies.
+	size string
 }
 
 type declType interface {
 	goType() string
 	goXdr(valPtr string) string
+	goSize(valPtr string) string
+	goEqual(a, b string) string
+	goClone(dst, src string) string
 }
 
 type declTypeTypespec struct {
@@ -54,8 +90,8 @@ func (t declTypeArray) goType() string {
 
 func (t declTypeArray) goXdr(valPtr string) string {
 	var res string
-	res += fmt.Sprintf("for i := 0; i < %s; i++ {\n", t.sz)
-	res += fmt.Sprintf("%s\n", t.t.goXdr(fmt.Sprintf("&(*(%s)[i])", valPtr)))
+	res += fmt.Sprintf("for i := range *(%s) {\n", valPtr)
+	res += fmt.Sprintf("%s\n", t.t.goXdr(fmt.Sprintf("&((*(%s))[i])", valPtr)))
 	res += fmt.Sprintf("}\n")
 	return res
 }
@@ -63,6 +99,9 @@ func (t declTypeArray) goXdr(valPtr string) string {
 type declTypeVarArray struct {
 	t  typespec
 	sz string
+
+	// elemSize is the encoded size of each element, or "" if it varies.
+	elemSize string
 }
 
 func (t declTypeVarArray) goType() string {
@@ -138,10 +177,17 @@ type declTypePtr struct {
 }
 
 func (t declTypePtr) goType() string {
+	if isListSlice(t.t) {
+		return fmt.Sprintf("[]%s", t.t.goType())
+	}
 	return fmt.Sprintf("*%s", t.t.goType())
 }
 
 func (t declTypePtr) goXdr(valPtr string) string {
+	if isListSlice(t.t) {
+		return listSliceXdr(t.t, valPtr)
+	}
+
 	var res string
 	res += fmt.Sprintf("if xs.Encoding() {\n")
 	res += fmt.Sprintf("opted := *(%s) != nil\n", valPtr)
@@ -165,6 +211,9 @@ func (t declTypePtr) goXdr(valPtr string) string {
 type typespec interface {
 	goType() string
 	goXdr(valPtr string) string
+	goSize(valPtr string) string
+	goEqual(a, b string) string
+	goClone(dst, src string) string
 }
 
 type typespecOpt struct {
@@ -180,6 +229,37 @@ func (t typespecOpt) maybeGoType() string {
 	return t.t.goType()
 }
 
+// xdrRef returns ref, a pointer to a value of type t, as an
+// xdr.Xdrable.  The Go types of XDR's built-in types have no Xdr
+// method, so pointers to them are converted to pointers to the
+// matching types of the xdr package.
+func xdrRef(t typespec, ref string) string {
+	var x string
+	switch t := t.(type) {
+	case typeInt:
+		x = "Int32"
+		if t.unsig {
+			x = "Uint32"
+		}
+	case typeHyper:
+		x = "Int64"
+		if t.unsig {
+			x = "Uint64"
+		}
+	case typeFloat:
+		x = "Float32"
+	case typeDouble:
+		x = "Float64"
+	case typeQuadruple:
+		x = "Quadruple"
+	case typeBool:
+		x = "Bool"
+	default:
+		return ref
+	}
+	return fmt.Sprintf("(*xdr.%s)(%s)", x, ref)
+}
+
 type typeInt struct {
 	unsig bool
 }
@@ -222,18 +302,26 @@ func (t typeHyper) goXdr(valPtr string) string {
 
 type typeFloat struct{}
 
-func (t typeFloat) goType() string             { return "float32" }
-func (t typeFloat) goXdr(valPtr string) string { panic("x") }
+func (t typeFloat) goType() string { return "float32" }
+func (t typeFloat) goXdr(valPtr string) string {
+	return fmt.Sprintf("xdr.XdrFloat32(xs, (*float32)(%s));\n", valPtr)
+}
 
 type typeDouble struct{}
 
-func (t typeDouble) goType() string             { return "float64" }
-func (t typeDouble) goXdr(valPtr string) string { panic("x") }
+func (t typeDouble) goType() string { return "float64" }
+func (t typeDouble) goXdr(valPtr string) string {
+	return fmt.Sprintf("xdr.XdrFloat64(xs, (*float64)(%s));\n", valPtr)
+}
 
+// Quadruple-precision values are carried as their raw 16-byte
+// encoding, since Go has no 128-bit float type.
 type typeQuadruple struct{}
 
-func (t typeQuadruple) goType() string             { panic("quadruple") }
-func (t typeQuadruple) goXdr(valPtr string) string { panic("x") }
+func (t typeQuadruple) goType() string { return "[16]byte" }
+func (t typeQuadruple) goXdr(valPtr string) string {
+	return fmt.Sprintf("xdr.XdrQuadruple(xs, (*[16]byte)(%s));\n", valPtr)
+}
 
 type typeBool struct{}
 
@@ -246,8 +334,16 @@ type typeEnum struct {
 	items []enumItem
 }
 
-func (t typeEnum) goType() string             { return "int32" }
-func (t typeEnum) goXdr(valPtr string) string { panic("x") }
+// Inline enums are normally lifted into named types by liftEnums;
+// these methods only handle the remaining cases (such as procedure
+// arguments) by treating the value as a plain integer.
+func (t typeEnum) goType() string {
+	return typeInt{*unsignedEnumFlag}.goType()
+}
+
+func (t typeEnum) goXdr(valPtr string) string {
+	return typeInt{*unsignedEnumFlag}.goXdr(valPtr)
+}
 
 func declToNameGotype(d decl) string {
 	switch v := d.(type) {
@@ -285,6 +381,9 @@ func (t typeStruct) goXdr(valPtr string) string {
 type typeUnion struct {
 	switchDecl decl
 	cases      unionCasesDef
+
+	// name is used in error messages.  It is set by liftEnumsUnion.
+	name string
 }
 
 func (t typeUnion) goType() string {
@@ -313,7 +412,7 @@ func (t typeUnion) goXdr(valPtr string) string {
 	res += fmt.Sprintf("switch %s {\n", switchName)
 	for _, c := range t.cases.cases {
 		for idx, cval := range c.cases {
-			res += fmt.Sprintf("case %s:\n", i(cval))
+			res += fmt.Sprintf("case %s:\n", cval)
 			if idx != len(c.cases)-1 {
 				res += "fallthrough\n"
 			}
@@ -329,18 +428,37 @@ func (t typeUnion) goXdr(valPtr string) string {
 		case declName:
 			res += v.t.goXdr(fmt.Sprintf("&((%s).%s)", valPtr, i(v.n)))
 		}
+	} else {
+		res += "default:\n"
+		res += invalidDiscriminant(t.name, switchName)
 	}
 	res += "}\n"
 	return res
 }
 
+// invalidDiscriminant returns code that rejects a discriminant value
+// not listed in a union without a default arm.
+func invalidDiscriminant(name string, disc string) string {
+	res := "if xs.Error() == nil {\n"
+	res += fmt.Sprintf("xs.SetErrorf(\"invalid %s discriminant %%v\", %s)\n", i(name), disc)
+	res += "}\n"
+	return res
+}
+
 type typeIdent struct {
-	n string
+	n   string
+	pos token.Pos
+}
+
+func (t typeIdent) goType() string {
+	if g, ok := typeNames[t.n]; ok {
+		return g
+	}
+	return i(t.n)
 }
 
-func (t typeIdent) goType() string { return i(t.n) }
 func (t typeIdent) goXdr(valPtr string) string {
-	return fmt.Sprintf("(*%s)(%s).Xdr(xs);\n", i(t.n), valPtr)
+	return fmt.Sprintf("(*%s)(%s).Xdr(xs);\n", t.goType(), valPtr)
 }
 
 type enumItem struct {
@@ -377,52 +495,152 @@ type progDef struct {
 	id   string
 }
 
-func emitProg(d progDef) {
-	fmt.Fprintf(tout, "const %s uint32 = %s\n", i(d.name), d.id)
-	for _, v := range d.vers {
-		fmt.Fprintf(tout, "const %s uint32 = %s\n", i(v.name), v.id)
+// liftEnums replaces anonymous enums nested anywhere inside d with
+// references to named enum types, emitting each one with emitEnum.
+// With -sum-unions, anonymous unions are lifted in the same way.
+// The synthesized name is the path of field names from the enclosing
+// definition, e.g. parent_kind for a field kind of struct parent.
+func liftEnums(prefix string, d decl) decl {
+	switch v := d.(type) {
+	case declName:
+		return declName{liftEnumsType(prefix+"_"+v.n, v.t), v.n, v.doc, v.size}
+	}
+	return d
+}
 
-		for _, c := range v.calls {
-			fmt.Fprintf(tout, "const %s uint32 = %s\n", i(c.name), c.id)
-		}
+func liftEnumsType(name string, t declType) declType {
+	switch v := t.(type) {
+	case declTypeTypespec:
+		return declTypeTypespec{liftEnumsSpec(name, v.t)}
+	case declTypeArray:
+		return declTypeArray{liftEnumsSpec(name, v.t), v.sz}
+	case declTypeVarArray:
+		return declTypeVarArray{liftEnumsSpec(name, v.t), v.sz, v.elemSize}
+	case declTypePtr:
+		return declTypePtr{liftEnumsSpec(name, v.t)}
+	}
+	return t
+}
 
-		fmt.Fprintf(out, "type %s_%s_handler interface {\n", i(d.name), i(v.name))
-		for _, c := range v.calls {
-			fmt.Fprintf(out, "%s(%s) %s\n", i(c.name), c.arg.maybeGoType(), c.res.maybeGoType())
+func liftEnumsSpec(name string, t typespec) typespec {
+	switch v := t.(type) {
+	case typeEnum:
+		emitEnum(name, v.items)
+		return typeIdent{n: name}
+	case typeStruct:
+		return typeStruct{liftEnumsDecls(name, v.items)}
+	case typeUnion:
+		if *sumUnionsFlag {
+			emitUnion(name, v)
+			return typeIdent{n: name}
 		}
-		fmt.Fprintf(out, "}\n")
+		return liftEnumsUnion(name, v)
+	}
+	return t
+}
 
-		fmt.Fprintf(out, "type %s_%s_handler_wrapper struct {\n", i(d.name), i(v.name))
-		fmt.Fprintf(out, "h %s_%s_handler\n", i(d.name), i(v.name))
-		fmt.Fprintf(out, "}\n")
+func liftEnumsDecls(prefix string, ds []decl) []decl {
+	var res []decl
+	for _, d := range ds {
+		res = append(res, liftEnums(prefix, d))
+	}
+	return res
+}
 
-		for _, c := range v.calls {
-			fmt.Fprintf(out, "func (w *%s_%s_handler_wrapper) %s(args *xdr.XdrState) (res xdr.Xdrable, err error) {\n",
-				i(d.name), i(v.name), i(c.name))
-			if !c.arg.isVoid {
-				fmt.Fprintf(out, "var in %s\n", c.arg.t.goType())
-				fmt.Fprintf(out, "in.Xdr(args)\n")
-				fmt.Fprintf(out, "err = args.Error()\n")
-				fmt.Fprintf(out, "if err != nil { return }\n")
-			}
+func liftEnumsUnion(prefix string, u typeUnion) typeUnion {
+	switchDecl := liftEnums(prefix, u.switchDecl)
 
-			if !c.res.isVoid {
-				fmt.Fprintf(out, "var out %s\n", c.res.t.goType())
-			} else {
-				fmt.Fprintf(out, "var out xdr.Void\n")
-			}
+	var cases []unionCaseDecl
+	for _, c := range u.cases.cases {
+		cases = append(cases, unionCaseDecl{c.cases, liftEnums(prefix, c.body)})
+	}
 
-			if !c.res.isVoid {
-				fmt.Fprintf(out, "out = ")
-			}
-			fmt.Fprintf(out, "w.h.%s(", i(c.name))
-			if !c.arg.isVoid {
-				fmt.Fprintf(out, "in")
+	var def decl
+	if u.cases.def != nil {
+		def = liftEnums(prefix, u.cases.def)
+	}
+
+	return typeUnion{
+		switchDecl: switchDecl,
+		cases:      unionCasesDef{cases, def},
+		name:       prefix,
+	}
+}
+
+// A definition is one of the top-level definitions in a .x file:
+// constDef, typedefDef, enumDef, structDef, unionDef or progDef.
+type definition interface{}
+
+type constDef struct {
+	name string
+	val  string
+}
+
+type typedefDef struct {
+	d decl
+}
+
+type enumDef struct {
+	name  string
+	items []enumItem
+}
+
+type structDef struct {
+	name  string
+	items []decl
+}
+
+type unionDef struct {
+	name string
+	u    typeUnion
+}
+
+// emitDef emits the built-in code for a definition.
+func emitDef(d definition) {
+	switch d := d.(type) {
+	case constDef:
+		emitConst(d.name, d.val)
+	case typedefDef:
+		emitTypedef(d.d)
+	case enumDef:
+		emitEnum(d.name, d.items)
+	case structDef:
+		emitStruct(d.name, d.items)
+	case unionDef:
+		emitUnion(d.name, d.u)
+	case progDef:
+		emitProg(d)
+	}
+}
+
+// progs records every program seen so far, for emitProgNames.
+var progs []progDef
+
+func emitProg(d progDef) {
+	progs = append(progs, d)
+
+	emitDoc(tout, docs[d.name])
+	fmt.Fprintf(tout, "const %s uint32 = %s\n", i(d.name), d.id)
+
+	// A procedure may appear in several versions under one name.
+	procConsts := make(map[string]bool)
+	for _, v := range d.vers {
+		emitDoc(tout, docs[v.name])
+		fmt.Fprintf(tout, "const %s uint32 = %s\n", i(v.name), v.id)
+
+		for _, c := range v.calls {
+			if procConsts[c.name] {
+				continue
 			}
-			fmt.Fprintf(out, ")\n")
+			procConsts[c.name] = true
+			emitDoc(tout, docs[c.name])
+			fmt.Fprintf(tout, "const %s uint32 = %s\n", i(c.name), c.id)
+		}
 
-			fmt.Fprintf(out, "return &out, nil")
-			fmt.Fprintf(out, "}\n")
+		if *handlerContextFlag {
+			emitContextHandler(d, v)
+		} else {
+			emitHandler(d, v)
 		}
 
 		fmt.Fprintf(out, "func %s_%s_regs(h %s_%s_handler) []xdr.ProcRegistration {\n", i(d.name), i(v.name), i(d.name), i(v.name))
@@ -433,37 +651,257 @@ func emitProg(d progDef) {
 			fmt.Fprintf(out, "Prog: %s,\n", i(d.name))
 			fmt.Fprintf(out, "Vers: %s,\n", i(v.name))
 			fmt.Fprintf(out, "Proc: %s,\n", i(c.name))
-			fmt.Fprintf(out, "Handler: w.%s,\n", i(c.name))
+			if *handlerContextFlag {
+				fmt.Fprintf(out, "ContextHandler: w.%s,\n", i(c.name))
+			} else {
+				fmt.Fprintf(out, "Handler: w.%s,\n", i(c.name))
+			}
 			fmt.Fprintf(out, "},\n")
 		}
 		fmt.Fprintf(out, "}\n")
 		fmt.Fprintf(out, "}\n")
+
+		emitClient(d, v)
+	}
+
+	fmt.Fprintf(out, "func %s_ProcName(vers, proc uint32) string {\n", i(d.name))
+	fmt.Fprintf(out, "switch vers {\n")
+	for _, v := range d.vers {
+		fmt.Fprintf(out, "case %s:\n", i(v.name))
+		fmt.Fprintf(out, "switch proc {\n")
+		for _, c := range v.calls {
+			fmt.Fprintf(out, "case %s:\n", i(c.name))
+			fmt.Fprintf(out, "return \"%s/%s/%s\"\n", d.name, v.name, c.name)
+		}
+		fmt.Fprintf(out, "}\n")
+		fmt.Fprintf(out, "return fmt.Sprintf(\"%s/%s/%%d\", proc)\n", d.name, v.name)
 	}
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "return fmt.Sprintf(\"%s/%%d/%%d\", vers, proc)\n", d.name)
+	fmt.Fprintf(out, "}\n")
+}
+
+// emitHandler generates the handler interface for one version of a
+// program, and a wrapper that decodes arguments and encodes results.
+func emitHandler(d progDef, v progVer) {
+	fmt.Fprintf(out, "type %s_%s_handler interface {\n", i(d.name), i(v.name))
+	for _, c := range v.calls {
+		emitDoc(out, docs[c.name])
+		fmt.Fprintf(out, "%s(%s) %s\n", i(c.name), c.arg.maybeGoType(), c.res.maybeGoType())
+	}
+	fmt.Fprintf(out, "}\n")
+
+	fmt.Fprintf(out, "type %s_%s_handler_wrapper struct {\n", i(d.name), i(v.name))
+	fmt.Fprintf(out, "h %s_%s_handler\n", i(d.name), i(v.name))
+	fmt.Fprintf(out, "}\n")
+
+	for _, c := range v.calls {
+		fmt.Fprintf(out, "func (w *%s_%s_handler_wrapper) %s(args *xdr.XdrState) (res xdr.Xdrable, err error) {\n",
+			i(d.name), i(v.name), i(c.name))
+		if !c.arg.isVoid {
+			emitDecodeArg(c)
+		}
+
+		if !c.res.isVoid {
+			fmt.Fprintf(out, "var out %s\n", c.res.t.goType())
+		} else {
+			fmt.Fprintf(out, "var out xdr.Void\n")
+		}
+
+		if !c.res.isVoid {
+			fmt.Fprintf(out, "out = ")
+		}
+		fmt.Fprintf(out, "w.h.%s(", i(c.name))
+		if !c.arg.isVoid {
+			fmt.Fprintf(out, "in")
+		}
+		fmt.Fprintf(out, ")\n")
+
+		fmt.Fprintf(out, "return %s, nil", resRef(c))
+		fmt.Fprintf(out, "}\n")
+	}
+}
+
+// emitDecodeArg generates the decoding of the argument of c from args
+// into in, in a handler wrapper.
+func emitDecodeArg(c progCall) {
+	fmt.Fprintf(out, "var in %s\n", c.arg.t.goType())
+	if ref := xdrRef(c.arg.t, "&in"); ref != "&in" {
+		fmt.Fprintf(out, "%s.Xdr(args)\n", ref)
+	} else {
+		fmt.Fprintf(out, "in.Xdr(args)\n")
+	}
+	fmt.Fprintf(out, "err = args.Error()\n")
+	fmt.Fprintf(out, "if err != nil { return }\n")
+}
+
+// resRef returns the result out of a handler wrapper for c as an
+// xdr.Xdrable.
+func resRef(c progCall) string {
+	if c.res.isVoid {
+		return "&out"
+	}
+	return xdrRef(c.res.t, "&out")
+}
+
+// emitContextHandler is like emitHandler, but generates handlers that
+// take a context and call information, and that can return an error.
+func emitContextHandler(d progDef, v progVer) {
+	fmt.Fprintf(out, "type %s_%s_handler interface {\n", i(d.name), i(v.name))
+	for _, c := range v.calls {
+		emitDoc(out, docs[c.name])
+		fmt.Fprintf(out, "%s(ctx context.Context, call *xdr.CallInfo", i(c.name))
+		if !c.arg.isVoid {
+			fmt.Fprintf(out, ", arg %s", c.arg.t.goType())
+		}
+		fmt.Fprintf(out, ")")
+		if !c.res.isVoid {
+			fmt.Fprintf(out, " (%s, error)\n", c.res.t.goType())
+		} else {
+			fmt.Fprintf(out, " error\n")
+		}
+	}
+	fmt.Fprintf(out, "}\n")
+
+	fmt.Fprintf(out, "type %s_%s_handler_wrapper struct {\n", i(d.name), i(v.name))
+	fmt.Fprintf(out, "h %s_%s_handler\n", i(d.name), i(v.name))
+	fmt.Fprintf(out, "}\n")
+
+	for _, c := range v.calls {
+		fmt.Fprintf(out, "func (w *%s_%s_handler_wrapper) %s(ctx context.Context, call *xdr.CallInfo, args *xdr.XdrState) (res xdr.Xdrable, err error) {\n",
+			i(d.name), i(v.name), i(c.name))
+		if !c.arg.isVoid {
+			emitDecodeArg(c)
+		}
+
+		if !c.res.isVoid {
+			fmt.Fprintf(out, "var out %s\n", c.res.t.goType())
+			fmt.Fprintf(out, "out, err = ")
+		} else {
+			fmt.Fprintf(out, "var out xdr.Void\n")
+			fmt.Fprintf(out, "err = ")
+		}
+		fmt.Fprintf(out, "w.h.%s(ctx, call", i(c.name))
+		if !c.arg.isVoid {
+			fmt.Fprintf(out, ", in")
+		}
+		fmt.Fprintf(out, ")\n")
+		fmt.Fprintf(out, "if err != nil { return }\n")
+
+		fmt.Fprintf(out, "return %s, nil", resRef(c))
+		fmt.Fprintf(out, "}\n")
+	}
+}
+
+// emitClient generates a client for one version of a program, with a
+// method for each procedure that takes and returns its XDR types.
+func emitClient(d progDef, v progVer) {
+	client := fmt.Sprintf("%s_%s_Client", i(d.name), i(v.name))
+
+	fmt.Fprintf(out, "type %s struct {\n", client)
+	fmt.Fprintf(out, "c xdr.ProcCaller\n")
+	fmt.Fprintf(out, "}\n")
+
+	fmt.Fprintf(out, "func Make%s(c xdr.ProcCaller) *%s {\n", client, client)
+	fmt.Fprintf(out, "return &%s{c}\n", client)
+	fmt.Fprintf(out, "}\n")
+
+	for _, c := range v.calls {
+		emitDoc(out, docs[c.name])
+		fmt.Fprintf(out, "func (c *%s) %s(ctx context.Context", client, i(c.name))
+		if !c.arg.isVoid {
+			fmt.Fprintf(out, ", arg %s", c.arg.t.goType())
+		}
+		fmt.Fprintf(out, ")")
+		if !c.res.isVoid {
+			fmt.Fprintf(out, " (res %s, err error) {\n", c.res.t.goType())
+		} else {
+			fmt.Fprintf(out, " error {\n")
+			fmt.Fprintf(out, "var res xdr.Void\n")
+		}
+
+		argRef := "&xdr.Void{}"
+		if !c.arg.isVoid {
+			argRef = xdrRef(c.arg.t, "&arg")
+		}
+
+		if !c.res.isVoid {
+			fmt.Fprintf(out, "err = c.c.CallProc(ctx, %s, %s, %s, %s, %s)\n", i(d.name), i(v.name), i(c.name), argRef, xdrRef(c.res.t, "&res"))
+			fmt.Fprintf(out, "return\n")
+		} else {
+			fmt.Fprintf(out, "return c.c.CallProc(ctx, %s, %s, %s, %s, &res)\n", i(d.name), i(v.name), i(c.name), argRef)
+		}
+		fmt.Fprintf(out, "}\n")
+	}
+}
+
+// emitProgNames generates ProcName, which maps program, version and
+// procedure numbers to their names across all programs in the spec.
+func emitProgNames() {
+	if len(progs) == 0 {
+		return
+	}
+
+	fmt.Fprintf(out, "func ProcName(prog, vers, proc uint32) string {\n")
+	fmt.Fprintf(out, "switch prog {\n")
+	for _, d := range progs {
+		fmt.Fprintf(out, "case %s:\n", i(d.name))
+		fmt.Fprintf(out, "return %s_ProcName(vers, proc)\n", i(d.name))
+	}
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "return fmt.Sprintf(\"%%d/%%d/%%d\", prog, vers, proc)\n")
+	fmt.Fprintf(out, "}\n")
 }
 
 func emitConst(ident string, val string) {
-	fmt.Fprintf(tout, "const %s %s = %s\n", ident, *constTypeFlag, val)
+	emitDoc(tout, docs[ident])
+	fmt.Fprintf(tout, "const %s %s = %s\n", constName(ident), *constTypeFlag, val)
 }
 
 func emitTypedef(val decl) {
 	switch v := val.(type) {
 	case declName:
+		// A typedef of an anonymous enum is just a named enum.
+		// Anonymous enums nested below an array or pointer are
+		// named after the typedef with an _elem suffix.
+		switch t := v.t.(type) {
+		case declTypeTypespec:
+			if e, ok := t.t.(typeEnum); ok {
+				emitEnum(v.n, e.items)
+				return
+			}
+			if u, ok := t.t.(typeUnion); ok && *sumUnionsFlag {
+				emitUnion(v.n, u)
+				return
+			}
+			v.t = liftEnumsType(v.n, v.t)
+		default:
+			v.t = liftEnumsType(v.n+"_elem", v.t)
+		}
+
 		// Pointers get wrapped in an extra level of struct at
 		// the top level, so that we can define a pointer receiver
 		// on them.
 		goType := v.t.goType()
-		goRef := "v"
-		switch v.t.(type) {
+		goRef, oRef, cRef := "v", "o", "&c"
+		switch t := v.t.(type) {
 		case declTypePtr:
-			goType = fmt.Sprintf("struct { P %s }", goType)
-			goRef = "&v.P"
+			if !isListSlice(t.t) {
+				goType = fmt.Sprintf("struct { P %s }", goType)
+				goRef, oRef, cRef = "&v.P", "&o.P", "&c.P"
+			}
 		}
 
+		emitDoc(tout, docs[v.n])
 		fmt.Fprintf(tout, "type %s %s\n", i(v.n), goType)
 
 		fmt.Fprintf(out, "func (v *%s) Xdr(xs *xdr.XdrState) {\n", i(v.n))
 		fmt.Fprintf(out, "%s", v.t.goXdr(goRef))
 		fmt.Fprintf(out, "}\n")
+
+		emitXdrSize(v.n, v.t.goSize(goRef))
+
+		emitEqualClone(v.n, v.t.goEqual(goRef, oRef), v.t.goClone(cRef, goRef))
 	}
 }
 
@@ -473,22 +911,66 @@ func emitEnum(ident string, val []enumItem) {
 		t = "uint32"
 	}
 
+	emitDoc(tout, docs[ident])
 	fmt.Fprintf(tout, "type %s %s\n", i(ident), t)
 
+	fmt.Fprintf(out, "func (v %s) Valid() bool {\n", i(ident))
+	fmt.Fprintf(out, "return ")
+	for idx, v := range val {
+		if idx > 0 {
+			fmt.Fprintf(out, " || ")
+		}
+		fmt.Fprintf(out, "v == %s", i(v.name))
+	}
+	fmt.Fprintf(out, "\n")
+	fmt.Fprintf(out, "}\n")
+
 	fmt.Fprintf(out, "func (v *%s) Xdr(xs *xdr.XdrState) {\n", i(ident))
+	if *validateEnumFlag {
+		fmt.Fprintf(out, "if xs.Encoding() && !v.Valid() {\n")
+		fmt.Fprintf(out, "xs.SetErrorf(\"invalid %s value %%d\", *v)\n", i(ident))
+		fmt.Fprintf(out, "return\n")
+		fmt.Fprintf(out, "}\n")
+	}
 	fmt.Fprintf(out, "%s", typeInt{*unsignedEnumFlag}.goXdr("v"))
+	if *validateEnumFlag {
+		fmt.Fprintf(out, "if xs.Decoding() && !v.Valid() {\n")
+		fmt.Fprintf(out, "xs.SetErrorf(\"invalid %s value %%d\", *v)\n", i(ident))
+		fmt.Fprintf(out, "}\n")
+	}
+	fmt.Fprintf(out, "}\n")
+
+	fmt.Fprintf(out, "func (v %s) String() string {\n", i(ident))
+	for _, v := range val {
+		fmt.Fprintf(out, "if v == %s { return \"%s\" }\n", i(v.name), v.name)
+	}
+	fmt.Fprintf(out, "return fmt.Sprintf(\"%s(%%d)\", v)\n", i(ident))
 	fmt.Fprintf(out, "}\n")
 
 	for _, v := range val {
+		emitDoc(tout, docs[v.name])
 		fmt.Fprintf(tout, "const %s %s = %s\n", i(v.name), i(ident), v.val)
 	}
+
+	// Enums lifted out of other definitions are not seen by resolve.
+	fixedSizes[ident] = "4"
+	emitXdrSize(ident, "")
+	emitEqualClone(ident, scalarEqual("v", "o"), scalarClone("&c", "v"))
 }
 
 func emitStruct(ident string, val []decl) {
+	val = liftEnumsDecls(ident, val)
+	if link, ok := listLinks[ident]; ok {
+		emitListStruct(ident, val, link)
+		return
+	}
+
+	emitDoc(tout, docs[ident])
 	fmt.Fprintf(tout, "type %s struct {\n", i(ident))
 	for _, v := range val {
 		switch v := v.(type) {
 		case declName:
+			emitDoc(tout, v.doc)
 			fmt.Fprintf(tout, "  %s %s;\n", i(v.n), v.t.goType())
 		}
 	}
@@ -497,12 +979,225 @@ func emitStruct(ident string, val []decl) {
 	fmt.Fprintf(out, "func (v *%s) Xdr(xs *xdr.XdrState) {\n", i(ident))
 	fmt.Fprintf(out, "%s", typeStruct{val}.goXdr("v"))
 	fmt.Fprintf(out, "}\n")
+
+	emitXdrSize(ident, typeStruct{val}.goSize("v"))
+	emitEqualClone(ident, typeStruct{val}.goEqual("v", "o"), typeStruct{val}.goClone("&c", "v"))
 }
 
 func emitUnion(ident string, val typeUnion) {
+	val = liftEnumsUnion(ident, val)
+	if *sumUnionsFlag {
+		emitSumUnion(ident, val)
+		return
+	}
+
+	emitDoc(tout, docs[ident])
 	fmt.Fprintf(tout, "type %s %s\n", i(ident), val.goType())
 
 	fmt.Fprintf(out, "func (v *%s) Xdr(xs *xdr.XdrState) {\n", i(ident))
 	fmt.Fprintf(out, "%s", val.goXdr("v"))
 	fmt.Fprintf(out, "}\n")
+
+	emitXdrSize(ident, val.goSize("v"))
+	emitEqualClone(ident, val.goEqual("v", "o"), val.goClone("&c", "v"))
+}
+
+func caseBodies(cases unionCasesDef) []decl {
+	var res []decl
+	for _, c := range cases.cases {
+		res = append(res, c.body)
+	}
+	return res
+}
+
+// emitSumUnion emits a union as a struct holding the discriminant and
+// an Arm interface, with one type per arm, so that only the arm
+// selected by the discriminant can be set.  Encoding rejects an arm
+// that does not match the discriminant; decoding allocates the arm.
+func emitSumUnion(ident string, val typeUnion) {
+	sw, ok := val.switchDecl.(declName)
+	if !ok {
+		panic("void union switch")
+	}
+
+	u := i(ident)
+	armIface := fmt.Sprintf("is%s_Arm", u)
+	// The discriminant shares the struct with the Arm field.
+	swName := i(sw.n)
+	if swName == "Arm" {
+		swName += "_"
+	}
+	disc := "v." + swName
+
+	emitDoc(tout, docs[ident])
+	fmt.Fprintf(tout, "type %s struct {\n", u)
+	emitDoc(tout, sw.doc)
+	fmt.Fprintf(tout, "%s %s\n", swName, sw.t.goType())
+	fmt.Fprintf(tout, "Arm %s\n", armIface)
+	fmt.Fprintf(tout, "}\n")
+
+	fmt.Fprintf(tout, "type %s interface {\n", armIface)
+	fmt.Fprintf(tout, "%s()\n", armIface)
+	fmt.Fprintf(tout, "}\n")
+
+	// Several cases may share an arm type, if they use the same
+	// field name.
+	armTypes := make(map[string]bool)
+	armType := func(body decl) string {
+		name := u + "_Void"
+		if v, ok := body.(declName); ok {
+			name = u + "_" + i(v.n)
+		}
+		if armTypes[name] {
+			return name
+		}
+		armTypes[name] = true
+
+		fmt.Fprintf(tout, "type %s struct {\n", name)
+		if v, ok := body.(declName); ok {
+			emitDoc(tout, v.doc)
+			fmt.Fprintf(tout, "%s %s\n", i(v.n), v.t.goType())
+		}
+		fmt.Fprintf(tout, "}\n")
+		fmt.Fprintf(tout, "func (*%s) %s() {}\n", name, armIface)
+		return name
+	}
+
+	// Declare the arm types before the Xdr method, since out and tout
+	// may be the same file.
+	for _, body := range caseBodies(val.cases) {
+		armType(body)
+	}
+	if val.cases.def != nil {
+		armType(val.cases.def)
+	}
+
+	emitArm := func(body decl) {
+		name := armType(body)
+		fmt.Fprintf(out, "if xs.Decoding() {\n")
+		fmt.Fprintf(out, "v.Arm = &%s{}\n", name)
+		fmt.Fprintf(out, "}\n")
+
+		v, ok := body.(declName)
+		if !ok {
+			// A nil arm is accepted for void cases.
+			fmt.Fprintf(out, "if _, ok := v.Arm.(*%s); !ok && v.Arm != nil {\n", name)
+		} else {
+			fmt.Fprintf(out, "arm, ok := v.Arm.(*%s)\n", name)
+			fmt.Fprintf(out, "if !ok {\n")
+		}
+		fmt.Fprintf(out, "if xs.Error() == nil {\n")
+		fmt.Fprintf(out, "xs.SetErrorf(\"%s: arm %%T does not match %s %%v\", v.Arm, %s)\n", u, swName, disc)
+		fmt.Fprintf(out, "}\n")
+		fmt.Fprintf(out, "return\n")
+		fmt.Fprintf(out, "}\n")
+		if ok {
+			fmt.Fprintf(out, "%s", v.t.goXdr(fmt.Sprintf("&arm.%s", i(v.n))))
+		}
+	}
+
+	fmt.Fprintf(out, "func (v *%s) Xdr(xs *xdr.XdrState) {\n", u)
+	fmt.Fprintf(out, "%s", sw.t.goXdr("&"+disc))
+	fmt.Fprintf(out, "switch %s {\n", disc)
+	for _, c := range val.cases.cases {
+		fmt.Fprintf(out, "case %s:\n", strings.Join(c.cases, ", "))
+		emitArm(c.body)
+	}
+	fmt.Fprintf(out, "default:\n")
+	if val.cases.def != nil {
+		emitArm(val.cases.def)
+	} else {
+		fmt.Fprintf(out, "%s", invalidDiscriminant(ident, disc))
+	}
+	fmt.Fprintf(out, "}\n")
+	fmt.Fprintf(out, "}\n")
+
+	size := sw.t.goSize("&" + disc)
+	// The arm types of a type switch only need a variable if some
+	// arm's size depends on its value.
+	var armCases string
+	usesArm := false
+	emitted := make(map[string]bool)
+	armSize := func(body decl) {
+		v, ok := body.(declName)
+		if !ok {
+			return
+		}
+		name := u + "_" + i(v.n)
+		if emitted[name] {
+			return
+		}
+		emitted[name] = true
+		armCases += fmt.Sprintf("case *%s:\n", name)
+		armCases += declSize(v, fmt.Sprintf("&arm.%s", i(v.n)))
+		if v.size == "" {
+			usesArm = true
+		}
+	}
+	for _, c := range val.cases.cases {
+		armSize(c.body)
+	}
+	if val.cases.def != nil {
+		armSize(val.cases.def)
+	}
+	if usesArm {
+		size += "switch arm := v.Arm.(type) {\n" + armCases + "}\n"
+	} else if armCases != "" {
+		size += "switch v.Arm.(type) {\n" + armCases + "}\n"
+	}
+	emitXdrSize(ident, size)
+
+	// Void arms are equal to a nil Arm.
+	voidArm := "nil"
+	if armTypes[u+"_Void"] {
+		voidArm += ", *" + u + "_Void"
+	}
+	equal := sw.t.goEqual("&"+disc, "&o."+swName)
+	clone := sw.t.goClone("&c."+swName, "&"+disc)
+	var equalCases, cloneCases string
+	emitted = make(map[string]bool)
+	for _, body := range append(caseBodies(val.cases), val.cases.def) {
+		v, ok := body.(declName)
+		if !ok {
+			continue
+		}
+		name := u + "_" + i(v.n)
+		if emitted[name] {
+			continue
+		}
+		emitted[name] = true
+
+		equalCases += fmt.Sprintf("case *%s:\n", name)
+		equalCases += fmt.Sprintf("oarm, ok := o.Arm.(*%s)\n", name)
+		equalCases += fmt.Sprintf("if !ok {\nreturn false\n}\n")
+		equalCases += v.t.goEqual(fmt.Sprintf("&arm.%s", i(v.n)), fmt.Sprintf("&oarm.%s", i(v.n)))
+
+		cloneCases += fmt.Sprintf("case *%s:\n", name)
+		cloneCases += fmt.Sprintf("carm := &%s{}\n", name)
+		cloneCases += v.t.goClone(fmt.Sprintf("&carm.%s", i(v.n)), fmt.Sprintf("&arm.%s", i(v.n)))
+		cloneCases += fmt.Sprintf("c.Arm = carm\n")
+	}
+
+	armVar := "arm := "
+	if equalCases == "" {
+		armVar = ""
+	}
+	equal += fmt.Sprintf("switch %sv.Arm.(type) {\n", armVar)
+	equal += equalCases
+	equal += fmt.Sprintf("default:\n")
+	equal += fmt.Sprintf("switch o.Arm.(type) {\n")
+	equal += fmt.Sprintf("case %s:\n", voidArm)
+	equal += fmt.Sprintf("default:\n")
+	equal += fmt.Sprintf("return false\n")
+	equal += fmt.Sprintf("}\n")
+	equal += fmt.Sprintf("}\n")
+
+	clone += fmt.Sprintf("switch %sv.Arm.(type) {\n", armVar)
+	clone += cloneCases
+	if armTypes[u+"_Void"] {
+		clone += fmt.Sprintf("case *%s_Void:\n", u)
+		clone += fmt.Sprintf("c.Arm = &%s_Void{}\n", u)
+	}
+	clone += fmt.Sprintf("}\n")
+	emitEqualClone(ident, equal, clone)
 }
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"path"
+	"strings"
+
+	"github.com/zeldovich/go-rpcgen/spec"
+)
+
+// Specs given with -import are parsed for their type and constant
+// definitions, which the input spec may then refer to.  References to
+// them are emitted as qualified names in the imported Go package.
+
+type importedConst struct {
+	goName string
+	n      *big.Int
+}
+
+var importedConsts = make(map[string]importedConst)
+var importedTypes = make(map[string]string)
+
+// typeNames maps each type name that can be referenced in the input
+// spec onto its Go type.  It is filled in by resolve.
+var typeNames = make(map[string]string)
+
+// importSpec handles one -import flag, of the form spec.x=import/path.
+func importSpec(arg string) error {
+	kv := strings.SplitN(arg, "=", 2)
+	if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+		return fmt.Errorf("invalid -import %q, expected spec.x=go/import/path", arg)
+	}
+	filename, importPath := kv[0], kv[1]
+
+	pkg := path.Base(importPath)
+	if p, ok := goPackages[pkg]; ok && p != importPath {
+		return fmt.Errorf("-import %s: package name %s is already used by %s", arg, pkg, p)
+	}
+	goPackages[pkg] = importPath
+
+	f, err := parseFile(filename)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range f.defs {
+		switch d := d.(type) {
+		case constDef:
+			importedConsts[d.name] = importedConst{pkg + "." + constName(d.name), f.Consts[d.name]}
+		case typedefDef:
+			if v, ok := d.d.(declName); ok {
+				importedTypes[v.n] = pkg + "." + i(v.n)
+			}
+		case enumDef:
+			importedTypes[d.name] = pkg + "." + i(d.name)
+			for _, item := range d.items {
+				importedConsts[item.name] = importedConst{pkg + "." + i(item.name), f.Consts[item.name]}
+			}
+		case structDef:
+			importedTypes[d.name] = pkg + "." + i(d.name)
+		case unionDef:
+			importedTypes[d.name] = pkg + "." + i(d.name)
+		}
+	}
+
+	// Imported list types are encoded by the imported package, but
+	// with -list-slices, pointers to them are slices in this one.
+	findLists(f)
+	if len(f.errs) > 0 {
+		f.errs.Sort()
+		return f.errs.Err()
+	}
+	return nil
+}
+
+// resolve fills in typeNames and fixedSizes.  References to undefined
+// types have already been reported by spec.Check.
+func resolve(f *specFile) {
+	for name, goName := range importedTypes {
+		typeNames[name] = goName
+	}
+
+	for _, d := range f.defs {
+		var name string
+		switch d := d.(type) {
+		case typedefDef:
+			if v, ok := d.d.(declName); ok {
+				name = v.n
+			}
+		case enumDef:
+			name = d.name
+		case structDef:
+			name = d.name
+		case unionDef:
+			name = d.name
+		}
+		if name != "" {
+			typeNames[name] = i(name)
+			fixedSizes[name] = f.fixedSize(spec.NamedType{Name: name})
+		}
+	}
+}
+
+// walkTypeIdents calls fn for every type reference in a definition.
+// ptr is set for references that are the target of a pointer.
+func walkTypeIdents(d definition, fn func(t typeIdent, ptr bool)) {
+	switch d := d.(type) {
+	case typedefDef:
+		walkDecl(d.d, fn)
+	case structDef:
+		for _, item := range d.items {
+			walkDecl(item, fn)
+		}
+	case unionDef:
+		walkTypespec(d.u, fn)
+	case progDef:
+		for _, v := range d.vers {
+			for _, c := range v.calls {
+				if !c.arg.isVoid {
+					walkTypespec(c.arg.t, fn)
+				}
+				if !c.res.isVoid {
+					walkTypespec(c.res.t, fn)
+				}
+			}
+		}
+	}
+}
+
+func walkDecl(d decl, fn func(typeIdent, bool)) {
+	v, ok := d.(declName)
+	if !ok {
+		return
+	}
+
+	switch t := v.t.(type) {
+	case declTypeTypespec:
+		walkTypespec(t.t, fn)
+	case declTypeArray:
+		walkTypespec(t.t, fn)
+	case declTypeVarArray:
+		walkTypespec(t.t, fn)
+	case declTypePtr:
+		if ti, ok := t.t.(typeIdent); ok {
+			fn(ti, true)
+			return
+		}
+		walkTypespec(t.t, fn)
+	}
+}
+
+func walkTypespec(t typespec, fn func(typeIdent, bool)) {
+	switch t := t.(type) {
+	case typeIdent:
+		fn(t, false)
+	case typeStruct:
+		for _, item := range t.items {
+			walkDecl(item, fn)
+		}
+	case typeUnion:
+		walkDecl(t.switchDecl, fn)
+		for _, c := range t.cases.cases {
+			walkDecl(c.body, fn)
+		}
+		if t.cases.def != nil {
+			walkDecl(t.cases.def, fn)
+		}
+	}
+}
@@ -1,9 +1,12 @@
 package rfc1057
 
 import (
+	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/zeldovich/go-rpcgen/xdr"
 )
@@ -13,8 +16,20 @@ type Client struct {
 	xid  uint32
 	prog uint32
 	vers uint32
+
+	// cred and verf are used by CallProc.
+	cred Opaque_auth
+	verf Opaque_auth
+
+	// broken is set once a call has been interrupted part-way, which
+	// leaves the connection in an unknown state.
+	broken bool
 }
 
+// errBroken is returned by calls on a Client whose connection was left
+// part-way through a record by an interrupted call.
+var errBroken = errors.New("connection unusable after an interrupted call")
+
 func MakeClient(rw io.ReadWriter, prog, vers uint32) *Client {
 	return &Client{
 		rw:   rw,
@@ -24,7 +39,72 @@ func MakeClient(rw io.ReadWriter, prog, vers uint32) *Client {
 	}
 }
 
+// SetAuth sets the credential and verifier that CallProc sends.
+// By default, both are AUTH_NONE.
+func (c *Client) SetAuth(cred, verf Opaque_auth) {
+	c.cred = cred
+	c.verf = verf
+}
+
+type deadliner interface {
+	SetDeadline(t time.Time) error
+}
+
+// CallProc implements xdr.ProcCaller, so that a Client can be used
+// by generated client stubs.  If the underlying connection supports
+// deadlines, the context's deadline and cancellation interrupt the
+// call.  An interrupted call may leave a request or reply half-way
+// through the connection, so every later call on c fails.  prog and
+// vers must be those that c was made for.
+func (c *Client) CallProc(ctx context.Context, prog, vers, proc uint32, args xdr.Xdrable, resp xdr.Xdrable) error {
+	if prog != c.prog || vers != c.vers {
+		return fmt.Errorf("call to program %d version %d on a client for program %d version %d", prog, vers, c.prog, c.vers)
+	}
+
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	d, ok := c.rw.(deadliner)
+	if !ok {
+		return c.Call(proc, c.cred, c.verf, args, resp)
+	}
+
+	deadline, _ := ctx.Deadline()
+	d.SetDeadline(deadline)
+
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case <-ctx.Done():
+			d.SetDeadline(time.Unix(1, 0))
+		case <-done:
+		}
+	}()
+
+	err = c.Call(proc, c.cred, c.verf, args, resp)
+
+	// Wait for the goroutine, so that it cannot set a deadline
+	// after the one cleared here.
+	close(done)
+	<-exited
+	d.SetDeadline(time.Time{})
+
+	if err != nil && err != errBroken && ctx.Err() != nil {
+		c.broken = true
+		return ctx.Err()
+	}
+	return err
+}
+
 func (c *Client) Call(proc uint32, cred, verf Opaque_auth, args xdr.Xdrable, resp xdr.Xdrable) error {
+	if c.broken {
+		return errBroken
+	}
+
 	c.xid++
 
 	var req Rpc_msg
@@ -37,7 +117,7 @@ func (c *Client) Call(proc uint32, cred, verf Opaque_auth, args xdr.Xdrable, res
 	req.Body.Cbody.Cred = cred
 	req.Body.Cbody.Verf = verf
 
-	wb := &rwBuffer{}
+	wb := newRecord(&req, args)
 	wr := xdr.MakeWriter(wb)
 	req.Xdr(wr)
 	err := wr.Error()
@@ -51,13 +131,12 @@ func (c *Client) Call(proc uint32, cred, verf Opaque_auth, args xdr.Xdrable, res
 		return err
 	}
 
-	var hdr [4]byte
-	binary.BigEndian.PutUint32(hdr[:], (1<<31)|uint32(len(wb.buf)))
-	_, err = c.rw.Write(append(hdr[:], wb.buf...))
+	_, err = c.rw.Write(wb.record())
 	if err != nil {
 		return err
 	}
 
+	var hdr [4]byte
 	_, err = io.ReadFull(c.rw, hdr[:])
 	if err != nil {
 		return err
@@ -88,15 +167,15 @@ func (c *Client) Call(proc uint32, cred, verf Opaque_auth, args xdr.Xdrable, res
 	}
 
 	if res.Body.Mtype != REPLY {
-		return fmt.Errorf("expected REPLY, got %d", res.Body.Mtype)
+		return fmt.Errorf("expected REPLY, got %v", res.Body.Mtype)
 	}
 
 	if res.Body.Rbody.Stat != MSG_ACCEPTED {
-		return fmt.Errorf("MSG_DENIED stat %d", res.Body.Rbody.Rreply.Stat)
+		return fmt.Errorf("MSG_DENIED stat %v", res.Body.Rbody.Rreply.Stat)
 	}
 
 	if res.Body.Rbody.Areply.Reply_data.Stat != SUCCESS {
-		return fmt.Errorf("accept_stat %d", res.Body.Rbody.Areply.Reply_data.Stat)
+		return fmt.Errorf("accept_stat %v", res.Body.Rbody.Areply.Reply_data.Stat)
 	}
 
 	resp.Xdr(rd)
@@ -0,0 +1,99 @@
+package rfc1057
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/zeldovich/go-rpcgen/xdr"
+)
+
+const (
+	testProg = 0x20000001
+	testVers = 1
+
+	procFast = 1
+	procSlow = 2
+)
+
+// testServer serves procFast, which returns at once, and procSlow,
+// which returns once release is closed, on one end of a pipe.  It
+// returns a Client for the other end.
+func testServer(t *testing.T, release chan struct{}) *Client {
+	srv := MakeServer()
+	srv.Register(testProg, testVers, procFast, func(args *xdr.XdrState) (xdr.Xdrable, error) {
+		return &xdr.Void{}, nil
+	})
+	srv.Register(testProg, testVers, procSlow, func(args *xdr.XdrState) (xdr.Xdrable, error) {
+		<-release
+		return &xdr.Void{}, nil
+	})
+
+	cc, sc := net.Pipe()
+	t.Cleanup(func() {
+		cc.Close()
+		sc.Close()
+	})
+	go srv.Run(sc)
+
+	return MakeClient(cc, testProg, testVers)
+}
+
+// A call interrupted by its context leaves the connection mid-record,
+// so later calls must not use it.
+func TestCallProcCancel(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	c := testServer(t, release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	err := c.CallProc(ctx, testProg, testVers, procSlow, &xdr.Void{}, &xdr.Void{})
+	if err != context.Canceled {
+		t.Fatalf("interrupted call returned %v, want %v", err, context.Canceled)
+	}
+
+	err = c.CallProc(context.Background(), testProg, testVers, procFast, &xdr.Void{}, &xdr.Void{})
+	if err != errBroken {
+		t.Fatalf("call after interrupted call returned %v, want %v", err, errBroken)
+	}
+}
+
+// The deadline of a call does not apply to later calls, including those
+// made with Call, which does not set deadlines itself.
+func TestCallProcDeadline(t *testing.T) {
+	c := testServer(t, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := c.CallProc(ctx, testProg, testVers, procFast, &xdr.Void{}, &xdr.Void{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	err = c.Call(procFast, c.cred, c.verf, &xdr.Void{}, &xdr.Void{})
+	if err != nil {
+		t.Fatalf("call after deadline returned %v", err)
+	}
+}
+
+// A Client only issues calls for the program and version it was made
+// for.
+func TestCallProcMismatch(t *testing.T) {
+	c := testServer(t, nil)
+
+	err := c.CallProc(context.Background(), testProg, testVers+1, procFast, &xdr.Void{}, &xdr.Void{})
+	if err == nil {
+		t.Fatal("call for another version succeeded")
+	}
+
+	err = c.CallProc(context.Background(), testProg, testVers, procFast, &xdr.Void{}, &xdr.Void{})
+	if err != nil {
+		t.Fatalf("call after rejected call returned %v", err)
+	}
+}
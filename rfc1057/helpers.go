@@ -1,7 +1,10 @@
 package rfc1057
 
 import (
+	"encoding/binary"
 	"io"
+
+	"github.com/zeldovich/go-rpcgen/xdr"
 )
 
 type rwBuffer struct {
@@ -14,11 +17,28 @@ func (rw *rwBuffer) Write(data []byte) (n int, err error) {
 }
 
 func (rw *rwBuffer) Read(buf []byte) (n int, err error) {
-	copy(buf, rw.buf)
-	rw.buf = rw.buf[len(buf):]
-	n = len(buf)
-	if n == 0 {
-		err = io.EOF
+	if len(rw.buf) == 0 && len(buf) > 0 {
+		return 0, io.EOF
 	}
+	n = copy(buf, rw.buf)
+	rw.buf = rw.buf[n:]
 	return
 }
+
+// newRecord returns a buffer for encoding an RPC record made of msg
+// and body, starting with space for the record-marking header.  The
+// buffer is allocated at its final size if body implements xdr.Sizer.
+func newRecord(msg *Rpc_msg, body xdr.Xdrable) *rwBuffer {
+	size := 4 + msg.XdrSize()
+	if s, ok := body.(xdr.Sizer); ok {
+		size += s.XdrSize()
+	}
+	return &rwBuffer{make([]byte, 4, size)}
+}
+
+// record fills in the record-marking header of a buffer from newRecord,
+// and returns the complete record.
+func (rw *rwBuffer) record() []byte {
+	binary.BigEndian.PutUint32(rw.buf[:4], (1<<31)|uint32(len(rw.buf)-4))
+	return rw.buf
+}
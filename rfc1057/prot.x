@@ -23,7 +23,8 @@ enum accept_stat {
    PROG_UNAVAIL  = 1, /* remote hasn't exported program  */
    PROG_MISMATCH = 2, /* remote can't support version #  */
    PROC_UNAVAIL  = 3, /* program can't support procedure */
-   GARBAGE_ARGS  = 4  /* procedure can't decode params   */ };
+   GARBAGE_ARGS  = 4, /* procedure can't decode params   */
+   SYSTEM_ERR    = 5  /* errors like memory allocation failure */ };
 
 enum reject_stat {
    RPC_MISMATCH = 0, /* RPC version number != 2          */
@@ -1,9 +1,11 @@
 package rfc1057
 
 import (
+	"context"
 	"encoding/binary"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"sync"
 
@@ -12,31 +14,45 @@ import (
 
 type ProcHandler func(args *xdr.XdrState) (res xdr.Xdrable, err error)
 
+// A ContextProcHandler is a ProcHandler that also receives the
+// connection's context and information about the call.  If it returns
+// an error after successfully decoding its arguments, the server
+// replies with SYSTEM_ERR.
+type ContextProcHandler func(ctx context.Context, call *xdr.CallInfo, args *xdr.XdrState) (res xdr.Xdrable, err error)
+
 type Server struct {
-	handlers map[uint32]map[uint32]map[uint32]ProcHandler
+	handlers map[uint32]map[uint32]map[uint32]ContextProcHandler
 }
 
 type serverConn struct {
 	s       *Server
 	rw      io.ReadWriter
+	ctx     context.Context
+	peer    net.Addr
 	writeMu sync.Mutex
 }
 
 func MakeServer() *Server {
 	return &Server{
-		handlers: make(map[uint32]map[uint32]map[uint32]ProcHandler),
+		handlers: make(map[uint32]map[uint32]map[uint32]ContextProcHandler),
 	}
 }
 
 func (s *Server) Register(prog, vers, proc uint32, handler ProcHandler) {
+	s.RegisterContext(prog, vers, proc, func(ctx context.Context, call *xdr.CallInfo, args *xdr.XdrState) (xdr.Xdrable, error) {
+		return handler(args)
+	})
+}
+
+func (s *Server) RegisterContext(prog, vers, proc uint32, handler ContextProcHandler) {
 	_, progok := s.handlers[prog]
 	if !progok {
-		s.handlers[prog] = make(map[uint32]map[uint32]ProcHandler)
+		s.handlers[prog] = make(map[uint32]map[uint32]ContextProcHandler)
 	}
 
 	_, versok := s.handlers[prog][vers]
 	if !versok {
-		s.handlers[prog][vers] = make(map[uint32]ProcHandler)
+		s.handlers[prog][vers] = make(map[uint32]ContextProcHandler)
 	}
 
 	s.handlers[prog][vers][proc] = handler
@@ -44,14 +60,29 @@ func (s *Server) Register(prog, vers, proc uint32, handler ProcHandler) {
 
 func (s *Server) RegisterMany(regs []xdr.ProcRegistration) {
 	for _, r := range regs {
-		s.Register(r.Prog, r.Vers, r.Proc, r.Handler)
+		if r.ContextHandler != nil {
+			s.RegisterContext(r.Prog, r.Vers, r.Proc, r.ContextHandler)
+		} else {
+			s.Register(r.Prog, r.Vers, r.Proc, r.Handler)
+		}
 	}
 }
 
+// Run serves requests from rw until it returns an error.  The context
+// passed to handlers is canceled when Run returns.
 func (s *Server) Run(rw io.ReadWriter) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	sc := &serverConn{
-		s:  s,
-		rw: rw,
+		s:   s,
+		rw:  rw,
+		ctx: ctx,
+	}
+
+	conn, ok := rw.(net.Conn)
+	if ok {
+		sc.peer = conn.RemoteAddr()
 	}
 
 	for {
@@ -95,7 +126,7 @@ func (sc *serverConn) handleReqErr(buf []byte) error {
 	}
 
 	if req.Body.Mtype != CALL {
-		return fmt.Errorf("request mtype %d != CALL", req.Body.Mtype)
+		return fmt.Errorf("request mtype %v != CALL", req.Body.Mtype)
 	}
 
 	var res Rpc_msg
@@ -126,9 +157,30 @@ func (sc *serverConn) handleReqErr(buf []byte) error {
 			goto reply
 		}
 
-		resdata, err = h(rd)
+		call := &xdr.CallInfo{
+			Xid:  req.Xid,
+			Prog: req.Body.Cbody.Prog,
+			Vers: req.Body.Cbody.Vers,
+			Proc: req.Body.Cbody.Proc,
+			Cred: xdr.OpaqueAuth{
+				Flavor: uint32(req.Body.Cbody.Cred.Flavor),
+				Body:   req.Body.Cbody.Cred.Body,
+			},
+			Verf: xdr.OpaqueAuth{
+				Flavor: uint32(req.Body.Cbody.Verf.Flavor),
+				Body:   req.Body.Cbody.Verf.Body,
+			},
+			Peer: sc.peer,
+		}
+
+		resdata, err = h(sc.ctx, call, rd)
 		if err != nil {
-			res.Body.Rbody.Areply.Reply_data.Stat = GARBAGE_ARGS
+			resdata = nil
+			if rd.Error() != nil {
+				res.Body.Rbody.Areply.Reply_data.Stat = GARBAGE_ARGS
+			} else {
+				res.Body.Rbody.Areply.Reply_data.Stat = SYSTEM_ERR
+			}
 			goto reply
 		}
 
@@ -136,7 +188,7 @@ func (sc *serverConn) handleReqErr(buf []byte) error {
 	}
 
 reply:
-	wb := &rwBuffer{}
+	wb := newRecord(&res, resdata)
 	wr := xdr.MakeWriter(wb)
 	res.Xdr(wr)
 	err = wr.Error()
@@ -152,12 +204,8 @@ reply:
 		}
 	}
 
-	var hdr [4]byte
-	binary.BigEndian.PutUint32(hdr[:], (1<<31)|uint32(len(wb.buf)))
-	resbuf := append(hdr[:], wb.buf...)
-
 	sc.writeMu.Lock()
 	defer sc.writeMu.Unlock()
-	_, err = sc.rw.Write(resbuf)
+	_, err = sc.rw.Write(wb.record())
 	return err
 }
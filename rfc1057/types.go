@@ -6,6 +6,7 @@ const AUTH_NONE Auth_flavor = 0
 const AUTH_UNIX Auth_flavor = 1
 const AUTH_SHORT Auth_flavor = 2
 const AUTH_DES Auth_flavor = 3
+const Auth_flavor_XdrSize = 4
 
 type Opaque_auth struct {
 	Flavor Auth_flavor
@@ -15,32 +16,61 @@ type Msg_type uint32
 
 const CALL Msg_type = 0
 const REPLY Msg_type = 1
+const Msg_type_XdrSize = 4
 
 type Reply_stat uint32
 
 const MSG_ACCEPTED Reply_stat = 0
 const MSG_DENIED Reply_stat = 1
+const Reply_stat_XdrSize = 4
 
 type Accept_stat uint32
 
+// RPC executed successfully
 const SUCCESS Accept_stat = 0
+
+// remote hasn't exported program
 const PROG_UNAVAIL Accept_stat = 1
+
+// remote can't support version #
 const PROG_MISMATCH Accept_stat = 2
+
+// program can't support procedure
 const PROC_UNAVAIL Accept_stat = 3
+
+// procedure can't decode params
 const GARBAGE_ARGS Accept_stat = 4
 
+// errors like memory allocation failure
+const SYSTEM_ERR Accept_stat = 5
+const Accept_stat_XdrSize = 4
+
 type Reject_stat uint32
 
+// RPC version number != 2
 const RPC_MISMATCH Reject_stat = 0
+
+// remote can't authenticate caller
 const AUTH_ERROR Reject_stat = 1
+const Reject_stat_XdrSize = 4
 
 type Auth_stat uint32
 
+// bad credential (seal broken)
 const AUTH_BADCRED Auth_stat = 1
+
+// client must begin new session
 const AUTH_REJECTEDCRED Auth_stat = 2
+
+// bad verifier (seal broken)
 const AUTH_BADVERF Auth_stat = 3
+
+// verifier expired or replayed
 const AUTH_REJECTEDVERF Auth_stat = 4
+
+// rejected for security reasons
 const AUTH_TOOWEAK Auth_stat = 5
+const Auth_stat_XdrSize = 4
 
 type Rpc_msg struct {
 	Xid  uint32
@@ -51,6 +81,7 @@ type Rpc_msg struct {
 	}
 }
 type Call_body struct {
+	// must be equal to two (2)
 	Rpcvers uint32
 	Prog    uint32
 	Vers    uint32
@@ -90,6 +121,7 @@ type Auth_unix struct {
 	Gids        []uint32
 }
 
+// portmapper port number
 const PMAP_PORT uint32 = 111
 
 type Mapping struct {
@@ -99,7 +131,12 @@ type Mapping struct {
 	Port uint32
 }
 
+const Mapping_XdrSize = 16
+
+// protocol number for TCP/IP
 const IPPROTO_TCP uint32 = 6
+
+// protocol number for UDP/IP
 const IPPROTO_UDP uint32 = 17
 
 type Pmaplist struct{ P *Pmaplistelem }
@@ -118,8 +155,12 @@ type Call_result struct {
 	Res  []byte
 }
 type Uint32 uint32
+
+const Uint32_XdrSize = 4
+
 type Xbool bool
 
+const Xbool_XdrSize = 4
 const PMAP_PROG uint32 = 100000
 const PMAP_VERS uint32 = 2
 const PMAPPROC_NULL uint32 = 0
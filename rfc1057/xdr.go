@@ -1,29 +1,251 @@
 package rfc1057
 
+import "bytes"
+import "context"
+import "fmt"
 import "github.com/zeldovich/go-rpcgen/xdr"
 
+func (v Auth_flavor) Valid() bool {
+	return v == AUTH_NONE || v == AUTH_UNIX || v == AUTH_SHORT || v == AUTH_DES
+}
 func (v *Auth_flavor) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(v))
 }
+func (v Auth_flavor) String() string {
+	if v == AUTH_NONE {
+		return "AUTH_NONE"
+	}
+	if v == AUTH_UNIX {
+		return "AUTH_UNIX"
+	}
+	if v == AUTH_SHORT {
+		return "AUTH_SHORT"
+	}
+	if v == AUTH_DES {
+		return "AUTH_DES"
+	}
+	return fmt.Sprintf("Auth_flavor(%d)", v)
+}
+func (v *Auth_flavor) XdrSize() int {
+	return Auth_flavor_XdrSize
+}
+func (v *Auth_flavor) Equal(o *Auth_flavor) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Auth_flavor) Clone() (c Auth_flavor) {
+	*(&c) = *(v)
+	return
+}
 func (v *Opaque_auth) Xdr(xs *xdr.XdrState) {
 	(*Auth_flavor)(&((v).Flavor)).Xdr(xs)
 	xdr.XdrVarArray(xs, int(400), (*[]byte)(&((v).Body)))
 }
+func (v *Opaque_auth) XdrSize() (n int) {
+	n += 4
+	n += 4 + (len(*(&((v).Body)))+3)&^3
+	return
+}
+func (v *Opaque_auth) Equal(o *Opaque_auth) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Auth_flavor)(&((v).Flavor)).Equal((*Auth_flavor)(&((o).Flavor))) {
+		return false
+	}
+	if !bytes.Equal(*(&((v).Body)), *(&((o).Body))) {
+		return false
+	}
+	return true
+}
+func (v *Opaque_auth) Clone() (c Opaque_auth) {
+	*(*Auth_flavor)(&((&c).Flavor)) = (*Auth_flavor)(&((v).Flavor)).Clone()
+	if *(&((v).Body)) != nil {
+		*(&((&c).Body)) = append([]byte{}, *(&((v).Body))...)
+	}
+	return
+}
+func (v Msg_type) Valid() bool {
+	return v == CALL || v == REPLY
+}
 func (v *Msg_type) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(v))
 }
+func (v Msg_type) String() string {
+	if v == CALL {
+		return "CALL"
+	}
+	if v == REPLY {
+		return "REPLY"
+	}
+	return fmt.Sprintf("Msg_type(%d)", v)
+}
+func (v *Msg_type) XdrSize() int {
+	return Msg_type_XdrSize
+}
+func (v *Msg_type) Equal(o *Msg_type) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Msg_type) Clone() (c Msg_type) {
+	*(&c) = *(v)
+	return
+}
+func (v Reply_stat) Valid() bool {
+	return v == MSG_ACCEPTED || v == MSG_DENIED
+}
 func (v *Reply_stat) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(v))
 }
+func (v Reply_stat) String() string {
+	if v == MSG_ACCEPTED {
+		return "MSG_ACCEPTED"
+	}
+	if v == MSG_DENIED {
+		return "MSG_DENIED"
+	}
+	return fmt.Sprintf("Reply_stat(%d)", v)
+}
+func (v *Reply_stat) XdrSize() int {
+	return Reply_stat_XdrSize
+}
+func (v *Reply_stat) Equal(o *Reply_stat) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Reply_stat) Clone() (c Reply_stat) {
+	*(&c) = *(v)
+	return
+}
+func (v Accept_stat) Valid() bool {
+	return v == SUCCESS || v == PROG_UNAVAIL || v == PROG_MISMATCH || v == PROC_UNAVAIL || v == GARBAGE_ARGS || v == SYSTEM_ERR
+}
 func (v *Accept_stat) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(v))
 }
+func (v Accept_stat) String() string {
+	if v == SUCCESS {
+		return "SUCCESS"
+	}
+	if v == PROG_UNAVAIL {
+		return "PROG_UNAVAIL"
+	}
+	if v == PROG_MISMATCH {
+		return "PROG_MISMATCH"
+	}
+	if v == PROC_UNAVAIL {
+		return "PROC_UNAVAIL"
+	}
+	if v == GARBAGE_ARGS {
+		return "GARBAGE_ARGS"
+	}
+	if v == SYSTEM_ERR {
+		return "SYSTEM_ERR"
+	}
+	return fmt.Sprintf("Accept_stat(%d)", v)
+}
+func (v *Accept_stat) XdrSize() int {
+	return Accept_stat_XdrSize
+}
+func (v *Accept_stat) Equal(o *Accept_stat) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Accept_stat) Clone() (c Accept_stat) {
+	*(&c) = *(v)
+	return
+}
+func (v Reject_stat) Valid() bool {
+	return v == RPC_MISMATCH || v == AUTH_ERROR
+}
 func (v *Reject_stat) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(v))
 }
+func (v Reject_stat) String() string {
+	if v == RPC_MISMATCH {
+		return "RPC_MISMATCH"
+	}
+	if v == AUTH_ERROR {
+		return "AUTH_ERROR"
+	}
+	return fmt.Sprintf("Reject_stat(%d)", v)
+}
+func (v *Reject_stat) XdrSize() int {
+	return Reject_stat_XdrSize
+}
+func (v *Reject_stat) Equal(o *Reject_stat) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Reject_stat) Clone() (c Reject_stat) {
+	*(&c) = *(v)
+	return
+}
+func (v Auth_stat) Valid() bool {
+	return v == AUTH_BADCRED || v == AUTH_REJECTEDCRED || v == AUTH_BADVERF || v == AUTH_REJECTEDVERF || v == AUTH_TOOWEAK
+}
 func (v *Auth_stat) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(v))
 }
+func (v Auth_stat) String() string {
+	if v == AUTH_BADCRED {
+		return "AUTH_BADCRED"
+	}
+	if v == AUTH_REJECTEDCRED {
+		return "AUTH_REJECTEDCRED"
+	}
+	if v == AUTH_BADVERF {
+		return "AUTH_BADVERF"
+	}
+	if v == AUTH_REJECTEDVERF {
+		return "AUTH_REJECTEDVERF"
+	}
+	if v == AUTH_TOOWEAK {
+		return "AUTH_TOOWEAK"
+	}
+	return fmt.Sprintf("Auth_stat(%d)", v)
+}
+func (v *Auth_stat) XdrSize() int {
+	return Auth_stat_XdrSize
+}
+func (v *Auth_stat) Equal(o *Auth_stat) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Auth_stat) Clone() (c Auth_stat) {
+	*(&c) = *(v)
+	return
+}
 func (v *Rpc_msg) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(&((v).Xid)))
 	(*Msg_type)(&((&((v).Body)).Mtype)).Xdr(xs)
@@ -32,7 +254,55 @@ func (v *Rpc_msg) Xdr(xs *xdr.XdrState) {
 		(*Call_body)(&((&((v).Body)).Cbody)).Xdr(xs)
 	case REPLY:
 		(*Reply_body)(&((&((v).Body)).Rbody)).Xdr(xs)
+	default:
+		if xs.Error() == nil {
+			xs.SetErrorf("invalid Rpc_msg_body discriminant %v", (&((v).Body)).Mtype)
+		}
+	}
+}
+func (v *Rpc_msg) XdrSize() (n int) {
+	n += 4
+	n += 4
+	switch (&((v).Body)).Mtype {
+	case CALL:
+		n += (*Call_body)(&((&((v).Body)).Cbody)).XdrSize()
+	case REPLY:
+		n += (*Reply_body)(&((&((v).Body)).Rbody)).XdrSize()
+	}
+	return
+}
+func (v *Rpc_msg) Equal(o *Rpc_msg) bool {
+	if v == nil || o == nil {
+		return v == o
 	}
+	if *(&((v).Xid)) != *(&((o).Xid)) {
+		return false
+	}
+	if !(*Msg_type)(&((&((v).Body)).Mtype)).Equal((*Msg_type)(&((&((o).Body)).Mtype))) {
+		return false
+	}
+	switch (&((v).Body)).Mtype {
+	case CALL:
+		if !(*Call_body)(&((&((v).Body)).Cbody)).Equal((*Call_body)(&((&((o).Body)).Cbody))) {
+			return false
+		}
+	case REPLY:
+		if !(*Reply_body)(&((&((v).Body)).Rbody)).Equal((*Reply_body)(&((&((o).Body)).Rbody))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *Rpc_msg) Clone() (c Rpc_msg) {
+	*(&((&c).Xid)) = *(&((v).Xid))
+	*(*Msg_type)(&((&((&c).Body)).Mtype)) = (*Msg_type)(&((&((v).Body)).Mtype)).Clone()
+	switch (&((v).Body)).Mtype {
+	case CALL:
+		*(*Call_body)(&((&((&c).Body)).Cbody)) = (*Call_body)(&((&((v).Body)).Cbody)).Clone()
+	case REPLY:
+		*(*Reply_body)(&((&((&c).Body)).Rbody)) = (*Reply_body)(&((&((v).Body)).Rbody)).Clone()
+	}
+	return
 }
 func (v *Call_body) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(&((v).Rpcvers)))
@@ -42,6 +312,48 @@ func (v *Call_body) Xdr(xs *xdr.XdrState) {
 	(*Opaque_auth)(&((v).Cred)).Xdr(xs)
 	(*Opaque_auth)(&((v).Verf)).Xdr(xs)
 }
+func (v *Call_body) XdrSize() (n int) {
+	n += 4
+	n += 4
+	n += 4
+	n += 4
+	n += (*Opaque_auth)(&((v).Cred)).XdrSize()
+	n += (*Opaque_auth)(&((v).Verf)).XdrSize()
+	return
+}
+func (v *Call_body) Equal(o *Call_body) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(&((v).Rpcvers)) != *(&((o).Rpcvers)) {
+		return false
+	}
+	if *(&((v).Prog)) != *(&((o).Prog)) {
+		return false
+	}
+	if *(&((v).Vers)) != *(&((o).Vers)) {
+		return false
+	}
+	if *(&((v).Proc)) != *(&((o).Proc)) {
+		return false
+	}
+	if !(*Opaque_auth)(&((v).Cred)).Equal((*Opaque_auth)(&((o).Cred))) {
+		return false
+	}
+	if !(*Opaque_auth)(&((v).Verf)).Equal((*Opaque_auth)(&((o).Verf))) {
+		return false
+	}
+	return true
+}
+func (v *Call_body) Clone() (c Call_body) {
+	*(&((&c).Rpcvers)) = *(&((v).Rpcvers))
+	*(&((&c).Prog)) = *(&((v).Prog))
+	*(&((&c).Vers)) = *(&((v).Vers))
+	*(&((&c).Proc)) = *(&((v).Proc))
+	*(*Opaque_auth)(&((&c).Cred)) = (*Opaque_auth)(&((v).Cred)).Clone()
+	*(*Opaque_auth)(&((&c).Verf)) = (*Opaque_auth)(&((v).Verf)).Clone()
+	return
+}
 func (v *Reply_body) Xdr(xs *xdr.XdrState) {
 	(*Reply_stat)(&((v).Stat)).Xdr(xs)
 	switch (v).Stat {
@@ -49,7 +361,50 @@ func (v *Reply_body) Xdr(xs *xdr.XdrState) {
 		(*Accepted_reply)(&((v).Areply)).Xdr(xs)
 	case MSG_DENIED:
 		(*Rejected_reply)(&((v).Rreply)).Xdr(xs)
+	default:
+		if xs.Error() == nil {
+			xs.SetErrorf("invalid Reply_body discriminant %v", (v).Stat)
+		}
+	}
+}
+func (v *Reply_body) XdrSize() (n int) {
+	n += 4
+	switch (v).Stat {
+	case MSG_ACCEPTED:
+		n += (*Accepted_reply)(&((v).Areply)).XdrSize()
+	case MSG_DENIED:
+		n += (*Rejected_reply)(&((v).Rreply)).XdrSize()
 	}
+	return
+}
+func (v *Reply_body) Equal(o *Reply_body) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Reply_stat)(&((v).Stat)).Equal((*Reply_stat)(&((o).Stat))) {
+		return false
+	}
+	switch (v).Stat {
+	case MSG_ACCEPTED:
+		if !(*Accepted_reply)(&((v).Areply)).Equal((*Accepted_reply)(&((o).Areply))) {
+			return false
+		}
+	case MSG_DENIED:
+		if !(*Rejected_reply)(&((v).Rreply)).Equal((*Rejected_reply)(&((o).Rreply))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *Reply_body) Clone() (c Reply_body) {
+	*(*Reply_stat)(&((&c).Stat)) = (*Reply_stat)(&((v).Stat)).Clone()
+	switch (v).Stat {
+	case MSG_ACCEPTED:
+		*(*Accepted_reply)(&((&c).Areply)) = (*Accepted_reply)(&((v).Areply)).Clone()
+	case MSG_DENIED:
+		*(*Rejected_reply)(&((&c).Rreply)) = (*Rejected_reply)(&((v).Rreply)).Clone()
+	}
+	return
 }
 func (v *Accepted_reply) Xdr(xs *xdr.XdrState) {
 	(*Opaque_auth)(&((v).Verf)).Xdr(xs)
@@ -63,6 +418,54 @@ func (v *Accepted_reply) Xdr(xs *xdr.XdrState) {
 	default:
 	}
 }
+func (v *Accepted_reply) XdrSize() (n int) {
+	n += (*Opaque_auth)(&((v).Verf)).XdrSize()
+	n += 4
+	switch (&((v).Reply_data)).Stat {
+	case SUCCESS:
+		n += 0
+	case PROG_MISMATCH:
+		n += 8
+	}
+	return
+}
+func (v *Accepted_reply) Equal(o *Accepted_reply) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Opaque_auth)(&((v).Verf)).Equal((*Opaque_auth)(&((o).Verf))) {
+		return false
+	}
+	if !(*Accept_stat)(&((&((v).Reply_data)).Stat)).Equal((*Accept_stat)(&((&((o).Reply_data)).Stat))) {
+		return false
+	}
+	switch (&((v).Reply_data)).Stat {
+	case SUCCESS:
+		if *(&((&((v).Reply_data)).Results)) != *(&((&((o).Reply_data)).Results)) {
+			return false
+		}
+	case PROG_MISMATCH:
+		if *(&((&((&((v).Reply_data)).Mismatch_info)).Low)) != *(&((&((&((o).Reply_data)).Mismatch_info)).Low)) {
+			return false
+		}
+		if *(&((&((&((v).Reply_data)).Mismatch_info)).High)) != *(&((&((&((o).Reply_data)).Mismatch_info)).High)) {
+			return false
+		}
+	}
+	return true
+}
+func (v *Accepted_reply) Clone() (c Accepted_reply) {
+	*(*Opaque_auth)(&((&c).Verf)) = (*Opaque_auth)(&((v).Verf)).Clone()
+	*(*Accept_stat)(&((&((&c).Reply_data)).Stat)) = (*Accept_stat)(&((&((v).Reply_data)).Stat)).Clone()
+	switch (&((v).Reply_data)).Stat {
+	case SUCCESS:
+		*(&((&((&c).Reply_data)).Results)) = *(&((&((v).Reply_data)).Results))
+	case PROG_MISMATCH:
+		*(&((&((&((&c).Reply_data)).Mismatch_info)).Low)) = *(&((&((&((v).Reply_data)).Mismatch_info)).Low))
+		*(&((&((&((&c).Reply_data)).Mismatch_info)).High)) = *(&((&((&((v).Reply_data)).Mismatch_info)).High))
+	}
+	return
+}
 func (v *Rejected_reply) Xdr(xs *xdr.XdrState) {
 	(*Reject_stat)(&((v).Stat)).Xdr(xs)
 	switch (v).Stat {
@@ -71,7 +474,54 @@ func (v *Rejected_reply) Xdr(xs *xdr.XdrState) {
 		xdr.XdrU32(xs, (*uint32)(&((&((v).Mismatch_info)).High)))
 	case AUTH_ERROR:
 		(*Auth_stat)(&((v).Astat)).Xdr(xs)
+	default:
+		if xs.Error() == nil {
+			xs.SetErrorf("invalid Rejected_reply discriminant %v", (v).Stat)
+		}
+	}
+}
+func (v *Rejected_reply) XdrSize() (n int) {
+	n += 4
+	switch (v).Stat {
+	case RPC_MISMATCH:
+		n += 8
+	case AUTH_ERROR:
+		n += 4
 	}
+	return
+}
+func (v *Rejected_reply) Equal(o *Rejected_reply) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Reject_stat)(&((v).Stat)).Equal((*Reject_stat)(&((o).Stat))) {
+		return false
+	}
+	switch (v).Stat {
+	case RPC_MISMATCH:
+		if *(&((&((v).Mismatch_info)).Low)) != *(&((&((o).Mismatch_info)).Low)) {
+			return false
+		}
+		if *(&((&((v).Mismatch_info)).High)) != *(&((&((o).Mismatch_info)).High)) {
+			return false
+		}
+	case AUTH_ERROR:
+		if !(*Auth_stat)(&((v).Astat)).Equal((*Auth_stat)(&((o).Astat))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *Rejected_reply) Clone() (c Rejected_reply) {
+	*(*Reject_stat)(&((&c).Stat)) = (*Reject_stat)(&((v).Stat)).Clone()
+	switch (v).Stat {
+	case RPC_MISMATCH:
+		*(&((&((&c).Mismatch_info)).Low)) = *(&((&((v).Mismatch_info)).Low))
+		*(&((&((&c).Mismatch_info)).High)) = *(&((&((v).Mismatch_info)).High))
+	case AUTH_ERROR:
+		*(*Auth_stat)(&((&c).Astat)) = (*Auth_stat)(&((v).Astat)).Clone()
+	}
+	return
 }
 func (v *Auth_unix) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(&((v).Stamp)))
@@ -96,12 +546,88 @@ func (v *Auth_unix) Xdr(xs *xdr.XdrState) {
 		}
 	}
 }
+func (v *Auth_unix) XdrSize() (n int) {
+	n += 4
+	n += 4 + (len(*(&((v).Machinename)))+3)&^3
+	n += 4
+	n += 4
+	n += 4
+	n += len(*(&((v).Gids))) * (4)
+	return
+}
+func (v *Auth_unix) Equal(o *Auth_unix) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(&((v).Stamp)) != *(&((o).Stamp)) {
+		return false
+	}
+	if *(&((v).Machinename)) != *(&((o).Machinename)) {
+		return false
+	}
+	if *(&((v).Uid)) != *(&((o).Uid)) {
+		return false
+	}
+	if *(&((v).Gid)) != *(&((o).Gid)) {
+		return false
+	}
+	if len(*(&((v).Gids))) != len(*(&((o).Gids))) {
+		return false
+	}
+	for i := range *(&((v).Gids)) {
+		if *(&((*(&((v).Gids)))[i])) != *(&((*(&((o).Gids)))[i])) {
+			return false
+		}
+	}
+	return true
+}
+func (v *Auth_unix) Clone() (c Auth_unix) {
+	*(&((&c).Stamp)) = *(&((v).Stamp))
+	*(&((&c).Machinename)) = *(&((v).Machinename))
+	*(&((&c).Uid)) = *(&((v).Uid))
+	*(&((&c).Gid)) = *(&((v).Gid))
+	if *(&((v).Gids)) != nil {
+		*(&((&c).Gids)) = make([]uint32, len(*(&((v).Gids))))
+		for i := range *(&((v).Gids)) {
+			*(&((*(&((&c).Gids)))[i])) = *(&((*(&((v).Gids)))[i]))
+		}
+	}
+	return
+}
 func (v *Mapping) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(&((v).Prog)))
 	xdr.XdrU32(xs, (*uint32)(&((v).Vers)))
 	xdr.XdrU32(xs, (*uint32)(&((v).Prot)))
 	xdr.XdrU32(xs, (*uint32)(&((v).Port)))
 }
+func (v *Mapping) XdrSize() int {
+	return Mapping_XdrSize
+}
+func (v *Mapping) Equal(o *Mapping) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(&((v).Prog)) != *(&((o).Prog)) {
+		return false
+	}
+	if *(&((v).Vers)) != *(&((o).Vers)) {
+		return false
+	}
+	if *(&((v).Prot)) != *(&((o).Prot)) {
+		return false
+	}
+	if *(&((v).Port)) != *(&((o).Port)) {
+		return false
+	}
+	return true
+}
+func (v *Mapping) Clone() (c Mapping) {
+	*(&((&c).Prog)) = *(&((v).Prog))
+	*(&((&c).Vers)) = *(&((v).Vers))
+	*(&((&c).Prot)) = *(&((v).Prot))
+	*(&((&c).Port)) = *(&((v).Port))
+	return
+}
 func (v *Pmaplist) Xdr(xs *xdr.XdrState) {
 	if xs.Encoding() {
 		opted := *(&v.P) != nil
@@ -119,9 +645,80 @@ func (v *Pmaplist) Xdr(xs *xdr.XdrState) {
 		}
 	}
 }
+func (v *Pmaplist) XdrSize() (n int) {
+	n += 4
+	if *(&v.P) != nil {
+		n += (*Pmaplistelem)(*(&v.P)).XdrSize()
+	}
+	return
+}
+func (v *Pmaplist) Equal(o *Pmaplist) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if (*(&v.P) == nil) != (*(&o.P) == nil) {
+		return false
+	}
+	if *(&v.P) != nil {
+		if !(*Pmaplistelem)(*(&v.P)).Equal((*Pmaplistelem)(*(&o.P))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *Pmaplist) Clone() (c Pmaplist) {
+	if *(&v.P) != nil {
+		*(&c.P) = new(Pmaplistelem)
+		*(*Pmaplistelem)(*(&c.P)) = (*Pmaplistelem)(*(&v.P)).Clone()
+	}
+	return
+}
 func (v *Pmaplistelem) Xdr(xs *xdr.XdrState) {
-	(*Mapping)(&((v).Map)).Xdr(xs)
-	(*Pmaplist)(&((v).Next)).Xdr(xs)
+	for {
+		(*Mapping)(&((v).Map)).Xdr(xs)
+		var opted bool
+		if xs.Encoding() {
+			opted = v.Next.P != nil
+		}
+		xdr.XdrBool(xs, (*bool)(&opted))
+		if !opted || xs.Error() != nil {
+			return
+		}
+		if xs.Decoding() {
+			v.Next.P = new(Pmaplistelem)
+		}
+		v = v.Next.P
+	}
+}
+func (v *Pmaplistelem) XdrSize() (n int) {
+	for v != nil {
+		n += 16
+		n += 4
+		v = v.Next.P
+	}
+	return
+}
+func (v *Pmaplistelem) Equal(o *Pmaplistelem) bool {
+	for {
+		if v == nil || o == nil {
+			return v == o
+		}
+		if !(*Mapping)(&((v).Map)).Equal((*Mapping)(&((o).Map))) {
+			return false
+		}
+		v, o = v.Next.P, o.Next.P
+	}
+}
+func (v *Pmaplistelem) Clone() (c Pmaplistelem) {
+	d := &c
+	for {
+		*(*Mapping)(&((d).Map)) = (*Mapping)(&((v).Map)).Clone()
+		if v.Next.P == nil {
+			return
+		}
+		d.Next.P = new(Pmaplistelem)
+		d, v = d.Next.P, v.Next.P
+	}
 }
 func (v *Call_args) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(&((v).Prog)))
@@ -129,16 +726,106 @@ func (v *Call_args) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(&((v).Proc)))
 	xdr.XdrVarArray(xs, int(-1), (*[]byte)(&((v).Args)))
 }
+func (v *Call_args) XdrSize() (n int) {
+	n += 4
+	n += 4
+	n += 4
+	n += 4 + (len(*(&((v).Args)))+3)&^3
+	return
+}
+func (v *Call_args) Equal(o *Call_args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(&((v).Prog)) != *(&((o).Prog)) {
+		return false
+	}
+	if *(&((v).Vers)) != *(&((o).Vers)) {
+		return false
+	}
+	if *(&((v).Proc)) != *(&((o).Proc)) {
+		return false
+	}
+	if !bytes.Equal(*(&((v).Args)), *(&((o).Args))) {
+		return false
+	}
+	return true
+}
+func (v *Call_args) Clone() (c Call_args) {
+	*(&((&c).Prog)) = *(&((v).Prog))
+	*(&((&c).Vers)) = *(&((v).Vers))
+	*(&((&c).Proc)) = *(&((v).Proc))
+	if *(&((v).Args)) != nil {
+		*(&((&c).Args)) = append([]byte{}, *(&((v).Args))...)
+	}
+	return
+}
 func (v *Call_result) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(&((v).Port)))
 	xdr.XdrVarArray(xs, int(-1), (*[]byte)(&((v).Res)))
 }
+func (v *Call_result) XdrSize() (n int) {
+	n += 4
+	n += 4 + (len(*(&((v).Res)))+3)&^3
+	return
+}
+func (v *Call_result) Equal(o *Call_result) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(&((v).Port)) != *(&((o).Port)) {
+		return false
+	}
+	if !bytes.Equal(*(&((v).Res)), *(&((o).Res))) {
+		return false
+	}
+	return true
+}
+func (v *Call_result) Clone() (c Call_result) {
+	*(&((&c).Port)) = *(&((v).Port))
+	if *(&((v).Res)) != nil {
+		*(&((&c).Res)) = append([]byte{}, *(&((v).Res))...)
+	}
+	return
+}
 func (v *Uint32) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(v))
 }
+func (v *Uint32) XdrSize() int {
+	return Uint32_XdrSize
+}
+func (v *Uint32) Equal(o *Uint32) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Uint32) Clone() (c Uint32) {
+	*(&c) = *(v)
+	return
+}
 func (v *Xbool) Xdr(xs *xdr.XdrState) {
 	xdr.XdrBool(xs, (*bool)(v))
 }
+func (v *Xbool) XdrSize() int {
+	return Xbool_XdrSize
+}
+func (v *Xbool) Equal(o *Xbool) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Xbool) Clone() (c Xbool) {
+	*(&c) = *(v)
+	return
+}
 
 type PMAP_PROG_PMAP_VERS_handler interface {
 	PMAPPROC_NULL()
@@ -247,3 +934,63 @@ func PMAP_PROG_PMAP_VERS_regs(h PMAP_PROG_PMAP_VERS_handler) []xdr.ProcRegistrat
 		},
 	}
 }
+
+type PMAP_PROG_PMAP_VERS_Client struct {
+	c xdr.ProcCaller
+}
+
+func MakePMAP_PROG_PMAP_VERS_Client(c xdr.ProcCaller) *PMAP_PROG_PMAP_VERS_Client {
+	return &PMAP_PROG_PMAP_VERS_Client{c}
+}
+func (c *PMAP_PROG_PMAP_VERS_Client) PMAPPROC_NULL(ctx context.Context) error {
+	var res xdr.Void
+	return c.c.CallProc(ctx, PMAP_PROG, PMAP_VERS, PMAPPROC_NULL, &xdr.Void{}, &res)
+}
+func (c *PMAP_PROG_PMAP_VERS_Client) PMAPPROC_SET(ctx context.Context, arg Mapping) (res Xbool, err error) {
+	err = c.c.CallProc(ctx, PMAP_PROG, PMAP_VERS, PMAPPROC_SET, &arg, &res)
+	return
+}
+func (c *PMAP_PROG_PMAP_VERS_Client) PMAPPROC_UNSET(ctx context.Context, arg Mapping) (res Xbool, err error) {
+	err = c.c.CallProc(ctx, PMAP_PROG, PMAP_VERS, PMAPPROC_UNSET, &arg, &res)
+	return
+}
+func (c *PMAP_PROG_PMAP_VERS_Client) PMAPPROC_GETPORT(ctx context.Context, arg Mapping) (res Uint32, err error) {
+	err = c.c.CallProc(ctx, PMAP_PROG, PMAP_VERS, PMAPPROC_GETPORT, &arg, &res)
+	return
+}
+func (c *PMAP_PROG_PMAP_VERS_Client) PMAPPROC_DUMP(ctx context.Context) (res Pmaplist, err error) {
+	err = c.c.CallProc(ctx, PMAP_PROG, PMAP_VERS, PMAPPROC_DUMP, &xdr.Void{}, &res)
+	return
+}
+func (c *PMAP_PROG_PMAP_VERS_Client) PMAPPROC_CALLIT(ctx context.Context, arg Call_args) (res Call_result, err error) {
+	err = c.c.CallProc(ctx, PMAP_PROG, PMAP_VERS, PMAPPROC_CALLIT, &arg, &res)
+	return
+}
+func PMAP_PROG_ProcName(vers, proc uint32) string {
+	switch vers {
+	case PMAP_VERS:
+		switch proc {
+		case PMAPPROC_NULL:
+			return "PMAP_PROG/PMAP_VERS/PMAPPROC_NULL"
+		case PMAPPROC_SET:
+			return "PMAP_PROG/PMAP_VERS/PMAPPROC_SET"
+		case PMAPPROC_UNSET:
+			return "PMAP_PROG/PMAP_VERS/PMAPPROC_UNSET"
+		case PMAPPROC_GETPORT:
+			return "PMAP_PROG/PMAP_VERS/PMAPPROC_GETPORT"
+		case PMAPPROC_DUMP:
+			return "PMAP_PROG/PMAP_VERS/PMAPPROC_DUMP"
+		case PMAPPROC_CALLIT:
+			return "PMAP_PROG/PMAP_VERS/PMAPPROC_CALLIT"
+		}
+		return fmt.Sprintf("PMAP_PROG/PMAP_VERS/%d", proc)
+	}
+	return fmt.Sprintf("PMAP_PROG/%d/%d", vers, proc)
+}
+func ProcName(prog, vers, proc uint32) string {
+	switch prog {
+	case PMAP_PROG:
+		return PMAP_PROG_ProcName(vers, proc)
+	}
+	return fmt.Sprintf("%d/%d/%d", prog, vers, proc)
+}
@@ -8,20 +8,59 @@ const NFS3_CREATEVERFSIZE uint32 = 8
 const NFS3_WRITEVERFSIZE uint32 = 8
 
 type Uint64 uint64
+
+const Uint64_XdrSize = 8
+
 type Uint32 uint32
+
+const Uint32_XdrSize = 4
+
 type Filename3 string
 type Nfspath3 string
 type Fileid3 Uint64
+
+const Fileid3_XdrSize = 8
+
 type Cookie3 Uint64
+
+const Cookie3_XdrSize = 8
+
 type Cookieverf3 [NFS3_COOKIEVERFSIZE]byte
+
+const Cookieverf3_XdrSize = 8
+
 type Createverf3 [NFS3_CREATEVERFSIZE]byte
+
+const Createverf3_XdrSize = 8
+
 type Writeverf3 [NFS3_WRITEVERFSIZE]byte
+
+const Writeverf3_XdrSize = 8
+
 type Uid3 Uint32
+
+const Uid3_XdrSize = 4
+
 type Gid3 Uint32
+
+const Gid3_XdrSize = 4
+
 type Size3 Uint64
+
+const Size3_XdrSize = 8
+
 type Offset3 Uint64
+
+const Offset3_XdrSize = 8
+
 type Mode3 Uint32
+
+const Mode3_XdrSize = 4
+
 type Count3 Uint32
+
+const Count3_XdrSize = 4
+
 type Nfsstat3 uint32
 
 const NFS3_OK Nfsstat3 = 0
@@ -53,6 +92,7 @@ const NFS3ERR_TOOSMALL Nfsstat3 = 10005
 const NFS3ERR_SERVERFAULT Nfsstat3 = 10006
 const NFS3ERR_BADTYPE Nfsstat3 = 10007
 const NFS3ERR_JUKEBOX Nfsstat3 = 10008
+const Nfsstat3_XdrSize = 4
 
 type Ftype3 uint32
 
@@ -63,11 +103,15 @@ const NF3CHR Ftype3 = 4
 const NF3LNK Ftype3 = 5
 const NF3SOCK Ftype3 = 6
 const NF3FIFO Ftype3 = 7
+const Ftype3_XdrSize = 4
 
 type Specdata3 struct {
 	Specdata1 Uint32
 	Specdata2 Uint32
 }
+
+const Specdata3_XdrSize = 8
+
 type Nfs_fh3 struct {
 	Data []byte
 }
@@ -75,6 +119,9 @@ type Nfstime3 struct {
 	Seconds  Uint32
 	Nseconds Uint32
 }
+
+const Nfstime3_XdrSize = 8
+
 type Fattr3 struct {
 	Ftype  Ftype3
 	Mode   Mode3
@@ -90,6 +137,9 @@ type Fattr3 struct {
 	Mtime  Nfstime3
 	Ctime  Nfstime3
 }
+
+const Fattr3_XdrSize = 84
+
 type Post_op_attr struct {
 	Attributes_follow bool
 	Attributes        Fattr3
@@ -99,6 +149,9 @@ type Wcc_attr struct {
 	Mtime Nfstime3
 	Ctime Nfstime3
 }
+
+const Wcc_attr_XdrSize = 24
+
 type Pre_op_attr struct {
 	Attributes_follow bool
 	Attributes        Wcc_attr
@@ -116,6 +169,7 @@ type Time_how uint32
 const DONT_CHANGE Time_how = 0
 const SET_TO_SERVER_TIME Time_how = 1
 const SET_TO_CLIENT_TIME Time_how = 2
+const Time_how_XdrSize = 4
 
 type Set_mode3 struct {
 	Set_it bool
@@ -185,6 +239,9 @@ type GETATTR3args struct {
 type GETATTR3resok struct {
 	Obj_attributes Fattr3
 }
+
+const GETATTR3resok_XdrSize = 84
+
 type GETATTR3res struct {
 	Status Nfsstat3
 	Resok  GETATTR3resok
@@ -288,6 +345,7 @@ type Stable_how uint32
 const UNSTABLE Stable_how = 0
 const DATA_SYNC Stable_how = 1
 const FILE_SYNC Stable_how = 2
+const Stable_how_XdrSize = 4
 
 type WRITE3args struct {
 	File   Nfs_fh3
@@ -315,6 +373,7 @@ type Createmode3 uint32
 const UNCHECKED Createmode3 = 0
 const GUARDED Createmode3 = 1
 const EXCLUSIVE Createmode3 = 2
+const Createmode3_XdrSize = 4
 
 type Createhow3 struct {
 	Mode           Createmode3
@@ -614,8 +673,13 @@ type COMMIT3res struct {
 	Resfail COMMIT3resfail
 }
 
+// Maximum bytes in a path name
 const MNTPATHLEN3 uint32 = 1024
+
+// Maximum bytes in a name
 const MNTNAMLEN3 uint32 = 255
+
+// Maximum bytes in a V3 file handle
 const FHSIZE3 uint32 = 64
 
 type Fhandle3 []byte
@@ -623,16 +687,36 @@ type Dirpath3 string
 type Name3 string
 type Mountstat3 uint32
 
+// no error
 const MNT3_OK Mountstat3 = 0
+
+// Not owner
 const MNT3ERR_PERM Mountstat3 = 1
+
+// No such file or directory
 const MNT3ERR_NOENT Mountstat3 = 2
+
+// I/O error
 const MNT3ERR_IO Mountstat3 = 5
+
+// Permission denied
 const MNT3ERR_ACCES Mountstat3 = 13
+
+// Not a directory
 const MNT3ERR_NOTDIR Mountstat3 = 20
+
+// Invalid argument
 const MNT3ERR_INVAL Mountstat3 = 22
+
+// Filename too long
 const MNT3ERR_NAMETOOLONG Mountstat3 = 63
+
+// Operation not supported
 const MNT3ERR_NOTSUPP Mountstat3 = 10004
+
+// A failure on the server
 const MNT3ERR_SERVERFAULT Mountstat3 = 10006
+const Mountstat3_XdrSize = 4
 const MOUNT_PROGRAM uint32 = 100005
 const MOUNT_V3 uint32 = 3
 const MOUNTPROC3_NULL uint32 = 0
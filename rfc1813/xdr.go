@@ -1,69 +1,525 @@
 package rfc1813
 
+import "bytes"
+import "context"
+import "fmt"
 import "github.com/zeldovich/go-rpcgen/xdr"
 
 func (v *Uint64) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU64(xs, (*uint64)(v))
 }
+func (v *Uint64) XdrSize() int {
+	return Uint64_XdrSize
+}
+func (v *Uint64) Equal(o *Uint64) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Uint64) Clone() (c Uint64) {
+	*(&c) = *(v)
+	return
+}
 func (v *Uint32) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(v))
 }
+func (v *Uint32) XdrSize() int {
+	return Uint32_XdrSize
+}
+func (v *Uint32) Equal(o *Uint32) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Uint32) Clone() (c Uint32) {
+	*(&c) = *(v)
+	return
+}
 func (v *Filename3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrString(xs, int(-1), (*string)(v))
 }
+func (v *Filename3) XdrSize() (n int) {
+	n += 4 + (len(*(v))+3)&^3
+	return
+}
+func (v *Filename3) Equal(o *Filename3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Filename3) Clone() (c Filename3) {
+	*(&c) = *(v)
+	return
+}
 func (v *Nfspath3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrString(xs, int(-1), (*string)(v))
 }
+func (v *Nfspath3) XdrSize() (n int) {
+	n += 4 + (len(*(v))+3)&^3
+	return
+}
+func (v *Nfspath3) Equal(o *Nfspath3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Nfspath3) Clone() (c Nfspath3) {
+	*(&c) = *(v)
+	return
+}
 func (v *Fileid3) Xdr(xs *xdr.XdrState) {
 	(*Uint64)(v).Xdr(xs)
 }
+func (v *Fileid3) XdrSize() int {
+	return Fileid3_XdrSize
+}
+func (v *Fileid3) Equal(o *Fileid3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Uint64)(v).Equal((*Uint64)(o)) {
+		return false
+	}
+	return true
+}
+func (v *Fileid3) Clone() (c Fileid3) {
+	*(*Uint64)(&c) = (*Uint64)(v).Clone()
+	return
+}
 func (v *Cookie3) Xdr(xs *xdr.XdrState) {
 	(*Uint64)(v).Xdr(xs)
 }
+func (v *Cookie3) XdrSize() int {
+	return Cookie3_XdrSize
+}
+func (v *Cookie3) Equal(o *Cookie3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Uint64)(v).Equal((*Uint64)(o)) {
+		return false
+	}
+	return true
+}
+func (v *Cookie3) Clone() (c Cookie3) {
+	*(*Uint64)(&c) = (*Uint64)(v).Clone()
+	return
+}
 func (v *Cookieverf3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrArray(xs, (*v)[:])
 }
+func (v *Cookieverf3) XdrSize() int {
+	return Cookieverf3_XdrSize
+}
+func (v *Cookieverf3) Equal(o *Cookieverf3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Cookieverf3) Clone() (c Cookieverf3) {
+	*(&c) = *(v)
+	return
+}
 func (v *Createverf3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrArray(xs, (*v)[:])
 }
+func (v *Createverf3) XdrSize() int {
+	return Createverf3_XdrSize
+}
+func (v *Createverf3) Equal(o *Createverf3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Createverf3) Clone() (c Createverf3) {
+	*(&c) = *(v)
+	return
+}
 func (v *Writeverf3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrArray(xs, (*v)[:])
 }
+func (v *Writeverf3) XdrSize() int {
+	return Writeverf3_XdrSize
+}
+func (v *Writeverf3) Equal(o *Writeverf3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Writeverf3) Clone() (c Writeverf3) {
+	*(&c) = *(v)
+	return
+}
 func (v *Uid3) Xdr(xs *xdr.XdrState) {
 	(*Uint32)(v).Xdr(xs)
 }
+func (v *Uid3) XdrSize() int {
+	return Uid3_XdrSize
+}
+func (v *Uid3) Equal(o *Uid3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Uint32)(v).Equal((*Uint32)(o)) {
+		return false
+	}
+	return true
+}
+func (v *Uid3) Clone() (c Uid3) {
+	*(*Uint32)(&c) = (*Uint32)(v).Clone()
+	return
+}
 func (v *Gid3) Xdr(xs *xdr.XdrState) {
 	(*Uint32)(v).Xdr(xs)
 }
+func (v *Gid3) XdrSize() int {
+	return Gid3_XdrSize
+}
+func (v *Gid3) Equal(o *Gid3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Uint32)(v).Equal((*Uint32)(o)) {
+		return false
+	}
+	return true
+}
+func (v *Gid3) Clone() (c Gid3) {
+	*(*Uint32)(&c) = (*Uint32)(v).Clone()
+	return
+}
 func (v *Size3) Xdr(xs *xdr.XdrState) {
 	(*Uint64)(v).Xdr(xs)
 }
+func (v *Size3) XdrSize() int {
+	return Size3_XdrSize
+}
+func (v *Size3) Equal(o *Size3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Uint64)(v).Equal((*Uint64)(o)) {
+		return false
+	}
+	return true
+}
+func (v *Size3) Clone() (c Size3) {
+	*(*Uint64)(&c) = (*Uint64)(v).Clone()
+	return
+}
 func (v *Offset3) Xdr(xs *xdr.XdrState) {
 	(*Uint64)(v).Xdr(xs)
 }
+func (v *Offset3) XdrSize() int {
+	return Offset3_XdrSize
+}
+func (v *Offset3) Equal(o *Offset3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Uint64)(v).Equal((*Uint64)(o)) {
+		return false
+	}
+	return true
+}
+func (v *Offset3) Clone() (c Offset3) {
+	*(*Uint64)(&c) = (*Uint64)(v).Clone()
+	return
+}
 func (v *Mode3) Xdr(xs *xdr.XdrState) {
 	(*Uint32)(v).Xdr(xs)
 }
+func (v *Mode3) XdrSize() int {
+	return Mode3_XdrSize
+}
+func (v *Mode3) Equal(o *Mode3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Uint32)(v).Equal((*Uint32)(o)) {
+		return false
+	}
+	return true
+}
+func (v *Mode3) Clone() (c Mode3) {
+	*(*Uint32)(&c) = (*Uint32)(v).Clone()
+	return
+}
 func (v *Count3) Xdr(xs *xdr.XdrState) {
 	(*Uint32)(v).Xdr(xs)
 }
+func (v *Count3) XdrSize() int {
+	return Count3_XdrSize
+}
+func (v *Count3) Equal(o *Count3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Uint32)(v).Equal((*Uint32)(o)) {
+		return false
+	}
+	return true
+}
+func (v *Count3) Clone() (c Count3) {
+	*(*Uint32)(&c) = (*Uint32)(v).Clone()
+	return
+}
+func (v Nfsstat3) Valid() bool {
+	return v == NFS3_OK || v == NFS3ERR_PERM || v == NFS3ERR_NOENT || v == NFS3ERR_IO || v == NFS3ERR_NXIO || v == NFS3ERR_ACCES || v == NFS3ERR_EXIST || v == NFS3ERR_XDEV || v == NFS3ERR_NODEV || v == NFS3ERR_NOTDIR || v == NFS3ERR_ISDIR || v == NFS3ERR_INVAL || v == NFS3ERR_FBIG || v == NFS3ERR_NOSPC || v == NFS3ERR_ROFS || v == NFS3ERR_MLINK || v == NFS3ERR_NAMETOOLONG || v == NFS3ERR_NOTEMPTY || v == NFS3ERR_DQUOT || v == NFS3ERR_STALE || v == NFS3ERR_REMOTE || v == NFS3ERR_BADHANDLE || v == NFS3ERR_NOT_SYNC || v == NFS3ERR_BAD_COOKIE || v == NFS3ERR_NOTSUPP || v == NFS3ERR_TOOSMALL || v == NFS3ERR_SERVERFAULT || v == NFS3ERR_BADTYPE || v == NFS3ERR_JUKEBOX
+}
 func (v *Nfsstat3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(v))
 }
+func (v Nfsstat3) String() string {
+	if v == NFS3_OK {
+		return "NFS3_OK"
+	}
+	if v == NFS3ERR_PERM {
+		return "NFS3ERR_PERM"
+	}
+	if v == NFS3ERR_NOENT {
+		return "NFS3ERR_NOENT"
+	}
+	if v == NFS3ERR_IO {
+		return "NFS3ERR_IO"
+	}
+	if v == NFS3ERR_NXIO {
+		return "NFS3ERR_NXIO"
+	}
+	if v == NFS3ERR_ACCES {
+		return "NFS3ERR_ACCES"
+	}
+	if v == NFS3ERR_EXIST {
+		return "NFS3ERR_EXIST"
+	}
+	if v == NFS3ERR_XDEV {
+		return "NFS3ERR_XDEV"
+	}
+	if v == NFS3ERR_NODEV {
+		return "NFS3ERR_NODEV"
+	}
+	if v == NFS3ERR_NOTDIR {
+		return "NFS3ERR_NOTDIR"
+	}
+	if v == NFS3ERR_ISDIR {
+		return "NFS3ERR_ISDIR"
+	}
+	if v == NFS3ERR_INVAL {
+		return "NFS3ERR_INVAL"
+	}
+	if v == NFS3ERR_FBIG {
+		return "NFS3ERR_FBIG"
+	}
+	if v == NFS3ERR_NOSPC {
+		return "NFS3ERR_NOSPC"
+	}
+	if v == NFS3ERR_ROFS {
+		return "NFS3ERR_ROFS"
+	}
+	if v == NFS3ERR_MLINK {
+		return "NFS3ERR_MLINK"
+	}
+	if v == NFS3ERR_NAMETOOLONG {
+		return "NFS3ERR_NAMETOOLONG"
+	}
+	if v == NFS3ERR_NOTEMPTY {
+		return "NFS3ERR_NOTEMPTY"
+	}
+	if v == NFS3ERR_DQUOT {
+		return "NFS3ERR_DQUOT"
+	}
+	if v == NFS3ERR_STALE {
+		return "NFS3ERR_STALE"
+	}
+	if v == NFS3ERR_REMOTE {
+		return "NFS3ERR_REMOTE"
+	}
+	if v == NFS3ERR_BADHANDLE {
+		return "NFS3ERR_BADHANDLE"
+	}
+	if v == NFS3ERR_NOT_SYNC {
+		return "NFS3ERR_NOT_SYNC"
+	}
+	if v == NFS3ERR_BAD_COOKIE {
+		return "NFS3ERR_BAD_COOKIE"
+	}
+	if v == NFS3ERR_NOTSUPP {
+		return "NFS3ERR_NOTSUPP"
+	}
+	if v == NFS3ERR_TOOSMALL {
+		return "NFS3ERR_TOOSMALL"
+	}
+	if v == NFS3ERR_SERVERFAULT {
+		return "NFS3ERR_SERVERFAULT"
+	}
+	if v == NFS3ERR_BADTYPE {
+		return "NFS3ERR_BADTYPE"
+	}
+	if v == NFS3ERR_JUKEBOX {
+		return "NFS3ERR_JUKEBOX"
+	}
+	return fmt.Sprintf("Nfsstat3(%d)", v)
+}
+func (v *Nfsstat3) XdrSize() int {
+	return Nfsstat3_XdrSize
+}
+func (v *Nfsstat3) Equal(o *Nfsstat3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Nfsstat3) Clone() (c Nfsstat3) {
+	*(&c) = *(v)
+	return
+}
+func (v Ftype3) Valid() bool {
+	return v == NF3REG || v == NF3DIR || v == NF3BLK || v == NF3CHR || v == NF3LNK || v == NF3SOCK || v == NF3FIFO
+}
 func (v *Ftype3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(v))
 }
+func (v Ftype3) String() string {
+	if v == NF3REG {
+		return "NF3REG"
+	}
+	if v == NF3DIR {
+		return "NF3DIR"
+	}
+	if v == NF3BLK {
+		return "NF3BLK"
+	}
+	if v == NF3CHR {
+		return "NF3CHR"
+	}
+	if v == NF3LNK {
+		return "NF3LNK"
+	}
+	if v == NF3SOCK {
+		return "NF3SOCK"
+	}
+	if v == NF3FIFO {
+		return "NF3FIFO"
+	}
+	return fmt.Sprintf("Ftype3(%d)", v)
+}
+func (v *Ftype3) XdrSize() int {
+	return Ftype3_XdrSize
+}
+func (v *Ftype3) Equal(o *Ftype3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Ftype3) Clone() (c Ftype3) {
+	*(&c) = *(v)
+	return
+}
 func (v *Specdata3) Xdr(xs *xdr.XdrState) {
 	(*Uint32)(&((v).Specdata1)).Xdr(xs)
 	(*Uint32)(&((v).Specdata2)).Xdr(xs)
 }
+func (v *Specdata3) XdrSize() int {
+	return Specdata3_XdrSize
+}
+func (v *Specdata3) Equal(o *Specdata3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Uint32)(&((v).Specdata1)).Equal((*Uint32)(&((o).Specdata1))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Specdata2)).Equal((*Uint32)(&((o).Specdata2))) {
+		return false
+	}
+	return true
+}
+func (v *Specdata3) Clone() (c Specdata3) {
+	*(*Uint32)(&((&c).Specdata1)) = (*Uint32)(&((v).Specdata1)).Clone()
+	*(*Uint32)(&((&c).Specdata2)) = (*Uint32)(&((v).Specdata2)).Clone()
+	return
+}
 func (v *Nfs_fh3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrVarArray(xs, int(NFS3_FHSIZE), (*[]byte)(&((v).Data)))
 }
+func (v *Nfs_fh3) XdrSize() (n int) {
+	n += 4 + (len(*(&((v).Data)))+3)&^3
+	return
+}
+func (v *Nfs_fh3) Equal(o *Nfs_fh3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !bytes.Equal(*(&((v).Data)), *(&((o).Data))) {
+		return false
+	}
+	return true
+}
+func (v *Nfs_fh3) Clone() (c Nfs_fh3) {
+	if *(&((v).Data)) != nil {
+		*(&((&c).Data)) = append([]byte{}, *(&((v).Data))...)
+	}
+	return
+}
 func (v *Nfstime3) Xdr(xs *xdr.XdrState) {
 	(*Uint32)(&((v).Seconds)).Xdr(xs)
 	(*Uint32)(&((v).Nseconds)).Xdr(xs)
 }
+func (v *Nfstime3) XdrSize() int {
+	return Nfstime3_XdrSize
+}
+func (v *Nfstime3) Equal(o *Nfstime3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Uint32)(&((v).Seconds)).Equal((*Uint32)(&((o).Seconds))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Nseconds)).Equal((*Uint32)(&((o).Nseconds))) {
+		return false
+	}
+	return true
+}
+func (v *Nfstime3) Clone() (c Nfstime3) {
+	*(*Uint32)(&((&c).Seconds)) = (*Uint32)(&((v).Seconds)).Clone()
+	*(*Uint32)(&((&c).Nseconds)) = (*Uint32)(&((v).Nseconds)).Clone()
+	return
+}
 func (v *Fattr3) Xdr(xs *xdr.XdrState) {
 	(*Ftype3)(&((v).Ftype)).Xdr(xs)
 	(*Mode3)(&((v).Mode)).Xdr(xs)
@@ -79,42 +535,297 @@ func (v *Fattr3) Xdr(xs *xdr.XdrState) {
 	(*Nfstime3)(&((v).Mtime)).Xdr(xs)
 	(*Nfstime3)(&((v).Ctime)).Xdr(xs)
 }
+func (v *Fattr3) XdrSize() int {
+	return Fattr3_XdrSize
+}
+func (v *Fattr3) Equal(o *Fattr3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Ftype3)(&((v).Ftype)).Equal((*Ftype3)(&((o).Ftype))) {
+		return false
+	}
+	if !(*Mode3)(&((v).Mode)).Equal((*Mode3)(&((o).Mode))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Nlink)).Equal((*Uint32)(&((o).Nlink))) {
+		return false
+	}
+	if !(*Uid3)(&((v).Uid)).Equal((*Uid3)(&((o).Uid))) {
+		return false
+	}
+	if !(*Gid3)(&((v).Gid)).Equal((*Gid3)(&((o).Gid))) {
+		return false
+	}
+	if !(*Size3)(&((v).Size)).Equal((*Size3)(&((o).Size))) {
+		return false
+	}
+	if !(*Size3)(&((v).Used)).Equal((*Size3)(&((o).Used))) {
+		return false
+	}
+	if !(*Specdata3)(&((v).Rdev)).Equal((*Specdata3)(&((o).Rdev))) {
+		return false
+	}
+	if !(*Uint64)(&((v).Fsid)).Equal((*Uint64)(&((o).Fsid))) {
+		return false
+	}
+	if !(*Fileid3)(&((v).Fileid)).Equal((*Fileid3)(&((o).Fileid))) {
+		return false
+	}
+	if !(*Nfstime3)(&((v).Atime)).Equal((*Nfstime3)(&((o).Atime))) {
+		return false
+	}
+	if !(*Nfstime3)(&((v).Mtime)).Equal((*Nfstime3)(&((o).Mtime))) {
+		return false
+	}
+	if !(*Nfstime3)(&((v).Ctime)).Equal((*Nfstime3)(&((o).Ctime))) {
+		return false
+	}
+	return true
+}
+func (v *Fattr3) Clone() (c Fattr3) {
+	*(*Ftype3)(&((&c).Ftype)) = (*Ftype3)(&((v).Ftype)).Clone()
+	*(*Mode3)(&((&c).Mode)) = (*Mode3)(&((v).Mode)).Clone()
+	*(*Uint32)(&((&c).Nlink)) = (*Uint32)(&((v).Nlink)).Clone()
+	*(*Uid3)(&((&c).Uid)) = (*Uid3)(&((v).Uid)).Clone()
+	*(*Gid3)(&((&c).Gid)) = (*Gid3)(&((v).Gid)).Clone()
+	*(*Size3)(&((&c).Size)) = (*Size3)(&((v).Size)).Clone()
+	*(*Size3)(&((&c).Used)) = (*Size3)(&((v).Used)).Clone()
+	*(*Specdata3)(&((&c).Rdev)) = (*Specdata3)(&((v).Rdev)).Clone()
+	*(*Uint64)(&((&c).Fsid)) = (*Uint64)(&((v).Fsid)).Clone()
+	*(*Fileid3)(&((&c).Fileid)) = (*Fileid3)(&((v).Fileid)).Clone()
+	*(*Nfstime3)(&((&c).Atime)) = (*Nfstime3)(&((v).Atime)).Clone()
+	*(*Nfstime3)(&((&c).Mtime)) = (*Nfstime3)(&((v).Mtime)).Clone()
+	*(*Nfstime3)(&((&c).Ctime)) = (*Nfstime3)(&((v).Ctime)).Clone()
+	return
+}
 func (v *Post_op_attr) Xdr(xs *xdr.XdrState) {
 	xdr.XdrBool(xs, (*bool)(&((v).Attributes_follow)))
 	switch (v).Attributes_follow {
 	case true:
 		(*Fattr3)(&((v).Attributes)).Xdr(xs)
 	case false:
+	default:
+		if xs.Error() == nil {
+			xs.SetErrorf("invalid Post_op_attr discriminant %v", (v).Attributes_follow)
+		}
+	}
+}
+func (v *Post_op_attr) XdrSize() (n int) {
+	n += 4
+	switch (v).Attributes_follow {
+	case true:
+		n += 84
+	case false:
+	}
+	return
+}
+func (v *Post_op_attr) Equal(o *Post_op_attr) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(&((v).Attributes_follow)) != *(&((o).Attributes_follow)) {
+		return false
+	}
+	switch (v).Attributes_follow {
+	case true:
+		if !(*Fattr3)(&((v).Attributes)).Equal((*Fattr3)(&((o).Attributes))) {
+			return false
+		}
+	case false:
+	}
+	return true
+}
+func (v *Post_op_attr) Clone() (c Post_op_attr) {
+	*(&((&c).Attributes_follow)) = *(&((v).Attributes_follow))
+	switch (v).Attributes_follow {
+	case true:
+		*(*Fattr3)(&((&c).Attributes)) = (*Fattr3)(&((v).Attributes)).Clone()
+	case false:
 	}
+	return
 }
 func (v *Wcc_attr) Xdr(xs *xdr.XdrState) {
 	(*Size3)(&((v).Size)).Xdr(xs)
 	(*Nfstime3)(&((v).Mtime)).Xdr(xs)
 	(*Nfstime3)(&((v).Ctime)).Xdr(xs)
 }
+func (v *Wcc_attr) XdrSize() int {
+	return Wcc_attr_XdrSize
+}
+func (v *Wcc_attr) Equal(o *Wcc_attr) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Size3)(&((v).Size)).Equal((*Size3)(&((o).Size))) {
+		return false
+	}
+	if !(*Nfstime3)(&((v).Mtime)).Equal((*Nfstime3)(&((o).Mtime))) {
+		return false
+	}
+	if !(*Nfstime3)(&((v).Ctime)).Equal((*Nfstime3)(&((o).Ctime))) {
+		return false
+	}
+	return true
+}
+func (v *Wcc_attr) Clone() (c Wcc_attr) {
+	*(*Size3)(&((&c).Size)) = (*Size3)(&((v).Size)).Clone()
+	*(*Nfstime3)(&((&c).Mtime)) = (*Nfstime3)(&((v).Mtime)).Clone()
+	*(*Nfstime3)(&((&c).Ctime)) = (*Nfstime3)(&((v).Ctime)).Clone()
+	return
+}
 func (v *Pre_op_attr) Xdr(xs *xdr.XdrState) {
 	xdr.XdrBool(xs, (*bool)(&((v).Attributes_follow)))
 	switch (v).Attributes_follow {
 	case true:
 		(*Wcc_attr)(&((v).Attributes)).Xdr(xs)
 	case false:
+	default:
+		if xs.Error() == nil {
+			xs.SetErrorf("invalid Pre_op_attr discriminant %v", (v).Attributes_follow)
+		}
+	}
+}
+func (v *Pre_op_attr) XdrSize() (n int) {
+	n += 4
+	switch (v).Attributes_follow {
+	case true:
+		n += 24
+	case false:
+	}
+	return
+}
+func (v *Pre_op_attr) Equal(o *Pre_op_attr) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(&((v).Attributes_follow)) != *(&((o).Attributes_follow)) {
+		return false
+	}
+	switch (v).Attributes_follow {
+	case true:
+		if !(*Wcc_attr)(&((v).Attributes)).Equal((*Wcc_attr)(&((o).Attributes))) {
+			return false
+		}
+	case false:
+	}
+	return true
+}
+func (v *Pre_op_attr) Clone() (c Pre_op_attr) {
+	*(&((&c).Attributes_follow)) = *(&((v).Attributes_follow))
+	switch (v).Attributes_follow {
+	case true:
+		*(*Wcc_attr)(&((&c).Attributes)) = (*Wcc_attr)(&((v).Attributes)).Clone()
+	case false:
 	}
+	return
 }
 func (v *Wcc_data) Xdr(xs *xdr.XdrState) {
 	(*Pre_op_attr)(&((v).Before)).Xdr(xs)
 	(*Post_op_attr)(&((v).After)).Xdr(xs)
 }
+func (v *Wcc_data) XdrSize() (n int) {
+	n += (*Pre_op_attr)(&((v).Before)).XdrSize()
+	n += (*Post_op_attr)(&((v).After)).XdrSize()
+	return
+}
+func (v *Wcc_data) Equal(o *Wcc_data) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Pre_op_attr)(&((v).Before)).Equal((*Pre_op_attr)(&((o).Before))) {
+		return false
+	}
+	if !(*Post_op_attr)(&((v).After)).Equal((*Post_op_attr)(&((o).After))) {
+		return false
+	}
+	return true
+}
+func (v *Wcc_data) Clone() (c Wcc_data) {
+	*(*Pre_op_attr)(&((&c).Before)) = (*Pre_op_attr)(&((v).Before)).Clone()
+	*(*Post_op_attr)(&((&c).After)) = (*Post_op_attr)(&((v).After)).Clone()
+	return
+}
 func (v *Post_op_fh3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrBool(xs, (*bool)(&((v).Handle_follows)))
 	switch (v).Handle_follows {
 	case true:
 		(*Nfs_fh3)(&((v).Handle)).Xdr(xs)
 	case false:
+	default:
+		if xs.Error() == nil {
+			xs.SetErrorf("invalid Post_op_fh3 discriminant %v", (v).Handle_follows)
+		}
+	}
+}
+func (v *Post_op_fh3) XdrSize() (n int) {
+	n += 4
+	switch (v).Handle_follows {
+	case true:
+		n += (*Nfs_fh3)(&((v).Handle)).XdrSize()
+	case false:
+	}
+	return
+}
+func (v *Post_op_fh3) Equal(o *Post_op_fh3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(&((v).Handle_follows)) != *(&((o).Handle_follows)) {
+		return false
+	}
+	switch (v).Handle_follows {
+	case true:
+		if !(*Nfs_fh3)(&((v).Handle)).Equal((*Nfs_fh3)(&((o).Handle))) {
+			return false
+		}
+	case false:
+	}
+	return true
+}
+func (v *Post_op_fh3) Clone() (c Post_op_fh3) {
+	*(&((&c).Handle_follows)) = *(&((v).Handle_follows))
+	switch (v).Handle_follows {
+	case true:
+		*(*Nfs_fh3)(&((&c).Handle)) = (*Nfs_fh3)(&((v).Handle)).Clone()
+	case false:
 	}
+	return
+}
+func (v Time_how) Valid() bool {
+	return v == DONT_CHANGE || v == SET_TO_SERVER_TIME || v == SET_TO_CLIENT_TIME
 }
 func (v *Time_how) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(v))
 }
+func (v Time_how) String() string {
+	if v == DONT_CHANGE {
+		return "DONT_CHANGE"
+	}
+	if v == SET_TO_SERVER_TIME {
+		return "SET_TO_SERVER_TIME"
+	}
+	if v == SET_TO_CLIENT_TIME {
+		return "SET_TO_CLIENT_TIME"
+	}
+	return fmt.Sprintf("Time_how(%d)", v)
+}
+func (v *Time_how) XdrSize() int {
+	return Time_how_XdrSize
+}
+func (v *Time_how) Equal(o *Time_how) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Time_how) Clone() (c Time_how) {
+	*(&c) = *(v)
+	return
+}
 func (v *Set_mode3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrBool(xs, (*bool)(&((v).Set_it)))
 	switch (v).Set_it {
@@ -123,30 +834,154 @@ func (v *Set_mode3) Xdr(xs *xdr.XdrState) {
 	default:
 	}
 }
-func (v *Set_uid3) Xdr(xs *xdr.XdrState) {
-	xdr.XdrBool(xs, (*bool)(&((v).Set_it)))
+func (v *Set_mode3) XdrSize() (n int) {
+	n += 4
 	switch (v).Set_it {
 	case true:
-		(*Uid3)(&((v).Uid)).Xdr(xs)
-	default:
+		n += 4
 	}
+	return
 }
-func (v *Set_gid3) Xdr(xs *xdr.XdrState) {
-	xdr.XdrBool(xs, (*bool)(&((v).Set_it)))
+func (v *Set_mode3) Equal(o *Set_mode3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(&((v).Set_it)) != *(&((o).Set_it)) {
+		return false
+	}
 	switch (v).Set_it {
 	case true:
-		(*Gid3)(&((v).Gid)).Xdr(xs)
-	default:
+		if !(*Mode3)(&((v).Mode)).Equal((*Mode3)(&((o).Mode))) {
+			return false
+		}
 	}
+	return true
 }
-func (v *Set_size3) Xdr(xs *xdr.XdrState) {
-	xdr.XdrBool(xs, (*bool)(&((v).Set_it)))
+func (v *Set_mode3) Clone() (c Set_mode3) {
+	*(&((&c).Set_it)) = *(&((v).Set_it))
 	switch (v).Set_it {
 	case true:
-		(*Size3)(&((v).Size)).Xdr(xs)
+		*(*Mode3)(&((&c).Mode)) = (*Mode3)(&((v).Mode)).Clone()
+	}
+	return
+}
+func (v *Set_uid3) Xdr(xs *xdr.XdrState) {
+	xdr.XdrBool(xs, (*bool)(&((v).Set_it)))
+	switch (v).Set_it {
+	case true:
+		(*Uid3)(&((v).Uid)).Xdr(xs)
+	default:
+	}
+}
+func (v *Set_uid3) XdrSize() (n int) {
+	n += 4
+	switch (v).Set_it {
+	case true:
+		n += 4
+	}
+	return
+}
+func (v *Set_uid3) Equal(o *Set_uid3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(&((v).Set_it)) != *(&((o).Set_it)) {
+		return false
+	}
+	switch (v).Set_it {
+	case true:
+		if !(*Uid3)(&((v).Uid)).Equal((*Uid3)(&((o).Uid))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *Set_uid3) Clone() (c Set_uid3) {
+	*(&((&c).Set_it)) = *(&((v).Set_it))
+	switch (v).Set_it {
+	case true:
+		*(*Uid3)(&((&c).Uid)) = (*Uid3)(&((v).Uid)).Clone()
+	}
+	return
+}
+func (v *Set_gid3) Xdr(xs *xdr.XdrState) {
+	xdr.XdrBool(xs, (*bool)(&((v).Set_it)))
+	switch (v).Set_it {
+	case true:
+		(*Gid3)(&((v).Gid)).Xdr(xs)
 	default:
 	}
 }
+func (v *Set_gid3) XdrSize() (n int) {
+	n += 4
+	switch (v).Set_it {
+	case true:
+		n += 4
+	}
+	return
+}
+func (v *Set_gid3) Equal(o *Set_gid3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(&((v).Set_it)) != *(&((o).Set_it)) {
+		return false
+	}
+	switch (v).Set_it {
+	case true:
+		if !(*Gid3)(&((v).Gid)).Equal((*Gid3)(&((o).Gid))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *Set_gid3) Clone() (c Set_gid3) {
+	*(&((&c).Set_it)) = *(&((v).Set_it))
+	switch (v).Set_it {
+	case true:
+		*(*Gid3)(&((&c).Gid)) = (*Gid3)(&((v).Gid)).Clone()
+	}
+	return
+}
+func (v *Set_size3) Xdr(xs *xdr.XdrState) {
+	xdr.XdrBool(xs, (*bool)(&((v).Set_it)))
+	switch (v).Set_it {
+	case true:
+		(*Size3)(&((v).Size)).Xdr(xs)
+	default:
+	}
+}
+func (v *Set_size3) XdrSize() (n int) {
+	n += 4
+	switch (v).Set_it {
+	case true:
+		n += 8
+	}
+	return
+}
+func (v *Set_size3) Equal(o *Set_size3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(&((v).Set_it)) != *(&((o).Set_it)) {
+		return false
+	}
+	switch (v).Set_it {
+	case true:
+		if !(*Size3)(&((v).Size)).Equal((*Size3)(&((o).Size))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *Set_size3) Clone() (c Set_size3) {
+	*(&((&c).Set_it)) = *(&((v).Set_it))
+	switch (v).Set_it {
+	case true:
+		*(*Size3)(&((&c).Size)) = (*Size3)(&((v).Size)).Clone()
+	}
+	return
+}
 func (v *Set_atime) Xdr(xs *xdr.XdrState) {
 	(*Time_how)(&((v).Set_it)).Xdr(xs)
 	switch (v).Set_it {
@@ -155,6 +990,37 @@ func (v *Set_atime) Xdr(xs *xdr.XdrState) {
 	default:
 	}
 }
+func (v *Set_atime) XdrSize() (n int) {
+	n += 4
+	switch (v).Set_it {
+	case SET_TO_CLIENT_TIME:
+		n += 8
+	}
+	return
+}
+func (v *Set_atime) Equal(o *Set_atime) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Time_how)(&((v).Set_it)).Equal((*Time_how)(&((o).Set_it))) {
+		return false
+	}
+	switch (v).Set_it {
+	case SET_TO_CLIENT_TIME:
+		if !(*Nfstime3)(&((v).Atime)).Equal((*Nfstime3)(&((o).Atime))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *Set_atime) Clone() (c Set_atime) {
+	*(*Time_how)(&((&c).Set_it)) = (*Time_how)(&((v).Set_it)).Clone()
+	switch (v).Set_it {
+	case SET_TO_CLIENT_TIME:
+		*(*Nfstime3)(&((&c).Atime)) = (*Nfstime3)(&((v).Atime)).Clone()
+	}
+	return
+}
 func (v *Set_mtime) Xdr(xs *xdr.XdrState) {
 	(*Time_how)(&((v).Set_it)).Xdr(xs)
 	switch (v).Set_it {
@@ -163,6 +1029,37 @@ func (v *Set_mtime) Xdr(xs *xdr.XdrState) {
 	default:
 	}
 }
+func (v *Set_mtime) XdrSize() (n int) {
+	n += 4
+	switch (v).Set_it {
+	case SET_TO_CLIENT_TIME:
+		n += 8
+	}
+	return
+}
+func (v *Set_mtime) Equal(o *Set_mtime) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Time_how)(&((v).Set_it)).Equal((*Time_how)(&((o).Set_it))) {
+		return false
+	}
+	switch (v).Set_it {
+	case SET_TO_CLIENT_TIME:
+		if !(*Nfstime3)(&((v).Mtime)).Equal((*Nfstime3)(&((o).Mtime))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *Set_mtime) Clone() (c Set_mtime) {
+	*(*Time_how)(&((&c).Set_it)) = (*Time_how)(&((v).Set_it)).Clone()
+	switch (v).Set_it {
+	case SET_TO_CLIENT_TIME:
+		*(*Nfstime3)(&((&c).Mtime)) = (*Nfstime3)(&((v).Mtime)).Clone()
+	}
+	return
+}
 func (v *Sattr3) Xdr(xs *xdr.XdrState) {
 	(*Set_mode3)(&((v).Mode)).Xdr(xs)
 	(*Set_uid3)(&((v).Uid)).Xdr(xs)
@@ -171,10 +1068,74 @@ func (v *Sattr3) Xdr(xs *xdr.XdrState) {
 	(*Set_atime)(&((v).Atime)).Xdr(xs)
 	(*Set_mtime)(&((v).Mtime)).Xdr(xs)
 }
+func (v *Sattr3) XdrSize() (n int) {
+	n += (*Set_mode3)(&((v).Mode)).XdrSize()
+	n += (*Set_uid3)(&((v).Uid)).XdrSize()
+	n += (*Set_gid3)(&((v).Gid)).XdrSize()
+	n += (*Set_size3)(&((v).Size)).XdrSize()
+	n += (*Set_atime)(&((v).Atime)).XdrSize()
+	n += (*Set_mtime)(&((v).Mtime)).XdrSize()
+	return
+}
+func (v *Sattr3) Equal(o *Sattr3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Set_mode3)(&((v).Mode)).Equal((*Set_mode3)(&((o).Mode))) {
+		return false
+	}
+	if !(*Set_uid3)(&((v).Uid)).Equal((*Set_uid3)(&((o).Uid))) {
+		return false
+	}
+	if !(*Set_gid3)(&((v).Gid)).Equal((*Set_gid3)(&((o).Gid))) {
+		return false
+	}
+	if !(*Set_size3)(&((v).Size)).Equal((*Set_size3)(&((o).Size))) {
+		return false
+	}
+	if !(*Set_atime)(&((v).Atime)).Equal((*Set_atime)(&((o).Atime))) {
+		return false
+	}
+	if !(*Set_mtime)(&((v).Mtime)).Equal((*Set_mtime)(&((o).Mtime))) {
+		return false
+	}
+	return true
+}
+func (v *Sattr3) Clone() (c Sattr3) {
+	*(*Set_mode3)(&((&c).Mode)) = (*Set_mode3)(&((v).Mode)).Clone()
+	*(*Set_uid3)(&((&c).Uid)) = (*Set_uid3)(&((v).Uid)).Clone()
+	*(*Set_gid3)(&((&c).Gid)) = (*Set_gid3)(&((v).Gid)).Clone()
+	*(*Set_size3)(&((&c).Size)) = (*Set_size3)(&((v).Size)).Clone()
+	*(*Set_atime)(&((&c).Atime)) = (*Set_atime)(&((v).Atime)).Clone()
+	*(*Set_mtime)(&((&c).Mtime)) = (*Set_mtime)(&((v).Mtime)).Clone()
+	return
+}
 func (v *Diropargs3) Xdr(xs *xdr.XdrState) {
 	(*Nfs_fh3)(&((v).Dir)).Xdr(xs)
 	(*Filename3)(&((v).Name)).Xdr(xs)
 }
+func (v *Diropargs3) XdrSize() (n int) {
+	n += (*Nfs_fh3)(&((v).Dir)).XdrSize()
+	n += (*Filename3)(&((v).Name)).XdrSize()
+	return
+}
+func (v *Diropargs3) Equal(o *Diropargs3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfs_fh3)(&((v).Dir)).Equal((*Nfs_fh3)(&((o).Dir))) {
+		return false
+	}
+	if !(*Filename3)(&((v).Name)).Equal((*Filename3)(&((o).Name))) {
+		return false
+	}
+	return true
+}
+func (v *Diropargs3) Clone() (c Diropargs3) {
+	*(*Nfs_fh3)(&((&c).Dir)) = (*Nfs_fh3)(&((v).Dir)).Clone()
+	*(*Filename3)(&((&c).Name)) = (*Filename3)(&((v).Name)).Clone()
+	return
+}
 
 type NFS_PROGRAM_NFS_V3_handler interface {
 	NFSPROC3_NULL()
@@ -577,12 +1538,194 @@ func NFS_PROGRAM_NFS_V3_regs(h NFS_PROGRAM_NFS_V3_handler) []xdr.ProcRegistratio
 		},
 	}
 }
+
+type NFS_PROGRAM_NFS_V3_Client struct {
+	c xdr.ProcCaller
+}
+
+func MakeNFS_PROGRAM_NFS_V3_Client(c xdr.ProcCaller) *NFS_PROGRAM_NFS_V3_Client {
+	return &NFS_PROGRAM_NFS_V3_Client{c}
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_NULL(ctx context.Context) error {
+	var res xdr.Void
+	return c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_NULL, &xdr.Void{}, &res)
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_GETATTR(ctx context.Context, arg GETATTR3args) (res GETATTR3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_GETATTR, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_SETATTR(ctx context.Context, arg SETATTR3args) (res SETATTR3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_SETATTR, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_LOOKUP(ctx context.Context, arg LOOKUP3args) (res LOOKUP3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_LOOKUP, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_ACCESS(ctx context.Context, arg ACCESS3args) (res ACCESS3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_ACCESS, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_READLINK(ctx context.Context, arg READLINK3args) (res READLINK3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_READLINK, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_READ(ctx context.Context, arg READ3args) (res READ3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_READ, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_WRITE(ctx context.Context, arg WRITE3args) (res WRITE3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_WRITE, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_CREATE(ctx context.Context, arg CREATE3args) (res CREATE3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_CREATE, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_MKDIR(ctx context.Context, arg MKDIR3args) (res MKDIR3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_MKDIR, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_SYMLINK(ctx context.Context, arg SYMLINK3args) (res SYMLINK3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_SYMLINK, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_MKNOD(ctx context.Context, arg MKNOD3args) (res MKNOD3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_MKNOD, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_REMOVE(ctx context.Context, arg REMOVE3args) (res REMOVE3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_REMOVE, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_RMDIR(ctx context.Context, arg RMDIR3args) (res RMDIR3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_RMDIR, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_RENAME(ctx context.Context, arg RENAME3args) (res RENAME3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_RENAME, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_LINK(ctx context.Context, arg LINK3args) (res LINK3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_LINK, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_READDIR(ctx context.Context, arg READDIR3args) (res READDIR3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_READDIR, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_READDIRPLUS(ctx context.Context, arg READDIRPLUS3args) (res READDIRPLUS3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_READDIRPLUS, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_FSSTAT(ctx context.Context, arg FSSTAT3args) (res FSSTAT3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_FSSTAT, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_FSINFO(ctx context.Context, arg FSINFO3args) (res FSINFO3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_FSINFO, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_PATHCONF(ctx context.Context, arg PATHCONF3args) (res PATHCONF3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_PATHCONF, &arg, &res)
+	return
+}
+func (c *NFS_PROGRAM_NFS_V3_Client) NFSPROC3_COMMIT(ctx context.Context, arg COMMIT3args) (res COMMIT3res, err error) {
+	err = c.c.CallProc(ctx, NFS_PROGRAM, NFS_V3, NFSPROC3_COMMIT, &arg, &res)
+	return
+}
+func NFS_PROGRAM_ProcName(vers, proc uint32) string {
+	switch vers {
+	case NFS_V3:
+		switch proc {
+		case NFSPROC3_NULL:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_NULL"
+		case NFSPROC3_GETATTR:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_GETATTR"
+		case NFSPROC3_SETATTR:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_SETATTR"
+		case NFSPROC3_LOOKUP:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_LOOKUP"
+		case NFSPROC3_ACCESS:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_ACCESS"
+		case NFSPROC3_READLINK:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_READLINK"
+		case NFSPROC3_READ:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_READ"
+		case NFSPROC3_WRITE:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_WRITE"
+		case NFSPROC3_CREATE:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_CREATE"
+		case NFSPROC3_MKDIR:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_MKDIR"
+		case NFSPROC3_SYMLINK:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_SYMLINK"
+		case NFSPROC3_MKNOD:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_MKNOD"
+		case NFSPROC3_REMOVE:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_REMOVE"
+		case NFSPROC3_RMDIR:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_RMDIR"
+		case NFSPROC3_RENAME:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_RENAME"
+		case NFSPROC3_LINK:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_LINK"
+		case NFSPROC3_READDIR:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_READDIR"
+		case NFSPROC3_READDIRPLUS:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_READDIRPLUS"
+		case NFSPROC3_FSSTAT:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_FSSTAT"
+		case NFSPROC3_FSINFO:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_FSINFO"
+		case NFSPROC3_PATHCONF:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_PATHCONF"
+		case NFSPROC3_COMMIT:
+			return "NFS_PROGRAM/NFS_V3/NFSPROC3_COMMIT"
+		}
+		return fmt.Sprintf("NFS_PROGRAM/NFS_V3/%d", proc)
+	}
+	return fmt.Sprintf("NFS_PROGRAM/%d/%d", vers, proc)
+}
 func (v *GETATTR3args) Xdr(xs *xdr.XdrState) {
 	(*Nfs_fh3)(&((v).Object)).Xdr(xs)
 }
+func (v *GETATTR3args) XdrSize() (n int) {
+	n += (*Nfs_fh3)(&((v).Object)).XdrSize()
+	return
+}
+func (v *GETATTR3args) Equal(o *GETATTR3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfs_fh3)(&((v).Object)).Equal((*Nfs_fh3)(&((o).Object))) {
+		return false
+	}
+	return true
+}
+func (v *GETATTR3args) Clone() (c GETATTR3args) {
+	*(*Nfs_fh3)(&((&c).Object)) = (*Nfs_fh3)(&((v).Object)).Clone()
+	return
+}
 func (v *GETATTR3resok) Xdr(xs *xdr.XdrState) {
 	(*Fattr3)(&((v).Obj_attributes)).Xdr(xs)
 }
+func (v *GETATTR3resok) XdrSize() int {
+	return GETATTR3resok_XdrSize
+}
+func (v *GETATTR3resok) Equal(o *GETATTR3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Fattr3)(&((v).Obj_attributes)).Equal((*Fattr3)(&((o).Obj_attributes))) {
+		return false
+	}
+	return true
+}
+func (v *GETATTR3resok) Clone() (c GETATTR3resok) {
+	*(*Fattr3)(&((&c).Obj_attributes)) = (*Fattr3)(&((v).Obj_attributes)).Clone()
+	return
+}
 func (v *GETATTR3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -591,25 +1734,155 @@ func (v *GETATTR3res) Xdr(xs *xdr.XdrState) {
 	default:
 	}
 }
+func (v *GETATTR3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += 84
+	}
+	return
+}
+func (v *GETATTR3res) Equal(o *GETATTR3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*GETATTR3resok)(&((v).Resok)).Equal((*GETATTR3resok)(&((o).Resok))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *GETATTR3res) Clone() (c GETATTR3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*GETATTR3resok)(&((&c).Resok)) = (*GETATTR3resok)(&((v).Resok)).Clone()
+	}
+	return
+}
 func (v *Sattrguard3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrBool(xs, (*bool)(&((v).Check)))
 	switch (v).Check {
 	case true:
 		(*Nfstime3)(&((v).Obj_ctime)).Xdr(xs)
 	case false:
+	default:
+		if xs.Error() == nil {
+			xs.SetErrorf("invalid Sattrguard3 discriminant %v", (v).Check)
+		}
 	}
 }
+func (v *Sattrguard3) XdrSize() (n int) {
+	n += 4
+	switch (v).Check {
+	case true:
+		n += 8
+	case false:
+	}
+	return
+}
+func (v *Sattrguard3) Equal(o *Sattrguard3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(&((v).Check)) != *(&((o).Check)) {
+		return false
+	}
+	switch (v).Check {
+	case true:
+		if !(*Nfstime3)(&((v).Obj_ctime)).Equal((*Nfstime3)(&((o).Obj_ctime))) {
+			return false
+		}
+	case false:
+	}
+	return true
+}
+func (v *Sattrguard3) Clone() (c Sattrguard3) {
+	*(&((&c).Check)) = *(&((v).Check))
+	switch (v).Check {
+	case true:
+		*(*Nfstime3)(&((&c).Obj_ctime)) = (*Nfstime3)(&((v).Obj_ctime)).Clone()
+	case false:
+	}
+	return
+}
 func (v *SETATTR3args) Xdr(xs *xdr.XdrState) {
 	(*Nfs_fh3)(&((v).Object)).Xdr(xs)
 	(*Sattr3)(&((v).New_attributes)).Xdr(xs)
 	(*Sattrguard3)(&((v).Guard)).Xdr(xs)
 }
+func (v *SETATTR3args) XdrSize() (n int) {
+	n += (*Nfs_fh3)(&((v).Object)).XdrSize()
+	n += (*Sattr3)(&((v).New_attributes)).XdrSize()
+	n += (*Sattrguard3)(&((v).Guard)).XdrSize()
+	return
+}
+func (v *SETATTR3args) Equal(o *SETATTR3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfs_fh3)(&((v).Object)).Equal((*Nfs_fh3)(&((o).Object))) {
+		return false
+	}
+	if !(*Sattr3)(&((v).New_attributes)).Equal((*Sattr3)(&((o).New_attributes))) {
+		return false
+	}
+	if !(*Sattrguard3)(&((v).Guard)).Equal((*Sattrguard3)(&((o).Guard))) {
+		return false
+	}
+	return true
+}
+func (v *SETATTR3args) Clone() (c SETATTR3args) {
+	*(*Nfs_fh3)(&((&c).Object)) = (*Nfs_fh3)(&((v).Object)).Clone()
+	*(*Sattr3)(&((&c).New_attributes)) = (*Sattr3)(&((v).New_attributes)).Clone()
+	*(*Sattrguard3)(&((&c).Guard)) = (*Sattrguard3)(&((v).Guard)).Clone()
+	return
+}
 func (v *SETATTR3resok) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).Obj_wcc)).Xdr(xs)
 }
+func (v *SETATTR3resok) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).Obj_wcc)).XdrSize()
+	return
+}
+func (v *SETATTR3resok) Equal(o *SETATTR3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).Obj_wcc)).Equal((*Wcc_data)(&((o).Obj_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *SETATTR3resok) Clone() (c SETATTR3resok) {
+	*(*Wcc_data)(&((&c).Obj_wcc)) = (*Wcc_data)(&((v).Obj_wcc)).Clone()
+	return
+}
 func (v *SETATTR3resfail) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).Obj_wcc)).Xdr(xs)
 }
+func (v *SETATTR3resfail) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).Obj_wcc)).XdrSize()
+	return
+}
+func (v *SETATTR3resfail) Equal(o *SETATTR3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).Obj_wcc)).Equal((*Wcc_data)(&((o).Obj_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *SETATTR3resfail) Clone() (c SETATTR3resfail) {
+	*(*Wcc_data)(&((&c).Obj_wcc)) = (*Wcc_data)(&((v).Obj_wcc)).Clone()
+	return
+}
 func (v *SETATTR3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -619,17 +1892,117 @@ func (v *SETATTR3res) Xdr(xs *xdr.XdrState) {
 		(*SETATTR3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *SETATTR3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*SETATTR3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*SETATTR3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *SETATTR3res) Equal(o *SETATTR3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*SETATTR3resok)(&((v).Resok)).Equal((*SETATTR3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*SETATTR3resfail)(&((v).Resfail)).Equal((*SETATTR3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *SETATTR3res) Clone() (c SETATTR3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*SETATTR3resok)(&((&c).Resok)) = (*SETATTR3resok)(&((v).Resok)).Clone()
+	default:
+		*(*SETATTR3resfail)(&((&c).Resfail)) = (*SETATTR3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *LOOKUP3args) Xdr(xs *xdr.XdrState) {
 	(*Diropargs3)(&((v).What)).Xdr(xs)
 }
+func (v *LOOKUP3args) XdrSize() (n int) {
+	n += (*Diropargs3)(&((v).What)).XdrSize()
+	return
+}
+func (v *LOOKUP3args) Equal(o *LOOKUP3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Diropargs3)(&((v).What)).Equal((*Diropargs3)(&((o).What))) {
+		return false
+	}
+	return true
+}
+func (v *LOOKUP3args) Clone() (c LOOKUP3args) {
+	*(*Diropargs3)(&((&c).What)) = (*Diropargs3)(&((v).What)).Clone()
+	return
+}
 func (v *LOOKUP3resok) Xdr(xs *xdr.XdrState) {
 	(*Nfs_fh3)(&((v).Object)).Xdr(xs)
 	(*Post_op_attr)(&((v).Obj_attributes)).Xdr(xs)
 	(*Post_op_attr)(&((v).Dir_attributes)).Xdr(xs)
 }
+func (v *LOOKUP3resok) XdrSize() (n int) {
+	n += (*Nfs_fh3)(&((v).Object)).XdrSize()
+	n += (*Post_op_attr)(&((v).Obj_attributes)).XdrSize()
+	n += (*Post_op_attr)(&((v).Dir_attributes)).XdrSize()
+	return
+}
+func (v *LOOKUP3resok) Equal(o *LOOKUP3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfs_fh3)(&((v).Object)).Equal((*Nfs_fh3)(&((o).Object))) {
+		return false
+	}
+	if !(*Post_op_attr)(&((v).Obj_attributes)).Equal((*Post_op_attr)(&((o).Obj_attributes))) {
+		return false
+	}
+	if !(*Post_op_attr)(&((v).Dir_attributes)).Equal((*Post_op_attr)(&((o).Dir_attributes))) {
+		return false
+	}
+	return true
+}
+func (v *LOOKUP3resok) Clone() (c LOOKUP3resok) {
+	*(*Nfs_fh3)(&((&c).Object)) = (*Nfs_fh3)(&((v).Object)).Clone()
+	*(*Post_op_attr)(&((&c).Obj_attributes)) = (*Post_op_attr)(&((v).Obj_attributes)).Clone()
+	*(*Post_op_attr)(&((&c).Dir_attributes)) = (*Post_op_attr)(&((v).Dir_attributes)).Clone()
+	return
+}
 func (v *LOOKUP3resfail) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).Dir_attributes)).Xdr(xs)
 }
+func (v *LOOKUP3resfail) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).Dir_attributes)).XdrSize()
+	return
+}
+func (v *LOOKUP3resfail) Equal(o *LOOKUP3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).Dir_attributes)).Equal((*Post_op_attr)(&((o).Dir_attributes))) {
+		return false
+	}
+	return true
+}
+func (v *LOOKUP3resfail) Clone() (c LOOKUP3resfail) {
+	*(*Post_op_attr)(&((&c).Dir_attributes)) = (*Post_op_attr)(&((v).Dir_attributes)).Clone()
+	return
+}
 func (v *LOOKUP3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -639,17 +2012,117 @@ func (v *LOOKUP3res) Xdr(xs *xdr.XdrState) {
 		(*LOOKUP3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *LOOKUP3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*LOOKUP3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*LOOKUP3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *LOOKUP3res) Equal(o *LOOKUP3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*LOOKUP3resok)(&((v).Resok)).Equal((*LOOKUP3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*LOOKUP3resfail)(&((v).Resfail)).Equal((*LOOKUP3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *LOOKUP3res) Clone() (c LOOKUP3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*LOOKUP3resok)(&((&c).Resok)) = (*LOOKUP3resok)(&((v).Resok)).Clone()
+	default:
+		*(*LOOKUP3resfail)(&((&c).Resfail)) = (*LOOKUP3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *ACCESS3args) Xdr(xs *xdr.XdrState) {
 	(*Nfs_fh3)(&((v).Object)).Xdr(xs)
 	(*Uint32)(&((v).Access)).Xdr(xs)
 }
+func (v *ACCESS3args) XdrSize() (n int) {
+	n += (*Nfs_fh3)(&((v).Object)).XdrSize()
+	n += 4
+	return
+}
+func (v *ACCESS3args) Equal(o *ACCESS3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfs_fh3)(&((v).Object)).Equal((*Nfs_fh3)(&((o).Object))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Access)).Equal((*Uint32)(&((o).Access))) {
+		return false
+	}
+	return true
+}
+func (v *ACCESS3args) Clone() (c ACCESS3args) {
+	*(*Nfs_fh3)(&((&c).Object)) = (*Nfs_fh3)(&((v).Object)).Clone()
+	*(*Uint32)(&((&c).Access)) = (*Uint32)(&((v).Access)).Clone()
+	return
+}
 func (v *ACCESS3resok) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).Obj_attributes)).Xdr(xs)
 	(*Uint32)(&((v).Access)).Xdr(xs)
 }
+func (v *ACCESS3resok) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).Obj_attributes)).XdrSize()
+	n += 4
+	return
+}
+func (v *ACCESS3resok) Equal(o *ACCESS3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).Obj_attributes)).Equal((*Post_op_attr)(&((o).Obj_attributes))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Access)).Equal((*Uint32)(&((o).Access))) {
+		return false
+	}
+	return true
+}
+func (v *ACCESS3resok) Clone() (c ACCESS3resok) {
+	*(*Post_op_attr)(&((&c).Obj_attributes)) = (*Post_op_attr)(&((v).Obj_attributes)).Clone()
+	*(*Uint32)(&((&c).Access)) = (*Uint32)(&((v).Access)).Clone()
+	return
+}
 func (v *ACCESS3resfail) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).Obj_attributes)).Xdr(xs)
 }
+func (v *ACCESS3resfail) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).Obj_attributes)).XdrSize()
+	return
+}
+func (v *ACCESS3resfail) Equal(o *ACCESS3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).Obj_attributes)).Equal((*Post_op_attr)(&((o).Obj_attributes))) {
+		return false
+	}
+	return true
+}
+func (v *ACCESS3resfail) Clone() (c ACCESS3resfail) {
+	*(*Post_op_attr)(&((&c).Obj_attributes)) = (*Post_op_attr)(&((v).Obj_attributes)).Clone()
+	return
+}
 func (v *ACCESS3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -659,16 +2132,111 @@ func (v *ACCESS3res) Xdr(xs *xdr.XdrState) {
 		(*ACCESS3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *ACCESS3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*ACCESS3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*ACCESS3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *ACCESS3res) Equal(o *ACCESS3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*ACCESS3resok)(&((v).Resok)).Equal((*ACCESS3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*ACCESS3resfail)(&((v).Resfail)).Equal((*ACCESS3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *ACCESS3res) Clone() (c ACCESS3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*ACCESS3resok)(&((&c).Resok)) = (*ACCESS3resok)(&((v).Resok)).Clone()
+	default:
+		*(*ACCESS3resfail)(&((&c).Resfail)) = (*ACCESS3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *READLINK3args) Xdr(xs *xdr.XdrState) {
 	(*Nfs_fh3)(&((v).Symlink)).Xdr(xs)
 }
+func (v *READLINK3args) XdrSize() (n int) {
+	n += (*Nfs_fh3)(&((v).Symlink)).XdrSize()
+	return
+}
+func (v *READLINK3args) Equal(o *READLINK3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfs_fh3)(&((v).Symlink)).Equal((*Nfs_fh3)(&((o).Symlink))) {
+		return false
+	}
+	return true
+}
+func (v *READLINK3args) Clone() (c READLINK3args) {
+	*(*Nfs_fh3)(&((&c).Symlink)) = (*Nfs_fh3)(&((v).Symlink)).Clone()
+	return
+}
 func (v *READLINK3resok) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).Symlink_attributes)).Xdr(xs)
 	(*Nfspath3)(&((v).Data)).Xdr(xs)
 }
+func (v *READLINK3resok) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).Symlink_attributes)).XdrSize()
+	n += (*Nfspath3)(&((v).Data)).XdrSize()
+	return
+}
+func (v *READLINK3resok) Equal(o *READLINK3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).Symlink_attributes)).Equal((*Post_op_attr)(&((o).Symlink_attributes))) {
+		return false
+	}
+	if !(*Nfspath3)(&((v).Data)).Equal((*Nfspath3)(&((o).Data))) {
+		return false
+	}
+	return true
+}
+func (v *READLINK3resok) Clone() (c READLINK3resok) {
+	*(*Post_op_attr)(&((&c).Symlink_attributes)) = (*Post_op_attr)(&((v).Symlink_attributes)).Clone()
+	*(*Nfspath3)(&((&c).Data)) = (*Nfspath3)(&((v).Data)).Clone()
+	return
+}
 func (v *READLINK3resfail) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).Symlink_attributes)).Xdr(xs)
 }
+func (v *READLINK3resfail) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).Symlink_attributes)).XdrSize()
+	return
+}
+func (v *READLINK3resfail) Equal(o *READLINK3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).Symlink_attributes)).Equal((*Post_op_attr)(&((o).Symlink_attributes))) {
+		return false
+	}
+	return true
+}
+func (v *READLINK3resfail) Clone() (c READLINK3resfail) {
+	*(*Post_op_attr)(&((&c).Symlink_attributes)) = (*Post_op_attr)(&((v).Symlink_attributes)).Clone()
+	return
+}
 func (v *READLINK3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -678,20 +2246,137 @@ func (v *READLINK3res) Xdr(xs *xdr.XdrState) {
 		(*READLINK3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *READLINK3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*READLINK3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*READLINK3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *READLINK3res) Equal(o *READLINK3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*READLINK3resok)(&((v).Resok)).Equal((*READLINK3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*READLINK3resfail)(&((v).Resfail)).Equal((*READLINK3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *READLINK3res) Clone() (c READLINK3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*READLINK3resok)(&((&c).Resok)) = (*READLINK3resok)(&((v).Resok)).Clone()
+	default:
+		*(*READLINK3resfail)(&((&c).Resfail)) = (*READLINK3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *READ3args) Xdr(xs *xdr.XdrState) {
 	(*Nfs_fh3)(&((v).File)).Xdr(xs)
 	(*Offset3)(&((v).Offset)).Xdr(xs)
 	(*Count3)(&((v).Count)).Xdr(xs)
 }
+func (v *READ3args) XdrSize() (n int) {
+	n += (*Nfs_fh3)(&((v).File)).XdrSize()
+	n += 8
+	n += 4
+	return
+}
+func (v *READ3args) Equal(o *READ3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfs_fh3)(&((v).File)).Equal((*Nfs_fh3)(&((o).File))) {
+		return false
+	}
+	if !(*Offset3)(&((v).Offset)).Equal((*Offset3)(&((o).Offset))) {
+		return false
+	}
+	if !(*Count3)(&((v).Count)).Equal((*Count3)(&((o).Count))) {
+		return false
+	}
+	return true
+}
+func (v *READ3args) Clone() (c READ3args) {
+	*(*Nfs_fh3)(&((&c).File)) = (*Nfs_fh3)(&((v).File)).Clone()
+	*(*Offset3)(&((&c).Offset)) = (*Offset3)(&((v).Offset)).Clone()
+	*(*Count3)(&((&c).Count)) = (*Count3)(&((v).Count)).Clone()
+	return
+}
 func (v *READ3resok) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).File_attributes)).Xdr(xs)
 	(*Count3)(&((v).Count)).Xdr(xs)
 	xdr.XdrBool(xs, (*bool)(&((v).Eof)))
 	xdr.XdrVarArray(xs, int(-1), (*[]byte)(&((v).Data)))
 }
+func (v *READ3resok) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).File_attributes)).XdrSize()
+	n += 4
+	n += 4
+	n += 4 + (len(*(&((v).Data)))+3)&^3
+	return
+}
+func (v *READ3resok) Equal(o *READ3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).File_attributes)).Equal((*Post_op_attr)(&((o).File_attributes))) {
+		return false
+	}
+	if !(*Count3)(&((v).Count)).Equal((*Count3)(&((o).Count))) {
+		return false
+	}
+	if *(&((v).Eof)) != *(&((o).Eof)) {
+		return false
+	}
+	if !bytes.Equal(*(&((v).Data)), *(&((o).Data))) {
+		return false
+	}
+	return true
+}
+func (v *READ3resok) Clone() (c READ3resok) {
+	*(*Post_op_attr)(&((&c).File_attributes)) = (*Post_op_attr)(&((v).File_attributes)).Clone()
+	*(*Count3)(&((&c).Count)) = (*Count3)(&((v).Count)).Clone()
+	*(&((&c).Eof)) = *(&((v).Eof))
+	if *(&((v).Data)) != nil {
+		*(&((&c).Data)) = append([]byte{}, *(&((v).Data))...)
+	}
+	return
+}
 func (v *READ3resfail) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).File_attributes)).Xdr(xs)
 }
+func (v *READ3resfail) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).File_attributes)).XdrSize()
+	return
+}
+func (v *READ3resfail) Equal(o *READ3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).File_attributes)).Equal((*Post_op_attr)(&((o).File_attributes))) {
+		return false
+	}
+	return true
+}
+func (v *READ3resfail) Clone() (c READ3resfail) {
+	*(*Post_op_attr)(&((&c).File_attributes)) = (*Post_op_attr)(&((v).File_attributes)).Clone()
+	return
+}
 func (v *READ3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -701,9 +2386,79 @@ func (v *READ3res) Xdr(xs *xdr.XdrState) {
 		(*READ3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *READ3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*READ3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*READ3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *READ3res) Equal(o *READ3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*READ3resok)(&((v).Resok)).Equal((*READ3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*READ3resfail)(&((v).Resfail)).Equal((*READ3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *READ3res) Clone() (c READ3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*READ3resok)(&((&c).Resok)) = (*READ3resok)(&((v).Resok)).Clone()
+	default:
+		*(*READ3resfail)(&((&c).Resfail)) = (*READ3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
+func (v Stable_how) Valid() bool {
+	return v == UNSTABLE || v == DATA_SYNC || v == FILE_SYNC
+}
 func (v *Stable_how) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(v))
 }
+func (v Stable_how) String() string {
+	if v == UNSTABLE {
+		return "UNSTABLE"
+	}
+	if v == DATA_SYNC {
+		return "DATA_SYNC"
+	}
+	if v == FILE_SYNC {
+		return "FILE_SYNC"
+	}
+	return fmt.Sprintf("Stable_how(%d)", v)
+}
+func (v *Stable_how) XdrSize() int {
+	return Stable_how_XdrSize
+}
+func (v *Stable_how) Equal(o *Stable_how) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Stable_how) Clone() (c Stable_how) {
+	*(&c) = *(v)
+	return
+}
 func (v *WRITE3args) Xdr(xs *xdr.XdrState) {
 	(*Nfs_fh3)(&((v).File)).Xdr(xs)
 	(*Offset3)(&((v).Offset)).Xdr(xs)
@@ -711,15 +2466,103 @@ func (v *WRITE3args) Xdr(xs *xdr.XdrState) {
 	(*Stable_how)(&((v).Stable)).Xdr(xs)
 	xdr.XdrVarArray(xs, int(-1), (*[]byte)(&((v).Data)))
 }
+func (v *WRITE3args) XdrSize() (n int) {
+	n += (*Nfs_fh3)(&((v).File)).XdrSize()
+	n += 8
+	n += 4
+	n += 4
+	n += 4 + (len(*(&((v).Data)))+3)&^3
+	return
+}
+func (v *WRITE3args) Equal(o *WRITE3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfs_fh3)(&((v).File)).Equal((*Nfs_fh3)(&((o).File))) {
+		return false
+	}
+	if !(*Offset3)(&((v).Offset)).Equal((*Offset3)(&((o).Offset))) {
+		return false
+	}
+	if !(*Count3)(&((v).Count)).Equal((*Count3)(&((o).Count))) {
+		return false
+	}
+	if !(*Stable_how)(&((v).Stable)).Equal((*Stable_how)(&((o).Stable))) {
+		return false
+	}
+	if !bytes.Equal(*(&((v).Data)), *(&((o).Data))) {
+		return false
+	}
+	return true
+}
+func (v *WRITE3args) Clone() (c WRITE3args) {
+	*(*Nfs_fh3)(&((&c).File)) = (*Nfs_fh3)(&((v).File)).Clone()
+	*(*Offset3)(&((&c).Offset)) = (*Offset3)(&((v).Offset)).Clone()
+	*(*Count3)(&((&c).Count)) = (*Count3)(&((v).Count)).Clone()
+	*(*Stable_how)(&((&c).Stable)) = (*Stable_how)(&((v).Stable)).Clone()
+	if *(&((v).Data)) != nil {
+		*(&((&c).Data)) = append([]byte{}, *(&((v).Data))...)
+	}
+	return
+}
 func (v *WRITE3resok) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).File_wcc)).Xdr(xs)
 	(*Count3)(&((v).Count)).Xdr(xs)
 	(*Stable_how)(&((v).Committed)).Xdr(xs)
 	(*Writeverf3)(&((v).Verf)).Xdr(xs)
 }
+func (v *WRITE3resok) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).File_wcc)).XdrSize()
+	n += 4
+	n += 4
+	n += 8
+	return
+}
+func (v *WRITE3resok) Equal(o *WRITE3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).File_wcc)).Equal((*Wcc_data)(&((o).File_wcc))) {
+		return false
+	}
+	if !(*Count3)(&((v).Count)).Equal((*Count3)(&((o).Count))) {
+		return false
+	}
+	if !(*Stable_how)(&((v).Committed)).Equal((*Stable_how)(&((o).Committed))) {
+		return false
+	}
+	if !(*Writeverf3)(&((v).Verf)).Equal((*Writeverf3)(&((o).Verf))) {
+		return false
+	}
+	return true
+}
+func (v *WRITE3resok) Clone() (c WRITE3resok) {
+	*(*Wcc_data)(&((&c).File_wcc)) = (*Wcc_data)(&((v).File_wcc)).Clone()
+	*(*Count3)(&((&c).Count)) = (*Count3)(&((v).Count)).Clone()
+	*(*Stable_how)(&((&c).Committed)) = (*Stable_how)(&((v).Committed)).Clone()
+	*(*Writeverf3)(&((&c).Verf)) = (*Writeverf3)(&((v).Verf)).Clone()
+	return
+}
 func (v *WRITE3resfail) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).File_wcc)).Xdr(xs)
 }
+func (v *WRITE3resfail) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).File_wcc)).XdrSize()
+	return
+}
+func (v *WRITE3resfail) Equal(o *WRITE3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).File_wcc)).Equal((*Wcc_data)(&((o).File_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *WRITE3resfail) Clone() (c WRITE3resfail) {
+	*(*Wcc_data)(&((&c).File_wcc)) = (*Wcc_data)(&((v).File_wcc)).Clone()
+	return
+}
 func (v *WRITE3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -729,32 +2572,211 @@ func (v *WRITE3res) Xdr(xs *xdr.XdrState) {
 		(*WRITE3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *WRITE3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*WRITE3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*WRITE3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *WRITE3res) Equal(o *WRITE3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*WRITE3resok)(&((v).Resok)).Equal((*WRITE3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*WRITE3resfail)(&((v).Resfail)).Equal((*WRITE3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *WRITE3res) Clone() (c WRITE3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*WRITE3resok)(&((&c).Resok)) = (*WRITE3resok)(&((v).Resok)).Clone()
+	default:
+		*(*WRITE3resfail)(&((&c).Resfail)) = (*WRITE3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
+func (v Createmode3) Valid() bool {
+	return v == UNCHECKED || v == GUARDED || v == EXCLUSIVE
+}
 func (v *Createmode3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(v))
 }
-func (v *Createhow3) Xdr(xs *xdr.XdrState) {
-	(*Createmode3)(&((v).Mode)).Xdr(xs)
+func (v Createmode3) String() string {
+	if v == UNCHECKED {
+		return "UNCHECKED"
+	}
+	if v == GUARDED {
+		return "GUARDED"
+	}
+	if v == EXCLUSIVE {
+		return "EXCLUSIVE"
+	}
+	return fmt.Sprintf("Createmode3(%d)", v)
+}
+func (v *Createmode3) XdrSize() int {
+	return Createmode3_XdrSize
+}
+func (v *Createmode3) Equal(o *Createmode3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Createmode3) Clone() (c Createmode3) {
+	*(&c) = *(v)
+	return
+}
+func (v *Createhow3) Xdr(xs *xdr.XdrState) {
+	(*Createmode3)(&((v).Mode)).Xdr(xs)
+	switch (v).Mode {
+	case UNCHECKED:
+		fallthrough
+	case GUARDED:
+		(*Sattr3)(&((v).Obj_attributes)).Xdr(xs)
+	case EXCLUSIVE:
+		(*Createverf3)(&((v).Verf)).Xdr(xs)
+	default:
+		if xs.Error() == nil {
+			xs.SetErrorf("invalid Createhow3 discriminant %v", (v).Mode)
+		}
+	}
+}
+func (v *Createhow3) XdrSize() (n int) {
+	n += 4
+	switch (v).Mode {
+	case UNCHECKED, GUARDED:
+		n += (*Sattr3)(&((v).Obj_attributes)).XdrSize()
+	case EXCLUSIVE:
+		n += 8
+	}
+	return
+}
+func (v *Createhow3) Equal(o *Createhow3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Createmode3)(&((v).Mode)).Equal((*Createmode3)(&((o).Mode))) {
+		return false
+	}
+	switch (v).Mode {
+	case UNCHECKED, GUARDED:
+		if !(*Sattr3)(&((v).Obj_attributes)).Equal((*Sattr3)(&((o).Obj_attributes))) {
+			return false
+		}
+	case EXCLUSIVE:
+		if !(*Createverf3)(&((v).Verf)).Equal((*Createverf3)(&((o).Verf))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *Createhow3) Clone() (c Createhow3) {
+	*(*Createmode3)(&((&c).Mode)) = (*Createmode3)(&((v).Mode)).Clone()
 	switch (v).Mode {
-	case UNCHECKED:
-		fallthrough
-	case GUARDED:
-		(*Sattr3)(&((v).Obj_attributes)).Xdr(xs)
+	case UNCHECKED, GUARDED:
+		*(*Sattr3)(&((&c).Obj_attributes)) = (*Sattr3)(&((v).Obj_attributes)).Clone()
 	case EXCLUSIVE:
-		(*Createverf3)(&((v).Verf)).Xdr(xs)
+		*(*Createverf3)(&((&c).Verf)) = (*Createverf3)(&((v).Verf)).Clone()
 	}
+	return
 }
 func (v *CREATE3args) Xdr(xs *xdr.XdrState) {
 	(*Diropargs3)(&((v).Where)).Xdr(xs)
 	(*Createhow3)(&((v).How)).Xdr(xs)
 }
+func (v *CREATE3args) XdrSize() (n int) {
+	n += (*Diropargs3)(&((v).Where)).XdrSize()
+	n += (*Createhow3)(&((v).How)).XdrSize()
+	return
+}
+func (v *CREATE3args) Equal(o *CREATE3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Diropargs3)(&((v).Where)).Equal((*Diropargs3)(&((o).Where))) {
+		return false
+	}
+	if !(*Createhow3)(&((v).How)).Equal((*Createhow3)(&((o).How))) {
+		return false
+	}
+	return true
+}
+func (v *CREATE3args) Clone() (c CREATE3args) {
+	*(*Diropargs3)(&((&c).Where)) = (*Diropargs3)(&((v).Where)).Clone()
+	*(*Createhow3)(&((&c).How)) = (*Createhow3)(&((v).How)).Clone()
+	return
+}
 func (v *CREATE3resok) Xdr(xs *xdr.XdrState) {
 	(*Post_op_fh3)(&((v).Obj)).Xdr(xs)
 	(*Post_op_attr)(&((v).Obj_attributes)).Xdr(xs)
 	(*Wcc_data)(&((v).Dir_wcc)).Xdr(xs)
 }
+func (v *CREATE3resok) XdrSize() (n int) {
+	n += (*Post_op_fh3)(&((v).Obj)).XdrSize()
+	n += (*Post_op_attr)(&((v).Obj_attributes)).XdrSize()
+	n += (*Wcc_data)(&((v).Dir_wcc)).XdrSize()
+	return
+}
+func (v *CREATE3resok) Equal(o *CREATE3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_fh3)(&((v).Obj)).Equal((*Post_op_fh3)(&((o).Obj))) {
+		return false
+	}
+	if !(*Post_op_attr)(&((v).Obj_attributes)).Equal((*Post_op_attr)(&((o).Obj_attributes))) {
+		return false
+	}
+	if !(*Wcc_data)(&((v).Dir_wcc)).Equal((*Wcc_data)(&((o).Dir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *CREATE3resok) Clone() (c CREATE3resok) {
+	*(*Post_op_fh3)(&((&c).Obj)) = (*Post_op_fh3)(&((v).Obj)).Clone()
+	*(*Post_op_attr)(&((&c).Obj_attributes)) = (*Post_op_attr)(&((v).Obj_attributes)).Clone()
+	*(*Wcc_data)(&((&c).Dir_wcc)) = (*Wcc_data)(&((v).Dir_wcc)).Clone()
+	return
+}
 func (v *CREATE3resfail) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).Dir_wcc)).Xdr(xs)
 }
+func (v *CREATE3resfail) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).Dir_wcc)).XdrSize()
+	return
+}
+func (v *CREATE3resfail) Equal(o *CREATE3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).Dir_wcc)).Equal((*Wcc_data)(&((o).Dir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *CREATE3resfail) Clone() (c CREATE3resfail) {
+	*(*Wcc_data)(&((&c).Dir_wcc)) = (*Wcc_data)(&((v).Dir_wcc)).Clone()
+	return
+}
 func (v *CREATE3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -764,18 +2786,123 @@ func (v *CREATE3res) Xdr(xs *xdr.XdrState) {
 		(*CREATE3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *CREATE3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*CREATE3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*CREATE3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *CREATE3res) Equal(o *CREATE3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*CREATE3resok)(&((v).Resok)).Equal((*CREATE3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*CREATE3resfail)(&((v).Resfail)).Equal((*CREATE3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *CREATE3res) Clone() (c CREATE3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*CREATE3resok)(&((&c).Resok)) = (*CREATE3resok)(&((v).Resok)).Clone()
+	default:
+		*(*CREATE3resfail)(&((&c).Resfail)) = (*CREATE3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *MKDIR3args) Xdr(xs *xdr.XdrState) {
 	(*Diropargs3)(&((v).Where)).Xdr(xs)
 	(*Sattr3)(&((v).Attributes)).Xdr(xs)
 }
+func (v *MKDIR3args) XdrSize() (n int) {
+	n += (*Diropargs3)(&((v).Where)).XdrSize()
+	n += (*Sattr3)(&((v).Attributes)).XdrSize()
+	return
+}
+func (v *MKDIR3args) Equal(o *MKDIR3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Diropargs3)(&((v).Where)).Equal((*Diropargs3)(&((o).Where))) {
+		return false
+	}
+	if !(*Sattr3)(&((v).Attributes)).Equal((*Sattr3)(&((o).Attributes))) {
+		return false
+	}
+	return true
+}
+func (v *MKDIR3args) Clone() (c MKDIR3args) {
+	*(*Diropargs3)(&((&c).Where)) = (*Diropargs3)(&((v).Where)).Clone()
+	*(*Sattr3)(&((&c).Attributes)) = (*Sattr3)(&((v).Attributes)).Clone()
+	return
+}
 func (v *MKDIR3resok) Xdr(xs *xdr.XdrState) {
 	(*Post_op_fh3)(&((v).Obj)).Xdr(xs)
 	(*Post_op_attr)(&((v).Obj_attributes)).Xdr(xs)
 	(*Wcc_data)(&((v).Dir_wcc)).Xdr(xs)
 }
+func (v *MKDIR3resok) XdrSize() (n int) {
+	n += (*Post_op_fh3)(&((v).Obj)).XdrSize()
+	n += (*Post_op_attr)(&((v).Obj_attributes)).XdrSize()
+	n += (*Wcc_data)(&((v).Dir_wcc)).XdrSize()
+	return
+}
+func (v *MKDIR3resok) Equal(o *MKDIR3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_fh3)(&((v).Obj)).Equal((*Post_op_fh3)(&((o).Obj))) {
+		return false
+	}
+	if !(*Post_op_attr)(&((v).Obj_attributes)).Equal((*Post_op_attr)(&((o).Obj_attributes))) {
+		return false
+	}
+	if !(*Wcc_data)(&((v).Dir_wcc)).Equal((*Wcc_data)(&((o).Dir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *MKDIR3resok) Clone() (c MKDIR3resok) {
+	*(*Post_op_fh3)(&((&c).Obj)) = (*Post_op_fh3)(&((v).Obj)).Clone()
+	*(*Post_op_attr)(&((&c).Obj_attributes)) = (*Post_op_attr)(&((v).Obj_attributes)).Clone()
+	*(*Wcc_data)(&((&c).Dir_wcc)) = (*Wcc_data)(&((v).Dir_wcc)).Clone()
+	return
+}
 func (v *MKDIR3resfail) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).Dir_wcc)).Xdr(xs)
 }
+func (v *MKDIR3resfail) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).Dir_wcc)).XdrSize()
+	return
+}
+func (v *MKDIR3resfail) Equal(o *MKDIR3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).Dir_wcc)).Equal((*Wcc_data)(&((o).Dir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *MKDIR3resfail) Clone() (c MKDIR3resfail) {
+	*(*Wcc_data)(&((&c).Dir_wcc)) = (*Wcc_data)(&((v).Dir_wcc)).Clone()
+	return
+}
 func (v *MKDIR3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -785,22 +2912,149 @@ func (v *MKDIR3res) Xdr(xs *xdr.XdrState) {
 		(*MKDIR3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *MKDIR3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*MKDIR3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*MKDIR3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *MKDIR3res) Equal(o *MKDIR3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*MKDIR3resok)(&((v).Resok)).Equal((*MKDIR3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*MKDIR3resfail)(&((v).Resfail)).Equal((*MKDIR3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *MKDIR3res) Clone() (c MKDIR3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*MKDIR3resok)(&((&c).Resok)) = (*MKDIR3resok)(&((v).Resok)).Clone()
+	default:
+		*(*MKDIR3resfail)(&((&c).Resfail)) = (*MKDIR3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *Symlinkdata3) Xdr(xs *xdr.XdrState) {
 	(*Sattr3)(&((v).Symlink_attributes)).Xdr(xs)
 	(*Nfspath3)(&((v).Symlink_data)).Xdr(xs)
 }
+func (v *Symlinkdata3) XdrSize() (n int) {
+	n += (*Sattr3)(&((v).Symlink_attributes)).XdrSize()
+	n += (*Nfspath3)(&((v).Symlink_data)).XdrSize()
+	return
+}
+func (v *Symlinkdata3) Equal(o *Symlinkdata3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Sattr3)(&((v).Symlink_attributes)).Equal((*Sattr3)(&((o).Symlink_attributes))) {
+		return false
+	}
+	if !(*Nfspath3)(&((v).Symlink_data)).Equal((*Nfspath3)(&((o).Symlink_data))) {
+		return false
+	}
+	return true
+}
+func (v *Symlinkdata3) Clone() (c Symlinkdata3) {
+	*(*Sattr3)(&((&c).Symlink_attributes)) = (*Sattr3)(&((v).Symlink_attributes)).Clone()
+	*(*Nfspath3)(&((&c).Symlink_data)) = (*Nfspath3)(&((v).Symlink_data)).Clone()
+	return
+}
 func (v *SYMLINK3args) Xdr(xs *xdr.XdrState) {
 	(*Diropargs3)(&((v).Where)).Xdr(xs)
 	(*Symlinkdata3)(&((v).Symlink)).Xdr(xs)
 }
+func (v *SYMLINK3args) XdrSize() (n int) {
+	n += (*Diropargs3)(&((v).Where)).XdrSize()
+	n += (*Symlinkdata3)(&((v).Symlink)).XdrSize()
+	return
+}
+func (v *SYMLINK3args) Equal(o *SYMLINK3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Diropargs3)(&((v).Where)).Equal((*Diropargs3)(&((o).Where))) {
+		return false
+	}
+	if !(*Symlinkdata3)(&((v).Symlink)).Equal((*Symlinkdata3)(&((o).Symlink))) {
+		return false
+	}
+	return true
+}
+func (v *SYMLINK3args) Clone() (c SYMLINK3args) {
+	*(*Diropargs3)(&((&c).Where)) = (*Diropargs3)(&((v).Where)).Clone()
+	*(*Symlinkdata3)(&((&c).Symlink)) = (*Symlinkdata3)(&((v).Symlink)).Clone()
+	return
+}
 func (v *SYMLINK3resok) Xdr(xs *xdr.XdrState) {
 	(*Post_op_fh3)(&((v).Obj)).Xdr(xs)
 	(*Post_op_attr)(&((v).Obj_attributes)).Xdr(xs)
 	(*Wcc_data)(&((v).Dir_wcc)).Xdr(xs)
 }
+func (v *SYMLINK3resok) XdrSize() (n int) {
+	n += (*Post_op_fh3)(&((v).Obj)).XdrSize()
+	n += (*Post_op_attr)(&((v).Obj_attributes)).XdrSize()
+	n += (*Wcc_data)(&((v).Dir_wcc)).XdrSize()
+	return
+}
+func (v *SYMLINK3resok) Equal(o *SYMLINK3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_fh3)(&((v).Obj)).Equal((*Post_op_fh3)(&((o).Obj))) {
+		return false
+	}
+	if !(*Post_op_attr)(&((v).Obj_attributes)).Equal((*Post_op_attr)(&((o).Obj_attributes))) {
+		return false
+	}
+	if !(*Wcc_data)(&((v).Dir_wcc)).Equal((*Wcc_data)(&((o).Dir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *SYMLINK3resok) Clone() (c SYMLINK3resok) {
+	*(*Post_op_fh3)(&((&c).Obj)) = (*Post_op_fh3)(&((v).Obj)).Clone()
+	*(*Post_op_attr)(&((&c).Obj_attributes)) = (*Post_op_attr)(&((v).Obj_attributes)).Clone()
+	*(*Wcc_data)(&((&c).Dir_wcc)) = (*Wcc_data)(&((v).Dir_wcc)).Clone()
+	return
+}
 func (v *SYMLINK3resfail) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).Dir_wcc)).Xdr(xs)
 }
+func (v *SYMLINK3resfail) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).Dir_wcc)).XdrSize()
+	return
+}
+func (v *SYMLINK3resfail) Equal(o *SYMLINK3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).Dir_wcc)).Equal((*Wcc_data)(&((o).Dir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *SYMLINK3resfail) Clone() (c SYMLINK3resfail) {
+	*(*Wcc_data)(&((&c).Dir_wcc)) = (*Wcc_data)(&((v).Dir_wcc)).Clone()
+	return
+}
 func (v *SYMLINK3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -810,10 +3064,71 @@ func (v *SYMLINK3res) Xdr(xs *xdr.XdrState) {
 		(*SYMLINK3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *SYMLINK3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*SYMLINK3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*SYMLINK3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *SYMLINK3res) Equal(o *SYMLINK3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*SYMLINK3resok)(&((v).Resok)).Equal((*SYMLINK3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*SYMLINK3resfail)(&((v).Resfail)).Equal((*SYMLINK3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *SYMLINK3res) Clone() (c SYMLINK3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*SYMLINK3resok)(&((&c).Resok)) = (*SYMLINK3resok)(&((v).Resok)).Clone()
+	default:
+		*(*SYMLINK3resfail)(&((&c).Resfail)) = (*SYMLINK3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *Devicedata3) Xdr(xs *xdr.XdrState) {
 	(*Sattr3)(&((v).Dev_attributes)).Xdr(xs)
 	(*Specdata3)(&((v).Spec)).Xdr(xs)
 }
+func (v *Devicedata3) XdrSize() (n int) {
+	n += (*Sattr3)(&((v).Dev_attributes)).XdrSize()
+	n += 8
+	return
+}
+func (v *Devicedata3) Equal(o *Devicedata3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Sattr3)(&((v).Dev_attributes)).Equal((*Sattr3)(&((o).Dev_attributes))) {
+		return false
+	}
+	if !(*Specdata3)(&((v).Spec)).Equal((*Specdata3)(&((o).Spec))) {
+		return false
+	}
+	return true
+}
+func (v *Devicedata3) Clone() (c Devicedata3) {
+	*(*Sattr3)(&((&c).Dev_attributes)) = (*Sattr3)(&((v).Dev_attributes)).Clone()
+	*(*Specdata3)(&((&c).Spec)) = (*Specdata3)(&((v).Spec)).Clone()
+	return
+}
 func (v *Mknoddata3) Xdr(xs *xdr.XdrState) {
 	(*Ftype3)(&((v).Ftype)).Xdr(xs)
 	switch (v).Ftype {
@@ -828,18 +3143,123 @@ func (v *Mknoddata3) Xdr(xs *xdr.XdrState) {
 	default:
 	}
 }
+func (v *Mknoddata3) XdrSize() (n int) {
+	n += 4
+	switch (v).Ftype {
+	case NF3CHR, NF3BLK:
+		n += (*Devicedata3)(&((v).Device)).XdrSize()
+	case NF3SOCK, NF3FIFO:
+		n += (*Sattr3)(&((v).Pipe_attributes)).XdrSize()
+	}
+	return
+}
+func (v *Mknoddata3) Equal(o *Mknoddata3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Ftype3)(&((v).Ftype)).Equal((*Ftype3)(&((o).Ftype))) {
+		return false
+	}
+	switch (v).Ftype {
+	case NF3CHR, NF3BLK:
+		if !(*Devicedata3)(&((v).Device)).Equal((*Devicedata3)(&((o).Device))) {
+			return false
+		}
+	case NF3SOCK, NF3FIFO:
+		if !(*Sattr3)(&((v).Pipe_attributes)).Equal((*Sattr3)(&((o).Pipe_attributes))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *Mknoddata3) Clone() (c Mknoddata3) {
+	*(*Ftype3)(&((&c).Ftype)) = (*Ftype3)(&((v).Ftype)).Clone()
+	switch (v).Ftype {
+	case NF3CHR, NF3BLK:
+		*(*Devicedata3)(&((&c).Device)) = (*Devicedata3)(&((v).Device)).Clone()
+	case NF3SOCK, NF3FIFO:
+		*(*Sattr3)(&((&c).Pipe_attributes)) = (*Sattr3)(&((v).Pipe_attributes)).Clone()
+	}
+	return
+}
 func (v *MKNOD3args) Xdr(xs *xdr.XdrState) {
 	(*Diropargs3)(&((v).Where)).Xdr(xs)
 	(*Mknoddata3)(&((v).What)).Xdr(xs)
 }
+func (v *MKNOD3args) XdrSize() (n int) {
+	n += (*Diropargs3)(&((v).Where)).XdrSize()
+	n += (*Mknoddata3)(&((v).What)).XdrSize()
+	return
+}
+func (v *MKNOD3args) Equal(o *MKNOD3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Diropargs3)(&((v).Where)).Equal((*Diropargs3)(&((o).Where))) {
+		return false
+	}
+	if !(*Mknoddata3)(&((v).What)).Equal((*Mknoddata3)(&((o).What))) {
+		return false
+	}
+	return true
+}
+func (v *MKNOD3args) Clone() (c MKNOD3args) {
+	*(*Diropargs3)(&((&c).Where)) = (*Diropargs3)(&((v).Where)).Clone()
+	*(*Mknoddata3)(&((&c).What)) = (*Mknoddata3)(&((v).What)).Clone()
+	return
+}
 func (v *MKNOD3resok) Xdr(xs *xdr.XdrState) {
 	(*Post_op_fh3)(&((v).Obj)).Xdr(xs)
 	(*Post_op_attr)(&((v).Obj_attributes)).Xdr(xs)
 	(*Wcc_data)(&((v).Dir_wcc)).Xdr(xs)
 }
+func (v *MKNOD3resok) XdrSize() (n int) {
+	n += (*Post_op_fh3)(&((v).Obj)).XdrSize()
+	n += (*Post_op_attr)(&((v).Obj_attributes)).XdrSize()
+	n += (*Wcc_data)(&((v).Dir_wcc)).XdrSize()
+	return
+}
+func (v *MKNOD3resok) Equal(o *MKNOD3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_fh3)(&((v).Obj)).Equal((*Post_op_fh3)(&((o).Obj))) {
+		return false
+	}
+	if !(*Post_op_attr)(&((v).Obj_attributes)).Equal((*Post_op_attr)(&((o).Obj_attributes))) {
+		return false
+	}
+	if !(*Wcc_data)(&((v).Dir_wcc)).Equal((*Wcc_data)(&((o).Dir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *MKNOD3resok) Clone() (c MKNOD3resok) {
+	*(*Post_op_fh3)(&((&c).Obj)) = (*Post_op_fh3)(&((v).Obj)).Clone()
+	*(*Post_op_attr)(&((&c).Obj_attributes)) = (*Post_op_attr)(&((v).Obj_attributes)).Clone()
+	*(*Wcc_data)(&((&c).Dir_wcc)) = (*Wcc_data)(&((v).Dir_wcc)).Clone()
+	return
+}
 func (v *MKNOD3resfail) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).Dir_wcc)).Xdr(xs)
 }
+func (v *MKNOD3resfail) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).Dir_wcc)).XdrSize()
+	return
+}
+func (v *MKNOD3resfail) Equal(o *MKNOD3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).Dir_wcc)).Equal((*Wcc_data)(&((o).Dir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *MKNOD3resfail) Clone() (c MKNOD3resfail) {
+	*(*Wcc_data)(&((&c).Dir_wcc)) = (*Wcc_data)(&((v).Dir_wcc)).Clone()
+	return
+}
 func (v *MKNOD3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -849,15 +3269,105 @@ func (v *MKNOD3res) Xdr(xs *xdr.XdrState) {
 		(*MKNOD3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *MKNOD3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*MKNOD3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*MKNOD3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *MKNOD3res) Equal(o *MKNOD3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*MKNOD3resok)(&((v).Resok)).Equal((*MKNOD3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*MKNOD3resfail)(&((v).Resfail)).Equal((*MKNOD3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *MKNOD3res) Clone() (c MKNOD3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*MKNOD3resok)(&((&c).Resok)) = (*MKNOD3resok)(&((v).Resok)).Clone()
+	default:
+		*(*MKNOD3resfail)(&((&c).Resfail)) = (*MKNOD3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *REMOVE3args) Xdr(xs *xdr.XdrState) {
 	(*Diropargs3)(&((v).Object)).Xdr(xs)
 }
+func (v *REMOVE3args) XdrSize() (n int) {
+	n += (*Diropargs3)(&((v).Object)).XdrSize()
+	return
+}
+func (v *REMOVE3args) Equal(o *REMOVE3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Diropargs3)(&((v).Object)).Equal((*Diropargs3)(&((o).Object))) {
+		return false
+	}
+	return true
+}
+func (v *REMOVE3args) Clone() (c REMOVE3args) {
+	*(*Diropargs3)(&((&c).Object)) = (*Diropargs3)(&((v).Object)).Clone()
+	return
+}
 func (v *REMOVE3resok) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).Dir_wcc)).Xdr(xs)
 }
+func (v *REMOVE3resok) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).Dir_wcc)).XdrSize()
+	return
+}
+func (v *REMOVE3resok) Equal(o *REMOVE3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).Dir_wcc)).Equal((*Wcc_data)(&((o).Dir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *REMOVE3resok) Clone() (c REMOVE3resok) {
+	*(*Wcc_data)(&((&c).Dir_wcc)) = (*Wcc_data)(&((v).Dir_wcc)).Clone()
+	return
+}
 func (v *REMOVE3resfail) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).Dir_wcc)).Xdr(xs)
 }
+func (v *REMOVE3resfail) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).Dir_wcc)).XdrSize()
+	return
+}
+func (v *REMOVE3resfail) Equal(o *REMOVE3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).Dir_wcc)).Equal((*Wcc_data)(&((o).Dir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *REMOVE3resfail) Clone() (c REMOVE3resfail) {
+	*(*Wcc_data)(&((&c).Dir_wcc)) = (*Wcc_data)(&((v).Dir_wcc)).Clone()
+	return
+}
 func (v *REMOVE3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -867,15 +3377,105 @@ func (v *REMOVE3res) Xdr(xs *xdr.XdrState) {
 		(*REMOVE3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *REMOVE3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*REMOVE3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*REMOVE3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *REMOVE3res) Equal(o *REMOVE3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*REMOVE3resok)(&((v).Resok)).Equal((*REMOVE3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*REMOVE3resfail)(&((v).Resfail)).Equal((*REMOVE3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *REMOVE3res) Clone() (c REMOVE3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*REMOVE3resok)(&((&c).Resok)) = (*REMOVE3resok)(&((v).Resok)).Clone()
+	default:
+		*(*REMOVE3resfail)(&((&c).Resfail)) = (*REMOVE3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *RMDIR3args) Xdr(xs *xdr.XdrState) {
 	(*Diropargs3)(&((v).Object)).Xdr(xs)
 }
+func (v *RMDIR3args) XdrSize() (n int) {
+	n += (*Diropargs3)(&((v).Object)).XdrSize()
+	return
+}
+func (v *RMDIR3args) Equal(o *RMDIR3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Diropargs3)(&((v).Object)).Equal((*Diropargs3)(&((o).Object))) {
+		return false
+	}
+	return true
+}
+func (v *RMDIR3args) Clone() (c RMDIR3args) {
+	*(*Diropargs3)(&((&c).Object)) = (*Diropargs3)(&((v).Object)).Clone()
+	return
+}
 func (v *RMDIR3resok) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).Dir_wcc)).Xdr(xs)
 }
+func (v *RMDIR3resok) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).Dir_wcc)).XdrSize()
+	return
+}
+func (v *RMDIR3resok) Equal(o *RMDIR3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).Dir_wcc)).Equal((*Wcc_data)(&((o).Dir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *RMDIR3resok) Clone() (c RMDIR3resok) {
+	*(*Wcc_data)(&((&c).Dir_wcc)) = (*Wcc_data)(&((v).Dir_wcc)).Clone()
+	return
+}
 func (v *RMDIR3resfail) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).Dir_wcc)).Xdr(xs)
 }
+func (v *RMDIR3resfail) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).Dir_wcc)).XdrSize()
+	return
+}
+func (v *RMDIR3resfail) Equal(o *RMDIR3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).Dir_wcc)).Equal((*Wcc_data)(&((o).Dir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *RMDIR3resfail) Clone() (c RMDIR3resfail) {
+	*(*Wcc_data)(&((&c).Dir_wcc)) = (*Wcc_data)(&((v).Dir_wcc)).Clone()
+	return
+}
 func (v *RMDIR3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -885,18 +3485,123 @@ func (v *RMDIR3res) Xdr(xs *xdr.XdrState) {
 		(*RMDIR3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *RMDIR3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*RMDIR3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*RMDIR3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *RMDIR3res) Equal(o *RMDIR3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*RMDIR3resok)(&((v).Resok)).Equal((*RMDIR3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*RMDIR3resfail)(&((v).Resfail)).Equal((*RMDIR3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *RMDIR3res) Clone() (c RMDIR3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*RMDIR3resok)(&((&c).Resok)) = (*RMDIR3resok)(&((v).Resok)).Clone()
+	default:
+		*(*RMDIR3resfail)(&((&c).Resfail)) = (*RMDIR3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *RENAME3args) Xdr(xs *xdr.XdrState) {
 	(*Diropargs3)(&((v).From)).Xdr(xs)
 	(*Diropargs3)(&((v).To)).Xdr(xs)
 }
+func (v *RENAME3args) XdrSize() (n int) {
+	n += (*Diropargs3)(&((v).From)).XdrSize()
+	n += (*Diropargs3)(&((v).To)).XdrSize()
+	return
+}
+func (v *RENAME3args) Equal(o *RENAME3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Diropargs3)(&((v).From)).Equal((*Diropargs3)(&((o).From))) {
+		return false
+	}
+	if !(*Diropargs3)(&((v).To)).Equal((*Diropargs3)(&((o).To))) {
+		return false
+	}
+	return true
+}
+func (v *RENAME3args) Clone() (c RENAME3args) {
+	*(*Diropargs3)(&((&c).From)) = (*Diropargs3)(&((v).From)).Clone()
+	*(*Diropargs3)(&((&c).To)) = (*Diropargs3)(&((v).To)).Clone()
+	return
+}
 func (v *RENAME3resok) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).Fromdir_wcc)).Xdr(xs)
 	(*Wcc_data)(&((v).Todir_wcc)).Xdr(xs)
 }
+func (v *RENAME3resok) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).Fromdir_wcc)).XdrSize()
+	n += (*Wcc_data)(&((v).Todir_wcc)).XdrSize()
+	return
+}
+func (v *RENAME3resok) Equal(o *RENAME3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).Fromdir_wcc)).Equal((*Wcc_data)(&((o).Fromdir_wcc))) {
+		return false
+	}
+	if !(*Wcc_data)(&((v).Todir_wcc)).Equal((*Wcc_data)(&((o).Todir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *RENAME3resok) Clone() (c RENAME3resok) {
+	*(*Wcc_data)(&((&c).Fromdir_wcc)) = (*Wcc_data)(&((v).Fromdir_wcc)).Clone()
+	*(*Wcc_data)(&((&c).Todir_wcc)) = (*Wcc_data)(&((v).Todir_wcc)).Clone()
+	return
+}
 func (v *RENAME3resfail) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).Fromdir_wcc)).Xdr(xs)
 	(*Wcc_data)(&((v).Todir_wcc)).Xdr(xs)
 }
+func (v *RENAME3resfail) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).Fromdir_wcc)).XdrSize()
+	n += (*Wcc_data)(&((v).Todir_wcc)).XdrSize()
+	return
+}
+func (v *RENAME3resfail) Equal(o *RENAME3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).Fromdir_wcc)).Equal((*Wcc_data)(&((o).Fromdir_wcc))) {
+		return false
+	}
+	if !(*Wcc_data)(&((v).Todir_wcc)).Equal((*Wcc_data)(&((o).Todir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *RENAME3resfail) Clone() (c RENAME3resfail) {
+	*(*Wcc_data)(&((&c).Fromdir_wcc)) = (*Wcc_data)(&((v).Fromdir_wcc)).Clone()
+	*(*Wcc_data)(&((&c).Todir_wcc)) = (*Wcc_data)(&((v).Todir_wcc)).Clone()
+	return
+}
 func (v *RENAME3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -906,18 +3611,123 @@ func (v *RENAME3res) Xdr(xs *xdr.XdrState) {
 		(*RENAME3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *RENAME3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*RENAME3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*RENAME3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *RENAME3res) Equal(o *RENAME3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*RENAME3resok)(&((v).Resok)).Equal((*RENAME3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*RENAME3resfail)(&((v).Resfail)).Equal((*RENAME3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *RENAME3res) Clone() (c RENAME3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*RENAME3resok)(&((&c).Resok)) = (*RENAME3resok)(&((v).Resok)).Clone()
+	default:
+		*(*RENAME3resfail)(&((&c).Resfail)) = (*RENAME3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *LINK3args) Xdr(xs *xdr.XdrState) {
 	(*Nfs_fh3)(&((v).File)).Xdr(xs)
 	(*Diropargs3)(&((v).Link)).Xdr(xs)
 }
+func (v *LINK3args) XdrSize() (n int) {
+	n += (*Nfs_fh3)(&((v).File)).XdrSize()
+	n += (*Diropargs3)(&((v).Link)).XdrSize()
+	return
+}
+func (v *LINK3args) Equal(o *LINK3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfs_fh3)(&((v).File)).Equal((*Nfs_fh3)(&((o).File))) {
+		return false
+	}
+	if !(*Diropargs3)(&((v).Link)).Equal((*Diropargs3)(&((o).Link))) {
+		return false
+	}
+	return true
+}
+func (v *LINK3args) Clone() (c LINK3args) {
+	*(*Nfs_fh3)(&((&c).File)) = (*Nfs_fh3)(&((v).File)).Clone()
+	*(*Diropargs3)(&((&c).Link)) = (*Diropargs3)(&((v).Link)).Clone()
+	return
+}
 func (v *LINK3resok) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).File_attributes)).Xdr(xs)
 	(*Wcc_data)(&((v).Linkdir_wcc)).Xdr(xs)
 }
+func (v *LINK3resok) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).File_attributes)).XdrSize()
+	n += (*Wcc_data)(&((v).Linkdir_wcc)).XdrSize()
+	return
+}
+func (v *LINK3resok) Equal(o *LINK3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).File_attributes)).Equal((*Post_op_attr)(&((o).File_attributes))) {
+		return false
+	}
+	if !(*Wcc_data)(&((v).Linkdir_wcc)).Equal((*Wcc_data)(&((o).Linkdir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *LINK3resok) Clone() (c LINK3resok) {
+	*(*Post_op_attr)(&((&c).File_attributes)) = (*Post_op_attr)(&((v).File_attributes)).Clone()
+	*(*Wcc_data)(&((&c).Linkdir_wcc)) = (*Wcc_data)(&((v).Linkdir_wcc)).Clone()
+	return
+}
 func (v *LINK3resfail) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).File_attributes)).Xdr(xs)
 	(*Wcc_data)(&((v).Linkdir_wcc)).Xdr(xs)
 }
+func (v *LINK3resfail) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).File_attributes)).XdrSize()
+	n += (*Wcc_data)(&((v).Linkdir_wcc)).XdrSize()
+	return
+}
+func (v *LINK3resfail) Equal(o *LINK3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).File_attributes)).Equal((*Post_op_attr)(&((o).File_attributes))) {
+		return false
+	}
+	if !(*Wcc_data)(&((v).Linkdir_wcc)).Equal((*Wcc_data)(&((o).Linkdir_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *LINK3resfail) Clone() (c LINK3resfail) {
+	*(*Post_op_attr)(&((&c).File_attributes)) = (*Post_op_attr)(&((v).File_attributes)).Clone()
+	*(*Wcc_data)(&((&c).Linkdir_wcc)) = (*Wcc_data)(&((v).Linkdir_wcc)).Clone()
+	return
+}
 func (v *LINK3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -927,30 +3737,140 @@ func (v *LINK3res) Xdr(xs *xdr.XdrState) {
 		(*LINK3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *LINK3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*LINK3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*LINK3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *LINK3res) Equal(o *LINK3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*LINK3resok)(&((v).Resok)).Equal((*LINK3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*LINK3resfail)(&((v).Resfail)).Equal((*LINK3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *LINK3res) Clone() (c LINK3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*LINK3resok)(&((&c).Resok)) = (*LINK3resok)(&((v).Resok)).Clone()
+	default:
+		*(*LINK3resfail)(&((&c).Resfail)) = (*LINK3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *READDIR3args) Xdr(xs *xdr.XdrState) {
 	(*Nfs_fh3)(&((v).Dir)).Xdr(xs)
 	(*Cookie3)(&((v).Cookie)).Xdr(xs)
 	(*Cookieverf3)(&((v).Cookieverf)).Xdr(xs)
 	(*Count3)(&((v).Count)).Xdr(xs)
 }
+func (v *READDIR3args) XdrSize() (n int) {
+	n += (*Nfs_fh3)(&((v).Dir)).XdrSize()
+	n += 8
+	n += 8
+	n += 4
+	return
+}
+func (v *READDIR3args) Equal(o *READDIR3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfs_fh3)(&((v).Dir)).Equal((*Nfs_fh3)(&((o).Dir))) {
+		return false
+	}
+	if !(*Cookie3)(&((v).Cookie)).Equal((*Cookie3)(&((o).Cookie))) {
+		return false
+	}
+	if !(*Cookieverf3)(&((v).Cookieverf)).Equal((*Cookieverf3)(&((o).Cookieverf))) {
+		return false
+	}
+	if !(*Count3)(&((v).Count)).Equal((*Count3)(&((o).Count))) {
+		return false
+	}
+	return true
+}
+func (v *READDIR3args) Clone() (c READDIR3args) {
+	*(*Nfs_fh3)(&((&c).Dir)) = (*Nfs_fh3)(&((v).Dir)).Clone()
+	*(*Cookie3)(&((&c).Cookie)) = (*Cookie3)(&((v).Cookie)).Clone()
+	*(*Cookieverf3)(&((&c).Cookieverf)) = (*Cookieverf3)(&((v).Cookieverf)).Clone()
+	*(*Count3)(&((&c).Count)) = (*Count3)(&((v).Count)).Clone()
+	return
+}
 func (v *Entry3) Xdr(xs *xdr.XdrState) {
-	(*Fileid3)(&((v).Fileid)).Xdr(xs)
-	(*Filename3)(&((v).Name)).Xdr(xs)
-	(*Cookie3)(&((v).Cookie)).Xdr(xs)
-	if xs.Encoding() {
-		opted := *(&((v).Nextentry)) != nil
+	for {
+		(*Fileid3)(&((v).Fileid)).Xdr(xs)
+		(*Filename3)(&((v).Name)).Xdr(xs)
+		(*Cookie3)(&((v).Cookie)).Xdr(xs)
+		var opted bool
+		if xs.Encoding() {
+			opted = v.Nextentry != nil
+		}
 		xdr.XdrBool(xs, (*bool)(&opted))
-		if opted {
-			(*Entry3)(*(&((v).Nextentry))).Xdr(xs)
+		if !opted || xs.Error() != nil {
+			return
+		}
+		if xs.Decoding() {
+			v.Nextentry = new(Entry3)
 		}
+		v = v.Nextentry
 	}
-	if xs.Decoding() {
-		var opted bool
-		xdr.XdrBool(xs, (*bool)(&opted))
-		if opted {
-			*(&((v).Nextentry)) = new(Entry3)
-			(*Entry3)(*(&((v).Nextentry))).Xdr(xs)
+}
+func (v *Entry3) XdrSize() (n int) {
+	for v != nil {
+		n += 8
+		n += (*Filename3)(&((v).Name)).XdrSize()
+		n += 8
+		n += 4
+		v = v.Nextentry
+	}
+	return
+}
+func (v *Entry3) Equal(o *Entry3) bool {
+	for {
+		if v == nil || o == nil {
+			return v == o
+		}
+		if !(*Fileid3)(&((v).Fileid)).Equal((*Fileid3)(&((o).Fileid))) {
+			return false
+		}
+		if !(*Filename3)(&((v).Name)).Equal((*Filename3)(&((o).Name))) {
+			return false
+		}
+		if !(*Cookie3)(&((v).Cookie)).Equal((*Cookie3)(&((o).Cookie))) {
+			return false
+		}
+		v, o = v.Nextentry, o.Nextentry
+	}
+}
+func (v *Entry3) Clone() (c Entry3) {
+	d := &c
+	for {
+		*(*Fileid3)(&((d).Fileid)) = (*Fileid3)(&((v).Fileid)).Clone()
+		*(*Filename3)(&((d).Name)) = (*Filename3)(&((v).Name)).Clone()
+		*(*Cookie3)(&((d).Cookie)) = (*Cookie3)(&((v).Cookie)).Clone()
+		if v.Nextentry == nil {
+			return
 		}
+		d.Nextentry = new(Entry3)
+		d, v = d.Nextentry, v.Nextentry
 	}
 }
 func (v *Dirlist3) Xdr(xs *xdr.XdrState) {
@@ -971,14 +3891,91 @@ func (v *Dirlist3) Xdr(xs *xdr.XdrState) {
 	}
 	xdr.XdrBool(xs, (*bool)(&((v).Eof)))
 }
+func (v *Dirlist3) XdrSize() (n int) {
+	n += 4
+	if *(&((v).Entries)) != nil {
+		n += (*Entry3)(*(&((v).Entries))).XdrSize()
+	}
+	n += 4
+	return
+}
+func (v *Dirlist3) Equal(o *Dirlist3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if (*(&((v).Entries)) == nil) != (*(&((o).Entries)) == nil) {
+		return false
+	}
+	if *(&((v).Entries)) != nil {
+		if !(*Entry3)(*(&((v).Entries))).Equal((*Entry3)(*(&((o).Entries)))) {
+			return false
+		}
+	}
+	if *(&((v).Eof)) != *(&((o).Eof)) {
+		return false
+	}
+	return true
+}
+func (v *Dirlist3) Clone() (c Dirlist3) {
+	if *(&((v).Entries)) != nil {
+		*(&((&c).Entries)) = new(Entry3)
+		*(*Entry3)(*(&((&c).Entries))) = (*Entry3)(*(&((v).Entries))).Clone()
+	}
+	*(&((&c).Eof)) = *(&((v).Eof))
+	return
+}
 func (v *READDIR3resok) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).Dir_attributes)).Xdr(xs)
 	(*Cookieverf3)(&((v).Cookieverf)).Xdr(xs)
 	(*Dirlist3)(&((v).Reply)).Xdr(xs)
 }
+func (v *READDIR3resok) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).Dir_attributes)).XdrSize()
+	n += 8
+	n += (*Dirlist3)(&((v).Reply)).XdrSize()
+	return
+}
+func (v *READDIR3resok) Equal(o *READDIR3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).Dir_attributes)).Equal((*Post_op_attr)(&((o).Dir_attributes))) {
+		return false
+	}
+	if !(*Cookieverf3)(&((v).Cookieverf)).Equal((*Cookieverf3)(&((o).Cookieverf))) {
+		return false
+	}
+	if !(*Dirlist3)(&((v).Reply)).Equal((*Dirlist3)(&((o).Reply))) {
+		return false
+	}
+	return true
+}
+func (v *READDIR3resok) Clone() (c READDIR3resok) {
+	*(*Post_op_attr)(&((&c).Dir_attributes)) = (*Post_op_attr)(&((v).Dir_attributes)).Clone()
+	*(*Cookieverf3)(&((&c).Cookieverf)) = (*Cookieverf3)(&((v).Cookieverf)).Clone()
+	*(*Dirlist3)(&((&c).Reply)) = (*Dirlist3)(&((v).Reply)).Clone()
+	return
+}
 func (v *READDIR3resfail) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).Dir_attributes)).Xdr(xs)
 }
+func (v *READDIR3resfail) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).Dir_attributes)).XdrSize()
+	return
+}
+func (v *READDIR3resfail) Equal(o *READDIR3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).Dir_attributes)).Equal((*Post_op_attr)(&((o).Dir_attributes))) {
+		return false
+	}
+	return true
+}
+func (v *READDIR3resfail) Clone() (c READDIR3resfail) {
+	*(*Post_op_attr)(&((&c).Dir_attributes)) = (*Post_op_attr)(&((v).Dir_attributes)).Clone()
+	return
+}
 func (v *READDIR3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -988,6 +3985,45 @@ func (v *READDIR3res) Xdr(xs *xdr.XdrState) {
 		(*READDIR3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *READDIR3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*READDIR3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*READDIR3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *READDIR3res) Equal(o *READDIR3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*READDIR3resok)(&((v).Resok)).Equal((*READDIR3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*READDIR3resfail)(&((v).Resfail)).Equal((*READDIR3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *READDIR3res) Clone() (c READDIR3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*READDIR3resok)(&((&c).Resok)) = (*READDIR3resok)(&((v).Resok)).Clone()
+	default:
+		*(*READDIR3resfail)(&((&c).Resfail)) = (*READDIR3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *READDIRPLUS3args) Xdr(xs *xdr.XdrState) {
 	(*Nfs_fh3)(&((v).Dir)).Xdr(xs)
 	(*Cookie3)(&((v).Cookie)).Xdr(xs)
@@ -995,26 +4031,112 @@ func (v *READDIRPLUS3args) Xdr(xs *xdr.XdrState) {
 	(*Count3)(&((v).Dircount)).Xdr(xs)
 	(*Count3)(&((v).Maxcount)).Xdr(xs)
 }
+func (v *READDIRPLUS3args) XdrSize() (n int) {
+	n += (*Nfs_fh3)(&((v).Dir)).XdrSize()
+	n += 8
+	n += 8
+	n += 4
+	n += 4
+	return
+}
+func (v *READDIRPLUS3args) Equal(o *READDIRPLUS3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfs_fh3)(&((v).Dir)).Equal((*Nfs_fh3)(&((o).Dir))) {
+		return false
+	}
+	if !(*Cookie3)(&((v).Cookie)).Equal((*Cookie3)(&((o).Cookie))) {
+		return false
+	}
+	if !(*Cookieverf3)(&((v).Cookieverf)).Equal((*Cookieverf3)(&((o).Cookieverf))) {
+		return false
+	}
+	if !(*Count3)(&((v).Dircount)).Equal((*Count3)(&((o).Dircount))) {
+		return false
+	}
+	if !(*Count3)(&((v).Maxcount)).Equal((*Count3)(&((o).Maxcount))) {
+		return false
+	}
+	return true
+}
+func (v *READDIRPLUS3args) Clone() (c READDIRPLUS3args) {
+	*(*Nfs_fh3)(&((&c).Dir)) = (*Nfs_fh3)(&((v).Dir)).Clone()
+	*(*Cookie3)(&((&c).Cookie)) = (*Cookie3)(&((v).Cookie)).Clone()
+	*(*Cookieverf3)(&((&c).Cookieverf)) = (*Cookieverf3)(&((v).Cookieverf)).Clone()
+	*(*Count3)(&((&c).Dircount)) = (*Count3)(&((v).Dircount)).Clone()
+	*(*Count3)(&((&c).Maxcount)) = (*Count3)(&((v).Maxcount)).Clone()
+	return
+}
 func (v *Entryplus3) Xdr(xs *xdr.XdrState) {
-	(*Fileid3)(&((v).Fileid)).Xdr(xs)
-	(*Filename3)(&((v).Name)).Xdr(xs)
-	(*Cookie3)(&((v).Cookie)).Xdr(xs)
-	(*Post_op_attr)(&((v).Name_attributes)).Xdr(xs)
-	(*Post_op_fh3)(&((v).Name_handle)).Xdr(xs)
-	if xs.Encoding() {
-		opted := *(&((v).Nextentry)) != nil
+	for {
+		(*Fileid3)(&((v).Fileid)).Xdr(xs)
+		(*Filename3)(&((v).Name)).Xdr(xs)
+		(*Cookie3)(&((v).Cookie)).Xdr(xs)
+		(*Post_op_attr)(&((v).Name_attributes)).Xdr(xs)
+		(*Post_op_fh3)(&((v).Name_handle)).Xdr(xs)
+		var opted bool
+		if xs.Encoding() {
+			opted = v.Nextentry != nil
+		}
 		xdr.XdrBool(xs, (*bool)(&opted))
-		if opted {
-			(*Entryplus3)(*(&((v).Nextentry))).Xdr(xs)
+		if !opted || xs.Error() != nil {
+			return
+		}
+		if xs.Decoding() {
+			v.Nextentry = new(Entryplus3)
 		}
+		v = v.Nextentry
 	}
-	if xs.Decoding() {
-		var opted bool
-		xdr.XdrBool(xs, (*bool)(&opted))
-		if opted {
-			*(&((v).Nextentry)) = new(Entryplus3)
-			(*Entryplus3)(*(&((v).Nextentry))).Xdr(xs)
+}
+func (v *Entryplus3) XdrSize() (n int) {
+	for v != nil {
+		n += 8
+		n += (*Filename3)(&((v).Name)).XdrSize()
+		n += 8
+		n += (*Post_op_attr)(&((v).Name_attributes)).XdrSize()
+		n += (*Post_op_fh3)(&((v).Name_handle)).XdrSize()
+		n += 4
+		v = v.Nextentry
+	}
+	return
+}
+func (v *Entryplus3) Equal(o *Entryplus3) bool {
+	for {
+		if v == nil || o == nil {
+			return v == o
+		}
+		if !(*Fileid3)(&((v).Fileid)).Equal((*Fileid3)(&((o).Fileid))) {
+			return false
+		}
+		if !(*Filename3)(&((v).Name)).Equal((*Filename3)(&((o).Name))) {
+			return false
+		}
+		if !(*Cookie3)(&((v).Cookie)).Equal((*Cookie3)(&((o).Cookie))) {
+			return false
+		}
+		if !(*Post_op_attr)(&((v).Name_attributes)).Equal((*Post_op_attr)(&((o).Name_attributes))) {
+			return false
+		}
+		if !(*Post_op_fh3)(&((v).Name_handle)).Equal((*Post_op_fh3)(&((o).Name_handle))) {
+			return false
 		}
+		v, o = v.Nextentry, o.Nextentry
+	}
+}
+func (v *Entryplus3) Clone() (c Entryplus3) {
+	d := &c
+	for {
+		*(*Fileid3)(&((d).Fileid)) = (*Fileid3)(&((v).Fileid)).Clone()
+		*(*Filename3)(&((d).Name)) = (*Filename3)(&((v).Name)).Clone()
+		*(*Cookie3)(&((d).Cookie)) = (*Cookie3)(&((v).Cookie)).Clone()
+		*(*Post_op_attr)(&((d).Name_attributes)) = (*Post_op_attr)(&((v).Name_attributes)).Clone()
+		*(*Post_op_fh3)(&((d).Name_handle)) = (*Post_op_fh3)(&((v).Name_handle)).Clone()
+		if v.Nextentry == nil {
+			return
+		}
+		d.Nextentry = new(Entryplus3)
+		d, v = d.Nextentry, v.Nextentry
 	}
 }
 func (v *Dirlistplus3) Xdr(xs *xdr.XdrState) {
@@ -1035,14 +4157,91 @@ func (v *Dirlistplus3) Xdr(xs *xdr.XdrState) {
 	}
 	xdr.XdrBool(xs, (*bool)(&((v).Eof)))
 }
+func (v *Dirlistplus3) XdrSize() (n int) {
+	n += 4
+	if *(&((v).Entries)) != nil {
+		n += (*Entryplus3)(*(&((v).Entries))).XdrSize()
+	}
+	n += 4
+	return
+}
+func (v *Dirlistplus3) Equal(o *Dirlistplus3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if (*(&((v).Entries)) == nil) != (*(&((o).Entries)) == nil) {
+		return false
+	}
+	if *(&((v).Entries)) != nil {
+		if !(*Entryplus3)(*(&((v).Entries))).Equal((*Entryplus3)(*(&((o).Entries)))) {
+			return false
+		}
+	}
+	if *(&((v).Eof)) != *(&((o).Eof)) {
+		return false
+	}
+	return true
+}
+func (v *Dirlistplus3) Clone() (c Dirlistplus3) {
+	if *(&((v).Entries)) != nil {
+		*(&((&c).Entries)) = new(Entryplus3)
+		*(*Entryplus3)(*(&((&c).Entries))) = (*Entryplus3)(*(&((v).Entries))).Clone()
+	}
+	*(&((&c).Eof)) = *(&((v).Eof))
+	return
+}
 func (v *READDIRPLUS3resok) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).Dir_attributes)).Xdr(xs)
 	(*Cookieverf3)(&((v).Cookieverf)).Xdr(xs)
 	(*Dirlistplus3)(&((v).Reply)).Xdr(xs)
 }
+func (v *READDIRPLUS3resok) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).Dir_attributes)).XdrSize()
+	n += 8
+	n += (*Dirlistplus3)(&((v).Reply)).XdrSize()
+	return
+}
+func (v *READDIRPLUS3resok) Equal(o *READDIRPLUS3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).Dir_attributes)).Equal((*Post_op_attr)(&((o).Dir_attributes))) {
+		return false
+	}
+	if !(*Cookieverf3)(&((v).Cookieverf)).Equal((*Cookieverf3)(&((o).Cookieverf))) {
+		return false
+	}
+	if !(*Dirlistplus3)(&((v).Reply)).Equal((*Dirlistplus3)(&((o).Reply))) {
+		return false
+	}
+	return true
+}
+func (v *READDIRPLUS3resok) Clone() (c READDIRPLUS3resok) {
+	*(*Post_op_attr)(&((&c).Dir_attributes)) = (*Post_op_attr)(&((v).Dir_attributes)).Clone()
+	*(*Cookieverf3)(&((&c).Cookieverf)) = (*Cookieverf3)(&((v).Cookieverf)).Clone()
+	*(*Dirlistplus3)(&((&c).Reply)) = (*Dirlistplus3)(&((v).Reply)).Clone()
+	return
+}
 func (v *READDIRPLUS3resfail) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).Dir_attributes)).Xdr(xs)
 }
+func (v *READDIRPLUS3resfail) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).Dir_attributes)).XdrSize()
+	return
+}
+func (v *READDIRPLUS3resfail) Equal(o *READDIRPLUS3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).Dir_attributes)).Equal((*Post_op_attr)(&((o).Dir_attributes))) {
+		return false
+	}
+	return true
+}
+func (v *READDIRPLUS3resfail) Clone() (c READDIRPLUS3resfail) {
+	*(*Post_op_attr)(&((&c).Dir_attributes)) = (*Post_op_attr)(&((v).Dir_attributes)).Clone()
+	return
+}
 func (v *READDIRPLUS3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -1052,9 +4251,65 @@ func (v *READDIRPLUS3res) Xdr(xs *xdr.XdrState) {
 		(*READDIRPLUS3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *READDIRPLUS3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*READDIRPLUS3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*READDIRPLUS3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *READDIRPLUS3res) Equal(o *READDIRPLUS3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*READDIRPLUS3resok)(&((v).Resok)).Equal((*READDIRPLUS3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*READDIRPLUS3resfail)(&((v).Resfail)).Equal((*READDIRPLUS3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *READDIRPLUS3res) Clone() (c READDIRPLUS3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*READDIRPLUS3resok)(&((&c).Resok)) = (*READDIRPLUS3resok)(&((v).Resok)).Clone()
+	default:
+		*(*READDIRPLUS3resfail)(&((&c).Resfail)) = (*READDIRPLUS3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *FSSTAT3args) Xdr(xs *xdr.XdrState) {
 	(*Nfs_fh3)(&((v).Fsroot)).Xdr(xs)
 }
+func (v *FSSTAT3args) XdrSize() (n int) {
+	n += (*Nfs_fh3)(&((v).Fsroot)).XdrSize()
+	return
+}
+func (v *FSSTAT3args) Equal(o *FSSTAT3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfs_fh3)(&((v).Fsroot)).Equal((*Nfs_fh3)(&((o).Fsroot))) {
+		return false
+	}
+	return true
+}
+func (v *FSSTAT3args) Clone() (c FSSTAT3args) {
+	*(*Nfs_fh3)(&((&c).Fsroot)) = (*Nfs_fh3)(&((v).Fsroot)).Clone()
+	return
+}
 func (v *FSSTAT3resok) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).Obj_attributes)).Xdr(xs)
 	(*Size3)(&((v).Tbytes)).Xdr(xs)
@@ -1065,9 +4320,78 @@ func (v *FSSTAT3resok) Xdr(xs *xdr.XdrState) {
 	(*Size3)(&((v).Afiles)).Xdr(xs)
 	(*Uint32)(&((v).Invarsec)).Xdr(xs)
 }
+func (v *FSSTAT3resok) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).Obj_attributes)).XdrSize()
+	n += 8
+	n += 8
+	n += 8
+	n += 8
+	n += 8
+	n += 8
+	n += 4
+	return
+}
+func (v *FSSTAT3resok) Equal(o *FSSTAT3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).Obj_attributes)).Equal((*Post_op_attr)(&((o).Obj_attributes))) {
+		return false
+	}
+	if !(*Size3)(&((v).Tbytes)).Equal((*Size3)(&((o).Tbytes))) {
+		return false
+	}
+	if !(*Size3)(&((v).Fbytes)).Equal((*Size3)(&((o).Fbytes))) {
+		return false
+	}
+	if !(*Size3)(&((v).Abytes)).Equal((*Size3)(&((o).Abytes))) {
+		return false
+	}
+	if !(*Size3)(&((v).Tfiles)).Equal((*Size3)(&((o).Tfiles))) {
+		return false
+	}
+	if !(*Size3)(&((v).Ffiles)).Equal((*Size3)(&((o).Ffiles))) {
+		return false
+	}
+	if !(*Size3)(&((v).Afiles)).Equal((*Size3)(&((o).Afiles))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Invarsec)).Equal((*Uint32)(&((o).Invarsec))) {
+		return false
+	}
+	return true
+}
+func (v *FSSTAT3resok) Clone() (c FSSTAT3resok) {
+	*(*Post_op_attr)(&((&c).Obj_attributes)) = (*Post_op_attr)(&((v).Obj_attributes)).Clone()
+	*(*Size3)(&((&c).Tbytes)) = (*Size3)(&((v).Tbytes)).Clone()
+	*(*Size3)(&((&c).Fbytes)) = (*Size3)(&((v).Fbytes)).Clone()
+	*(*Size3)(&((&c).Abytes)) = (*Size3)(&((v).Abytes)).Clone()
+	*(*Size3)(&((&c).Tfiles)) = (*Size3)(&((v).Tfiles)).Clone()
+	*(*Size3)(&((&c).Ffiles)) = (*Size3)(&((v).Ffiles)).Clone()
+	*(*Size3)(&((&c).Afiles)) = (*Size3)(&((v).Afiles)).Clone()
+	*(*Uint32)(&((&c).Invarsec)) = (*Uint32)(&((v).Invarsec)).Clone()
+	return
+}
 func (v *FSSTAT3resfail) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).Obj_attributes)).Xdr(xs)
 }
+func (v *FSSTAT3resfail) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).Obj_attributes)).XdrSize()
+	return
+}
+func (v *FSSTAT3resfail) Equal(o *FSSTAT3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).Obj_attributes)).Equal((*Post_op_attr)(&((o).Obj_attributes))) {
+		return false
+	}
+	return true
+}
+func (v *FSSTAT3resfail) Clone() (c FSSTAT3resfail) {
+	*(*Post_op_attr)(&((&c).Obj_attributes)) = (*Post_op_attr)(&((v).Obj_attributes)).Clone()
+	return
+}
 func (v *FSSTAT3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -1077,9 +4401,65 @@ func (v *FSSTAT3res) Xdr(xs *xdr.XdrState) {
 		(*FSSTAT3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *FSSTAT3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*FSSTAT3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*FSSTAT3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *FSSTAT3res) Equal(o *FSSTAT3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*FSSTAT3resok)(&((v).Resok)).Equal((*FSSTAT3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*FSSTAT3resfail)(&((v).Resfail)).Equal((*FSSTAT3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *FSSTAT3res) Clone() (c FSSTAT3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*FSSTAT3resok)(&((&c).Resok)) = (*FSSTAT3resok)(&((v).Resok)).Clone()
+	default:
+		*(*FSSTAT3resfail)(&((&c).Resfail)) = (*FSSTAT3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *FSINFO3args) Xdr(xs *xdr.XdrState) {
 	(*Nfs_fh3)(&((v).Fsroot)).Xdr(xs)
 }
+func (v *FSINFO3args) XdrSize() (n int) {
+	n += (*Nfs_fh3)(&((v).Fsroot)).XdrSize()
+	return
+}
+func (v *FSINFO3args) Equal(o *FSINFO3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfs_fh3)(&((v).Fsroot)).Equal((*Nfs_fh3)(&((o).Fsroot))) {
+		return false
+	}
+	return true
+}
+func (v *FSINFO3args) Clone() (c FSINFO3args) {
+	*(*Nfs_fh3)(&((&c).Fsroot)) = (*Nfs_fh3)(&((v).Fsroot)).Clone()
+	return
+}
 func (v *FSINFO3resok) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).Obj_attributes)).Xdr(xs)
 	(*Uint32)(&((v).Rtmax)).Xdr(xs)
@@ -1093,9 +4473,93 @@ func (v *FSINFO3resok) Xdr(xs *xdr.XdrState) {
 	(*Nfstime3)(&((v).Time_delta)).Xdr(xs)
 	(*Uint32)(&((v).Properties)).Xdr(xs)
 }
+func (v *FSINFO3resok) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).Obj_attributes)).XdrSize()
+	n += 4
+	n += 4
+	n += 4
+	n += 4
+	n += 4
+	n += 4
+	n += 4
+	n += 8
+	n += 8
+	n += 4
+	return
+}
+func (v *FSINFO3resok) Equal(o *FSINFO3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).Obj_attributes)).Equal((*Post_op_attr)(&((o).Obj_attributes))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Rtmax)).Equal((*Uint32)(&((o).Rtmax))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Rtpref)).Equal((*Uint32)(&((o).Rtpref))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Rtmult)).Equal((*Uint32)(&((o).Rtmult))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Wtmax)).Equal((*Uint32)(&((o).Wtmax))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Wtpref)).Equal((*Uint32)(&((o).Wtpref))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Wtmult)).Equal((*Uint32)(&((o).Wtmult))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Dtpref)).Equal((*Uint32)(&((o).Dtpref))) {
+		return false
+	}
+	if !(*Size3)(&((v).Maxfilesize)).Equal((*Size3)(&((o).Maxfilesize))) {
+		return false
+	}
+	if !(*Nfstime3)(&((v).Time_delta)).Equal((*Nfstime3)(&((o).Time_delta))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Properties)).Equal((*Uint32)(&((o).Properties))) {
+		return false
+	}
+	return true
+}
+func (v *FSINFO3resok) Clone() (c FSINFO3resok) {
+	*(*Post_op_attr)(&((&c).Obj_attributes)) = (*Post_op_attr)(&((v).Obj_attributes)).Clone()
+	*(*Uint32)(&((&c).Rtmax)) = (*Uint32)(&((v).Rtmax)).Clone()
+	*(*Uint32)(&((&c).Rtpref)) = (*Uint32)(&((v).Rtpref)).Clone()
+	*(*Uint32)(&((&c).Rtmult)) = (*Uint32)(&((v).Rtmult)).Clone()
+	*(*Uint32)(&((&c).Wtmax)) = (*Uint32)(&((v).Wtmax)).Clone()
+	*(*Uint32)(&((&c).Wtpref)) = (*Uint32)(&((v).Wtpref)).Clone()
+	*(*Uint32)(&((&c).Wtmult)) = (*Uint32)(&((v).Wtmult)).Clone()
+	*(*Uint32)(&((&c).Dtpref)) = (*Uint32)(&((v).Dtpref)).Clone()
+	*(*Size3)(&((&c).Maxfilesize)) = (*Size3)(&((v).Maxfilesize)).Clone()
+	*(*Nfstime3)(&((&c).Time_delta)) = (*Nfstime3)(&((v).Time_delta)).Clone()
+	*(*Uint32)(&((&c).Properties)) = (*Uint32)(&((v).Properties)).Clone()
+	return
+}
 func (v *FSINFO3resfail) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).Obj_attributes)).Xdr(xs)
 }
+func (v *FSINFO3resfail) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).Obj_attributes)).XdrSize()
+	return
+}
+func (v *FSINFO3resfail) Equal(o *FSINFO3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).Obj_attributes)).Equal((*Post_op_attr)(&((o).Obj_attributes))) {
+		return false
+	}
+	return true
+}
+func (v *FSINFO3resfail) Clone() (c FSINFO3resfail) {
+	*(*Post_op_attr)(&((&c).Obj_attributes)) = (*Post_op_attr)(&((v).Obj_attributes)).Clone()
+	return
+}
 func (v *FSINFO3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -1105,9 +4569,65 @@ func (v *FSINFO3res) Xdr(xs *xdr.XdrState) {
 		(*FSINFO3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *FSINFO3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*FSINFO3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*FSINFO3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *FSINFO3res) Equal(o *FSINFO3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*FSINFO3resok)(&((v).Resok)).Equal((*FSINFO3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*FSINFO3resfail)(&((v).Resfail)).Equal((*FSINFO3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *FSINFO3res) Clone() (c FSINFO3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*FSINFO3resok)(&((&c).Resok)) = (*FSINFO3resok)(&((v).Resok)).Clone()
+	default:
+		*(*FSINFO3resfail)(&((&c).Resfail)) = (*FSINFO3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *PATHCONF3args) Xdr(xs *xdr.XdrState) {
 	(*Nfs_fh3)(&((v).Object)).Xdr(xs)
 }
+func (v *PATHCONF3args) XdrSize() (n int) {
+	n += (*Nfs_fh3)(&((v).Object)).XdrSize()
+	return
+}
+func (v *PATHCONF3args) Equal(o *PATHCONF3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfs_fh3)(&((v).Object)).Equal((*Nfs_fh3)(&((o).Object))) {
+		return false
+	}
+	return true
+}
+func (v *PATHCONF3args) Clone() (c PATHCONF3args) {
+	*(*Nfs_fh3)(&((&c).Object)) = (*Nfs_fh3)(&((v).Object)).Clone()
+	return
+}
 func (v *PATHCONF3resok) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).Obj_attributes)).Xdr(xs)
 	(*Uint32)(&((v).Linkmax)).Xdr(xs)
@@ -1117,9 +4637,73 @@ func (v *PATHCONF3resok) Xdr(xs *xdr.XdrState) {
 	xdr.XdrBool(xs, (*bool)(&((v).Case_insensitive)))
 	xdr.XdrBool(xs, (*bool)(&((v).Case_preserving)))
 }
+func (v *PATHCONF3resok) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).Obj_attributes)).XdrSize()
+	n += 4
+	n += 4
+	n += 4
+	n += 4
+	n += 4
+	n += 4
+	return
+}
+func (v *PATHCONF3resok) Equal(o *PATHCONF3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).Obj_attributes)).Equal((*Post_op_attr)(&((o).Obj_attributes))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Linkmax)).Equal((*Uint32)(&((o).Linkmax))) {
+		return false
+	}
+	if !(*Uint32)(&((v).Name_max)).Equal((*Uint32)(&((o).Name_max))) {
+		return false
+	}
+	if *(&((v).No_trunc)) != *(&((o).No_trunc)) {
+		return false
+	}
+	if *(&((v).Chown_restricted)) != *(&((o).Chown_restricted)) {
+		return false
+	}
+	if *(&((v).Case_insensitive)) != *(&((o).Case_insensitive)) {
+		return false
+	}
+	if *(&((v).Case_preserving)) != *(&((o).Case_preserving)) {
+		return false
+	}
+	return true
+}
+func (v *PATHCONF3resok) Clone() (c PATHCONF3resok) {
+	*(*Post_op_attr)(&((&c).Obj_attributes)) = (*Post_op_attr)(&((v).Obj_attributes)).Clone()
+	*(*Uint32)(&((&c).Linkmax)) = (*Uint32)(&((v).Linkmax)).Clone()
+	*(*Uint32)(&((&c).Name_max)) = (*Uint32)(&((v).Name_max)).Clone()
+	*(&((&c).No_trunc)) = *(&((v).No_trunc))
+	*(&((&c).Chown_restricted)) = *(&((v).Chown_restricted))
+	*(&((&c).Case_insensitive)) = *(&((v).Case_insensitive))
+	*(&((&c).Case_preserving)) = *(&((v).Case_preserving))
+	return
+}
 func (v *PATHCONF3resfail) Xdr(xs *xdr.XdrState) {
 	(*Post_op_attr)(&((v).Obj_attributes)).Xdr(xs)
 }
+func (v *PATHCONF3resfail) XdrSize() (n int) {
+	n += (*Post_op_attr)(&((v).Obj_attributes)).XdrSize()
+	return
+}
+func (v *PATHCONF3resfail) Equal(o *PATHCONF3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Post_op_attr)(&((v).Obj_attributes)).Equal((*Post_op_attr)(&((o).Obj_attributes))) {
+		return false
+	}
+	return true
+}
+func (v *PATHCONF3resfail) Clone() (c PATHCONF3resfail) {
+	*(*Post_op_attr)(&((&c).Obj_attributes)) = (*Post_op_attr)(&((v).Obj_attributes)).Clone()
+	return
+}
 func (v *PATHCONF3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -1129,18 +4713,123 @@ func (v *PATHCONF3res) Xdr(xs *xdr.XdrState) {
 		(*PATHCONF3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *PATHCONF3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*PATHCONF3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*PATHCONF3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *PATHCONF3res) Equal(o *PATHCONF3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*PATHCONF3resok)(&((v).Resok)).Equal((*PATHCONF3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*PATHCONF3resfail)(&((v).Resfail)).Equal((*PATHCONF3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *PATHCONF3res) Clone() (c PATHCONF3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*PATHCONF3resok)(&((&c).Resok)) = (*PATHCONF3resok)(&((v).Resok)).Clone()
+	default:
+		*(*PATHCONF3resfail)(&((&c).Resfail)) = (*PATHCONF3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *COMMIT3args) Xdr(xs *xdr.XdrState) {
 	(*Nfs_fh3)(&((v).File)).Xdr(xs)
 	(*Offset3)(&((v).Offset)).Xdr(xs)
 	(*Count3)(&((v).Count)).Xdr(xs)
 }
+func (v *COMMIT3args) XdrSize() (n int) {
+	n += (*Nfs_fh3)(&((v).File)).XdrSize()
+	n += 8
+	n += 4
+	return
+}
+func (v *COMMIT3args) Equal(o *COMMIT3args) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfs_fh3)(&((v).File)).Equal((*Nfs_fh3)(&((o).File))) {
+		return false
+	}
+	if !(*Offset3)(&((v).Offset)).Equal((*Offset3)(&((o).Offset))) {
+		return false
+	}
+	if !(*Count3)(&((v).Count)).Equal((*Count3)(&((o).Count))) {
+		return false
+	}
+	return true
+}
+func (v *COMMIT3args) Clone() (c COMMIT3args) {
+	*(*Nfs_fh3)(&((&c).File)) = (*Nfs_fh3)(&((v).File)).Clone()
+	*(*Offset3)(&((&c).Offset)) = (*Offset3)(&((v).Offset)).Clone()
+	*(*Count3)(&((&c).Count)) = (*Count3)(&((v).Count)).Clone()
+	return
+}
 func (v *COMMIT3resok) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).File_wcc)).Xdr(xs)
 	(*Writeverf3)(&((v).Verf)).Xdr(xs)
 }
+func (v *COMMIT3resok) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).File_wcc)).XdrSize()
+	n += 8
+	return
+}
+func (v *COMMIT3resok) Equal(o *COMMIT3resok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).File_wcc)).Equal((*Wcc_data)(&((o).File_wcc))) {
+		return false
+	}
+	if !(*Writeverf3)(&((v).Verf)).Equal((*Writeverf3)(&((o).Verf))) {
+		return false
+	}
+	return true
+}
+func (v *COMMIT3resok) Clone() (c COMMIT3resok) {
+	*(*Wcc_data)(&((&c).File_wcc)) = (*Wcc_data)(&((v).File_wcc)).Clone()
+	*(*Writeverf3)(&((&c).Verf)) = (*Writeverf3)(&((v).Verf)).Clone()
+	return
+}
 func (v *COMMIT3resfail) Xdr(xs *xdr.XdrState) {
 	(*Wcc_data)(&((v).File_wcc)).Xdr(xs)
 }
+func (v *COMMIT3resfail) XdrSize() (n int) {
+	n += (*Wcc_data)(&((v).File_wcc)).XdrSize()
+	return
+}
+func (v *COMMIT3resfail) Equal(o *COMMIT3resfail) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Wcc_data)(&((v).File_wcc)).Equal((*Wcc_data)(&((o).File_wcc))) {
+		return false
+	}
+	return true
+}
+func (v *COMMIT3resfail) Clone() (c COMMIT3resfail) {
+	*(*Wcc_data)(&((&c).File_wcc)) = (*Wcc_data)(&((v).File_wcc)).Clone()
+	return
+}
 func (v *COMMIT3res) Xdr(xs *xdr.XdrState) {
 	(*Nfsstat3)(&((v).Status)).Xdr(xs)
 	switch (v).Status {
@@ -1150,18 +4839,162 @@ func (v *COMMIT3res) Xdr(xs *xdr.XdrState) {
 		(*COMMIT3resfail)(&((v).Resfail)).Xdr(xs)
 	}
 }
+func (v *COMMIT3res) XdrSize() (n int) {
+	n += 4
+	switch (v).Status {
+	case NFS3_OK:
+		n += (*COMMIT3resok)(&((v).Resok)).XdrSize()
+	default:
+		n += (*COMMIT3resfail)(&((v).Resfail)).XdrSize()
+	}
+	return
+}
+func (v *COMMIT3res) Equal(o *COMMIT3res) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Nfsstat3)(&((v).Status)).Equal((*Nfsstat3)(&((o).Status))) {
+		return false
+	}
+	switch (v).Status {
+	case NFS3_OK:
+		if !(*COMMIT3resok)(&((v).Resok)).Equal((*COMMIT3resok)(&((o).Resok))) {
+			return false
+		}
+	default:
+		if !(*COMMIT3resfail)(&((v).Resfail)).Equal((*COMMIT3resfail)(&((o).Resfail))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *COMMIT3res) Clone() (c COMMIT3res) {
+	*(*Nfsstat3)(&((&c).Status)) = (*Nfsstat3)(&((v).Status)).Clone()
+	switch (v).Status {
+	case NFS3_OK:
+		*(*COMMIT3resok)(&((&c).Resok)) = (*COMMIT3resok)(&((v).Resok)).Clone()
+	default:
+		*(*COMMIT3resfail)(&((&c).Resfail)) = (*COMMIT3resfail)(&((v).Resfail)).Clone()
+	}
+	return
+}
 func (v *Fhandle3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrVarArray(xs, int(FHSIZE3), (*[]byte)(v))
 }
+func (v *Fhandle3) XdrSize() (n int) {
+	n += 4 + (len(*(v))+3)&^3
+	return
+}
+func (v *Fhandle3) Equal(o *Fhandle3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !bytes.Equal(*(v), *(o)) {
+		return false
+	}
+	return true
+}
+func (v *Fhandle3) Clone() (c Fhandle3) {
+	if *(v) != nil {
+		*(&c) = append([]byte{}, *(v)...)
+	}
+	return
+}
 func (v *Dirpath3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrString(xs, int(MNTPATHLEN3), (*string)(v))
 }
+func (v *Dirpath3) XdrSize() (n int) {
+	n += 4 + (len(*(v))+3)&^3
+	return
+}
+func (v *Dirpath3) Equal(o *Dirpath3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Dirpath3) Clone() (c Dirpath3) {
+	*(&c) = *(v)
+	return
+}
 func (v *Name3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrString(xs, int(MNTNAMLEN3), (*string)(v))
 }
+func (v *Name3) XdrSize() (n int) {
+	n += 4 + (len(*(v))+3)&^3
+	return
+}
+func (v *Name3) Equal(o *Name3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Name3) Clone() (c Name3) {
+	*(&c) = *(v)
+	return
+}
+func (v Mountstat3) Valid() bool {
+	return v == MNT3_OK || v == MNT3ERR_PERM || v == MNT3ERR_NOENT || v == MNT3ERR_IO || v == MNT3ERR_ACCES || v == MNT3ERR_NOTDIR || v == MNT3ERR_INVAL || v == MNT3ERR_NAMETOOLONG || v == MNT3ERR_NOTSUPP || v == MNT3ERR_SERVERFAULT
+}
 func (v *Mountstat3) Xdr(xs *xdr.XdrState) {
 	xdr.XdrU32(xs, (*uint32)(v))
 }
+func (v Mountstat3) String() string {
+	if v == MNT3_OK {
+		return "MNT3_OK"
+	}
+	if v == MNT3ERR_PERM {
+		return "MNT3ERR_PERM"
+	}
+	if v == MNT3ERR_NOENT {
+		return "MNT3ERR_NOENT"
+	}
+	if v == MNT3ERR_IO {
+		return "MNT3ERR_IO"
+	}
+	if v == MNT3ERR_ACCES {
+		return "MNT3ERR_ACCES"
+	}
+	if v == MNT3ERR_NOTDIR {
+		return "MNT3ERR_NOTDIR"
+	}
+	if v == MNT3ERR_INVAL {
+		return "MNT3ERR_INVAL"
+	}
+	if v == MNT3ERR_NAMETOOLONG {
+		return "MNT3ERR_NAMETOOLONG"
+	}
+	if v == MNT3ERR_NOTSUPP {
+		return "MNT3ERR_NOTSUPP"
+	}
+	if v == MNT3ERR_SERVERFAULT {
+		return "MNT3ERR_SERVERFAULT"
+	}
+	return fmt.Sprintf("Mountstat3(%d)", v)
+}
+func (v *Mountstat3) XdrSize() int {
+	return Mountstat3_XdrSize
+}
+func (v *Mountstat3) Equal(o *Mountstat3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if *(v) != *(o) {
+		return false
+	}
+	return true
+}
+func (v *Mountstat3) Clone() (c Mountstat3) {
+	*(&c) = *(v)
+	return
+}
 
 type MOUNT_PROGRAM_MOUNT_V3_handler interface {
 	MOUNTPROC3_NULL()
@@ -1258,6 +5091,59 @@ func MOUNT_PROGRAM_MOUNT_V3_regs(h MOUNT_PROGRAM_MOUNT_V3_handler) []xdr.ProcReg
 		},
 	}
 }
+
+type MOUNT_PROGRAM_MOUNT_V3_Client struct {
+	c xdr.ProcCaller
+}
+
+func MakeMOUNT_PROGRAM_MOUNT_V3_Client(c xdr.ProcCaller) *MOUNT_PROGRAM_MOUNT_V3_Client {
+	return &MOUNT_PROGRAM_MOUNT_V3_Client{c}
+}
+func (c *MOUNT_PROGRAM_MOUNT_V3_Client) MOUNTPROC3_NULL(ctx context.Context) error {
+	var res xdr.Void
+	return c.c.CallProc(ctx, MOUNT_PROGRAM, MOUNT_V3, MOUNTPROC3_NULL, &xdr.Void{}, &res)
+}
+func (c *MOUNT_PROGRAM_MOUNT_V3_Client) MOUNTPROC3_MNT(ctx context.Context, arg Dirpath3) (res Mountres3, err error) {
+	err = c.c.CallProc(ctx, MOUNT_PROGRAM, MOUNT_V3, MOUNTPROC3_MNT, &arg, &res)
+	return
+}
+func (c *MOUNT_PROGRAM_MOUNT_V3_Client) MOUNTPROC3_DUMP(ctx context.Context) (res Mountopt3, err error) {
+	err = c.c.CallProc(ctx, MOUNT_PROGRAM, MOUNT_V3, MOUNTPROC3_DUMP, &xdr.Void{}, &res)
+	return
+}
+func (c *MOUNT_PROGRAM_MOUNT_V3_Client) MOUNTPROC3_UMNT(ctx context.Context, arg Dirpath3) error {
+	var res xdr.Void
+	return c.c.CallProc(ctx, MOUNT_PROGRAM, MOUNT_V3, MOUNTPROC3_UMNT, &arg, &res)
+}
+func (c *MOUNT_PROGRAM_MOUNT_V3_Client) MOUNTPROC3_UMNTALL(ctx context.Context) error {
+	var res xdr.Void
+	return c.c.CallProc(ctx, MOUNT_PROGRAM, MOUNT_V3, MOUNTPROC3_UMNTALL, &xdr.Void{}, &res)
+}
+func (c *MOUNT_PROGRAM_MOUNT_V3_Client) MOUNTPROC3_EXPORT(ctx context.Context) (res Exportsopt3, err error) {
+	err = c.c.CallProc(ctx, MOUNT_PROGRAM, MOUNT_V3, MOUNTPROC3_EXPORT, &xdr.Void{}, &res)
+	return
+}
+func MOUNT_PROGRAM_ProcName(vers, proc uint32) string {
+	switch vers {
+	case MOUNT_V3:
+		switch proc {
+		case MOUNTPROC3_NULL:
+			return "MOUNT_PROGRAM/MOUNT_V3/MOUNTPROC3_NULL"
+		case MOUNTPROC3_MNT:
+			return "MOUNT_PROGRAM/MOUNT_V3/MOUNTPROC3_MNT"
+		case MOUNTPROC3_DUMP:
+			return "MOUNT_PROGRAM/MOUNT_V3/MOUNTPROC3_DUMP"
+		case MOUNTPROC3_UMNT:
+			return "MOUNT_PROGRAM/MOUNT_V3/MOUNTPROC3_UMNT"
+		case MOUNTPROC3_UMNTALL:
+			return "MOUNT_PROGRAM/MOUNT_V3/MOUNTPROC3_UMNTALL"
+		case MOUNTPROC3_EXPORT:
+			return "MOUNT_PROGRAM/MOUNT_V3/MOUNTPROC3_EXPORT"
+		}
+		return fmt.Sprintf("MOUNT_PROGRAM/MOUNT_V3/%d", proc)
+	}
+	return fmt.Sprintf("MOUNT_PROGRAM/%d/%d", vers, proc)
+}
 func (v *Mountres3_ok) Xdr(xs *xdr.XdrState) {
 	(*Fhandle3)(&((v).Fhandle)).Xdr(xs)
 	{
@@ -1274,6 +5160,39 @@ func (v *Mountres3_ok) Xdr(xs *xdr.XdrState) {
 		}
 	}
 }
+func (v *Mountres3_ok) XdrSize() (n int) {
+	n += (*Fhandle3)(&((v).Fhandle)).XdrSize()
+	n += 4
+	n += len(*(&((v).Auth_flavors))) * (4)
+	return
+}
+func (v *Mountres3_ok) Equal(o *Mountres3_ok) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Fhandle3)(&((v).Fhandle)).Equal((*Fhandle3)(&((o).Fhandle))) {
+		return false
+	}
+	if len(*(&((v).Auth_flavors))) != len(*(&((o).Auth_flavors))) {
+		return false
+	}
+	for i := range *(&((v).Auth_flavors)) {
+		if *(&((*(&((v).Auth_flavors)))[i])) != *(&((*(&((o).Auth_flavors)))[i])) {
+			return false
+		}
+	}
+	return true
+}
+func (v *Mountres3_ok) Clone() (c Mountres3_ok) {
+	*(*Fhandle3)(&((&c).Fhandle)) = (*Fhandle3)(&((v).Fhandle)).Clone()
+	if *(&((v).Auth_flavors)) != nil {
+		*(&((&c).Auth_flavors)) = make([]uint32, len(*(&((v).Auth_flavors))))
+		for i := range *(&((v).Auth_flavors)) {
+			*(&((*(&((&c).Auth_flavors)))[i])) = *(&((*(&((v).Auth_flavors)))[i]))
+		}
+	}
+	return
+}
 func (v *Mountres3) Xdr(xs *xdr.XdrState) {
 	(*Mountstat3)(&((v).Fhs_status)).Xdr(xs)
 	switch (v).Fhs_status {
@@ -1282,23 +5201,88 @@ func (v *Mountres3) Xdr(xs *xdr.XdrState) {
 	default:
 	}
 }
-func (v *Mount3) Xdr(xs *xdr.XdrState) {
-	(*Name3)(&((v).Ml_hostname)).Xdr(xs)
-	(*Dirpath3)(&((v).Ml_directory)).Xdr(xs)
-	if xs.Encoding() {
-		opted := *(&((v).Ml_next)) != nil
-		xdr.XdrBool(xs, (*bool)(&opted))
-		if opted {
-			(*Mount3)(*(&((v).Ml_next))).Xdr(xs)
+func (v *Mountres3) XdrSize() (n int) {
+	n += 4
+	switch (v).Fhs_status {
+	case MNT3_OK:
+		n += (*Mountres3_ok)(&((v).Mountinfo)).XdrSize()
+	}
+	return
+}
+func (v *Mountres3) Equal(o *Mountres3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if !(*Mountstat3)(&((v).Fhs_status)).Equal((*Mountstat3)(&((o).Fhs_status))) {
+		return false
+	}
+	switch (v).Fhs_status {
+	case MNT3_OK:
+		if !(*Mountres3_ok)(&((v).Mountinfo)).Equal((*Mountres3_ok)(&((o).Mountinfo))) {
+			return false
 		}
 	}
-	if xs.Decoding() {
+	return true
+}
+func (v *Mountres3) Clone() (c Mountres3) {
+	*(*Mountstat3)(&((&c).Fhs_status)) = (*Mountstat3)(&((v).Fhs_status)).Clone()
+	switch (v).Fhs_status {
+	case MNT3_OK:
+		*(*Mountres3_ok)(&((&c).Mountinfo)) = (*Mountres3_ok)(&((v).Mountinfo)).Clone()
+	}
+	return
+}
+func (v *Mount3) Xdr(xs *xdr.XdrState) {
+	for {
+		(*Name3)(&((v).Ml_hostname)).Xdr(xs)
+		(*Dirpath3)(&((v).Ml_directory)).Xdr(xs)
 		var opted bool
+		if xs.Encoding() {
+			opted = v.Ml_next != nil
+		}
 		xdr.XdrBool(xs, (*bool)(&opted))
-		if opted {
-			*(&((v).Ml_next)) = new(Mount3)
-			(*Mount3)(*(&((v).Ml_next))).Xdr(xs)
+		if !opted || xs.Error() != nil {
+			return
+		}
+		if xs.Decoding() {
+			v.Ml_next = new(Mount3)
+		}
+		v = v.Ml_next
+	}
+}
+func (v *Mount3) XdrSize() (n int) {
+	for v != nil {
+		n += (*Name3)(&((v).Ml_hostname)).XdrSize()
+		n += (*Dirpath3)(&((v).Ml_directory)).XdrSize()
+		n += 4
+		v = v.Ml_next
+	}
+	return
+}
+func (v *Mount3) Equal(o *Mount3) bool {
+	for {
+		if v == nil || o == nil {
+			return v == o
+		}
+		if !(*Name3)(&((v).Ml_hostname)).Equal((*Name3)(&((o).Ml_hostname))) {
+			return false
+		}
+		if !(*Dirpath3)(&((v).Ml_directory)).Equal((*Dirpath3)(&((o).Ml_directory))) {
+			return false
+		}
+		v, o = v.Ml_next, o.Ml_next
+	}
+}
+func (v *Mount3) Clone() (c Mount3) {
+	d := &c
+	for {
+		*(*Name3)(&((d).Ml_hostname)) = (*Name3)(&((v).Ml_hostname)).Clone()
+		*(*Dirpath3)(&((d).Ml_directory)) = (*Dirpath3)(&((v).Ml_directory)).Clone()
+		if v.Ml_next == nil {
+			return
 		}
+		d.Ml_next = new(Mount3)
+		d, v = d.Ml_next, v.Ml_next
 	}
 }
 func (v *Mountopt3) Xdr(xs *xdr.XdrState) {
@@ -1318,55 +5302,157 @@ func (v *Mountopt3) Xdr(xs *xdr.XdrState) {
 		}
 	}
 }
-func (v *Groups3) Xdr(xs *xdr.XdrState) {
-	(*Name3)(&((v).Gr_name)).Xdr(xs)
-	if xs.Encoding() {
-		opted := *(&((v).Gr_next)) != nil
-		xdr.XdrBool(xs, (*bool)(&opted))
-		if opted {
-			(*Groups3)(*(&((v).Gr_next))).Xdr(xs)
+func (v *Mountopt3) XdrSize() (n int) {
+	n += 4
+	if *(&v.P) != nil {
+		n += (*Mount3)(*(&v.P)).XdrSize()
+	}
+	return
+}
+func (v *Mountopt3) Equal(o *Mountopt3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if (*(&v.P) == nil) != (*(&o.P) == nil) {
+		return false
+	}
+	if *(&v.P) != nil {
+		if !(*Mount3)(*(&v.P)).Equal((*Mount3)(*(&o.P))) {
+			return false
 		}
 	}
-	if xs.Decoding() {
+	return true
+}
+func (v *Mountopt3) Clone() (c Mountopt3) {
+	if *(&v.P) != nil {
+		*(&c.P) = new(Mount3)
+		*(*Mount3)(*(&c.P)) = (*Mount3)(*(&v.P)).Clone()
+	}
+	return
+}
+func (v *Groups3) Xdr(xs *xdr.XdrState) {
+	for {
+		(*Name3)(&((v).Gr_name)).Xdr(xs)
 		var opted bool
+		if xs.Encoding() {
+			opted = v.Gr_next != nil
+		}
 		xdr.XdrBool(xs, (*bool)(&opted))
-		if opted {
-			*(&((v).Gr_next)) = new(Groups3)
-			(*Groups3)(*(&((v).Gr_next))).Xdr(xs)
+		if !opted || xs.Error() != nil {
+			return
 		}
+		if xs.Decoding() {
+			v.Gr_next = new(Groups3)
+		}
+		v = v.Gr_next
 	}
 }
-func (v *Exports3) Xdr(xs *xdr.XdrState) {
-	(*Dirpath3)(&((v).Ex_dir)).Xdr(xs)
-	if xs.Encoding() {
-		opted := *(&((v).Ex_groups)) != nil
-		xdr.XdrBool(xs, (*bool)(&opted))
-		if opted {
-			(*Groups3)(*(&((v).Ex_groups))).Xdr(xs)
+func (v *Groups3) XdrSize() (n int) {
+	for v != nil {
+		n += (*Name3)(&((v).Gr_name)).XdrSize()
+		n += 4
+		v = v.Gr_next
+	}
+	return
+}
+func (v *Groups3) Equal(o *Groups3) bool {
+	for {
+		if v == nil || o == nil {
+			return v == o
 		}
+		if !(*Name3)(&((v).Gr_name)).Equal((*Name3)(&((o).Gr_name))) {
+			return false
+		}
+		v, o = v.Gr_next, o.Gr_next
 	}
-	if xs.Decoding() {
+}
+func (v *Groups3) Clone() (c Groups3) {
+	d := &c
+	for {
+		*(*Name3)(&((d).Gr_name)) = (*Name3)(&((v).Gr_name)).Clone()
+		if v.Gr_next == nil {
+			return
+		}
+		d.Gr_next = new(Groups3)
+		d, v = d.Gr_next, v.Gr_next
+	}
+}
+func (v *Exports3) Xdr(xs *xdr.XdrState) {
+	for {
+		(*Dirpath3)(&((v).Ex_dir)).Xdr(xs)
+		if xs.Encoding() {
+			opted := *(&((v).Ex_groups)) != nil
+			xdr.XdrBool(xs, (*bool)(&opted))
+			if opted {
+				(*Groups3)(*(&((v).Ex_groups))).Xdr(xs)
+			}
+		}
+		if xs.Decoding() {
+			var opted bool
+			xdr.XdrBool(xs, (*bool)(&opted))
+			if opted {
+				*(&((v).Ex_groups)) = new(Groups3)
+				(*Groups3)(*(&((v).Ex_groups))).Xdr(xs)
+			}
+		}
 		var opted bool
+		if xs.Encoding() {
+			opted = v.Ex_next != nil
+		}
 		xdr.XdrBool(xs, (*bool)(&opted))
-		if opted {
-			*(&((v).Ex_groups)) = new(Groups3)
-			(*Groups3)(*(&((v).Ex_groups))).Xdr(xs)
+		if !opted || xs.Error() != nil {
+			return
 		}
+		if xs.Decoding() {
+			v.Ex_next = new(Exports3)
+		}
+		v = v.Ex_next
 	}
-	if xs.Encoding() {
-		opted := *(&((v).Ex_next)) != nil
-		xdr.XdrBool(xs, (*bool)(&opted))
-		if opted {
-			(*Exports3)(*(&((v).Ex_next))).Xdr(xs)
+}
+func (v *Exports3) XdrSize() (n int) {
+	for v != nil {
+		n += (*Dirpath3)(&((v).Ex_dir)).XdrSize()
+		n += 4
+		if *(&((v).Ex_groups)) != nil {
+			n += (*Groups3)(*(&((v).Ex_groups))).XdrSize()
 		}
+		n += 4
+		v = v.Ex_next
 	}
-	if xs.Decoding() {
-		var opted bool
-		xdr.XdrBool(xs, (*bool)(&opted))
-		if opted {
-			*(&((v).Ex_next)) = new(Exports3)
-			(*Exports3)(*(&((v).Ex_next))).Xdr(xs)
+	return
+}
+func (v *Exports3) Equal(o *Exports3) bool {
+	for {
+		if v == nil || o == nil {
+			return v == o
+		}
+		if !(*Dirpath3)(&((v).Ex_dir)).Equal((*Dirpath3)(&((o).Ex_dir))) {
+			return false
 		}
+		if (*(&((v).Ex_groups)) == nil) != (*(&((o).Ex_groups)) == nil) {
+			return false
+		}
+		if *(&((v).Ex_groups)) != nil {
+			if !(*Groups3)(*(&((v).Ex_groups))).Equal((*Groups3)(*(&((o).Ex_groups)))) {
+				return false
+			}
+		}
+		v, o = v.Ex_next, o.Ex_next
+	}
+}
+func (v *Exports3) Clone() (c Exports3) {
+	d := &c
+	for {
+		*(*Dirpath3)(&((d).Ex_dir)) = (*Dirpath3)(&((v).Ex_dir)).Clone()
+		if *(&((v).Ex_groups)) != nil {
+			*(&((d).Ex_groups)) = new(Groups3)
+			*(*Groups3)(*(&((d).Ex_groups))) = (*Groups3)(*(&((v).Ex_groups))).Clone()
+		}
+		if v.Ex_next == nil {
+			return
+		}
+		d.Ex_next = new(Exports3)
+		d, v = d.Ex_next, v.Ex_next
 	}
 }
 func (v *Exportsopt3) Xdr(xs *xdr.XdrState) {
@@ -1386,3 +5472,40 @@ func (v *Exportsopt3) Xdr(xs *xdr.XdrState) {
 		}
 	}
 }
+func (v *Exportsopt3) XdrSize() (n int) {
+	n += 4
+	if *(&v.P) != nil {
+		n += (*Exports3)(*(&v.P)).XdrSize()
+	}
+	return
+}
+func (v *Exportsopt3) Equal(o *Exportsopt3) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	if (*(&v.P) == nil) != (*(&o.P) == nil) {
+		return false
+	}
+	if *(&v.P) != nil {
+		if !(*Exports3)(*(&v.P)).Equal((*Exports3)(*(&o.P))) {
+			return false
+		}
+	}
+	return true
+}
+func (v *Exportsopt3) Clone() (c Exportsopt3) {
+	if *(&v.P) != nil {
+		*(&c.P) = new(Exports3)
+		*(*Exports3)(*(&c.P)) = (*Exports3)(*(&v.P)).Clone()
+	}
+	return
+}
+func ProcName(prog, vers, proc uint32) string {
+	switch prog {
+	case NFS_PROGRAM:
+		return NFS_PROGRAM_ProcName(vers, proc)
+	case MOUNT_PROGRAM:
+		return MOUNT_PROGRAM_ProcName(vers, proc)
+	}
+	return fmt.Sprintf("%d/%d/%d", prog, vers, proc)
+}
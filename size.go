@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zeldovich/go-rpcgen/spec"
+)
+
+// Each declType and typespec can compute its encoded size.  goSize
+// returns statements that add the size of the value at valPtr to n.
+// Whether a type has a fixed size, and what it is, comes from
+// spec.Spec.FixedSize: fixed sizes are recorded for named types in
+// fixedSizes, and for declarations in declName.size when they are
+// converted.
+
+// fixedSizes holds the fixed sizes of named types, with "" for types
+// whose size varies.  It is filled in by resolve, and by emitEnum for
+// enums lifted out of other definitions.
+var fixedSizes = make(map[string]string)
+
+// fixedSize returns the size of t, or "" if it is not fixed.
+func (f *specFile) fixedSize(t spec.Type) string {
+	if n, ok := f.FixedSize(t); ok {
+		return n.String()
+	}
+	return ""
+}
+
+// declFixedSize returns the size of the type declared by d, or "" if
+// it is not fixed.
+func (f *specFile) declFixedSize(d spec.Decl) string {
+	if n, ok := f.DeclFixedSize(d); ok {
+		return n.String()
+	}
+	return ""
+}
+
+func fixedGoSize(sz string) string {
+	return fmt.Sprintf("n += %s\n", sz)
+}
+
+// declSize returns statements that add the size of the field or arm v
+// at valPtr to n.
+func declSize(v declName, valPtr string) string {
+	if v.size != "" {
+		return fixedGoSize(v.size)
+	}
+	return v.t.goSize(valPtr)
+}
+
+func (t declTypeTypespec) goSize(valPtr string) string {
+	return t.t.goSize(valPtr)
+}
+
+func (t declTypeArray) goSize(valPtr string) string {
+	var res string
+	res += fmt.Sprintf("for i := range *(%s) {\n", valPtr)
+	res += t.t.goSize(fmt.Sprintf("&((*(%s))[i])", valPtr))
+	res += fmt.Sprintf("}\n")
+	return res
+}
+
+func (t declTypeVarArray) goSize(valPtr string) string {
+	res := "n += 4\n"
+	if t.elemSize != "" {
+		return res + fmt.Sprintf("n += len(*(%s)) * (%s)\n", valPtr, t.elemSize)
+	}
+
+	res += fmt.Sprintf("for i := range *(%s) {\n", valPtr)
+	res += t.t.goSize(fmt.Sprintf("&((*(%s))[i])", valPtr))
+	res += fmt.Sprintf("}\n")
+	return res
+}
+
+func (t declTypeOpaqueArray) goSize(valPtr string) string {
+	return fmt.Sprintf("n += (len(*(%s)) + 3) &^ 3\n", valPtr)
+}
+
+func (t declTypeOpaqueVarArray) goSize(valPtr string) string {
+	return fmt.Sprintf("n += 4 + (len(*(%s)) + 3) &^ 3\n", valPtr)
+}
+
+func (t declTypeString) goSize(valPtr string) string {
+	return fmt.Sprintf("n += 4 + (len(*(%s)) + 3) &^ 3\n", valPtr)
+}
+
+func (t declTypePtr) goSize(valPtr string) string {
+	var res string
+	res += "n += 4\n"
+	if isListSlice(t.t) {
+		res += fmt.Sprintf("for idx := range *(%s) {\n", valPtr)
+		res += "n += 4\n"
+		res += t.t.goSize(fmt.Sprintf("&(*(%s))[idx]", valPtr))
+		res += fmt.Sprintf("}\n")
+		return res
+	}
+
+	res += fmt.Sprintf("if *(%s) != nil {\n", valPtr)
+	res += t.t.goSize(fmt.Sprintf("*(%s)", valPtr))
+	res += fmt.Sprintf("}\n")
+	return res
+}
+
+func (t typeInt) goSize(valPtr string) string       { return fixedGoSize("4") }
+func (t typeHyper) goSize(valPtr string) string     { return fixedGoSize("8") }
+func (t typeFloat) goSize(valPtr string) string     { return fixedGoSize("4") }
+func (t typeDouble) goSize(valPtr string) string    { return fixedGoSize("8") }
+func (t typeQuadruple) goSize(valPtr string) string { return fixedGoSize("16") }
+func (t typeBool) goSize(valPtr string) string      { return fixedGoSize("4") }
+func (t typeEnum) goSize(valPtr string) string      { return fixedGoSize("4") }
+
+func (t typeStruct) goSize(valPtr string) string {
+	var res string
+	for _, v := range t.items {
+		switch v := v.(type) {
+		case declName:
+			res += declSize(v, fmt.Sprintf("&((%s).%s)", valPtr, i(v.n)))
+		}
+	}
+	return res
+}
+
+func (t typeUnion) goSize(valPtr string) string {
+	v, ok := t.switchDecl.(declName)
+	if !ok {
+		panic("void union switch")
+	}
+
+	var res string
+	switchName := fmt.Sprintf("(%s).%s", valPtr, i(v.n))
+	res += declSize(v, fmt.Sprintf("&(%s)", switchName))
+	res += fmt.Sprintf("switch %s {\n", switchName)
+	for _, c := range t.cases.cases {
+		res += fmt.Sprintf("case %s:\n", strings.Join(c.cases, ", "))
+		if v, ok := c.body.(declName); ok {
+			res += declSize(v, fmt.Sprintf("&((%s).%s)", valPtr, i(v.n)))
+		}
+	}
+	if v, ok := t.cases.def.(declName); ok {
+		res += "default:\n"
+		res += declSize(v, fmt.Sprintf("&((%s).%s)", valPtr, i(v.n)))
+	}
+	res += "}\n"
+	return res
+}
+
+func (t typeIdent) goSize(valPtr string) string {
+	if fixedSizes[t.n] != "" {
+		return fixedGoSize(t.goType() + "_XdrSize")
+	}
+	return fmt.Sprintf("n += (*%s)(%s).XdrSize()\n", t.goType(), valPtr)
+}
+
+// emitXdrSize emits the XdrSize method of a named type, and its
+// _XdrSize constant if the type has a fixed size.  goSize holds the
+// statements that compute the size otherwise.
+func emitXdrSize(ident string, goSize string) {
+	sz := fixedSizes[ident]
+	if sz != "" {
+		fmt.Fprintf(tout, "const %s_XdrSize = %s\n", i(ident), sz)
+		fmt.Fprintf(out, "func (v *%s) XdrSize() int {\n", i(ident))
+		fmt.Fprintf(out, "return %s_XdrSize\n", i(ident))
+		fmt.Fprintf(out, "}\n")
+		return
+	}
+
+	fmt.Fprintf(out, "func (v *%s) XdrSize() (n int) {\n", i(ident))
+	fmt.Fprintf(out, "%s", goSize)
+	fmt.Fprintf(out, "return\n")
+	fmt.Fprintf(out, "}\n")
+}
@@ -0,0 +1,247 @@
+// Package spec parses XDR specifications (.x files), as used by
+// rpcgen, into a syntax tree.  It is the front end of go-rpcgen, and
+// can be used by other tools that need to read .x files.
+package spec
+
+import (
+	"go/token"
+	"math/big"
+)
+
+// A Spec is a parsed .x file.
+type Spec struct {
+	// Fset holds the positions in the spec, including those in
+	// included files.
+	Fset *token.FileSet
+
+	Defs []Def
+
+	// Consts holds the value of each constant and enum item that
+	// is defined in the spec.
+	Consts map[string]*big.Int
+
+	// Symbols holds the names defined in the spec.  It is filled in
+	// by Check.
+	Symbols map[string]Symbol
+}
+
+// Value returns the value of v, or nil if it is not known.  Unlike
+// v.Int, it is set for a single identifier that refers to a constant
+// defined later in the spec.
+func (s *Spec) Value(v Value) *big.Int {
+	if v.Int == nil && v.Ident {
+		return s.Consts[v.Text]
+	}
+	return v.Int
+}
+
+// A Def is a top-level definition: a ConstDef, TypedefDef, EnumDef,
+// StructDef, UnionDef or ProgramDef.
+//
+// The Doc fields of definitions, enum items, declarations, versions
+// and procedures hold the text of the comment attached to them in the
+// spec, without comment markers.  A comment is attached to a name if
+// it ends on the line before the name, or follows the name on the same
+// line.
+type Def interface {
+	Pos() token.Pos
+}
+
+// ConstDef is const Name = Value.
+type ConstDef struct {
+	NamePos token.Pos
+	Name    string
+	Value   Value
+	Doc     string
+}
+
+// TypedefDef is typedef Decl, which names the type of Decl.
+type TypedefDef struct {
+	Decl Decl
+}
+
+// EnumDef is enum Name { Items }.
+type EnumDef struct {
+	NamePos token.Pos
+	Name    string
+	Items   []EnumItem
+	Doc     string
+}
+
+// StructDef is struct Name { Fields }.
+type StructDef struct {
+	NamePos token.Pos
+	Name    string
+	Fields  []Decl
+	Doc     string
+}
+
+// UnionDef is union Name switch (...) { ... }.
+type UnionDef struct {
+	NamePos token.Pos
+	Name    string
+	Union   UnionType
+	Doc     string
+}
+
+// ProgramDef is program Name { Versions } = Value.
+type ProgramDef struct {
+	NamePos  token.Pos
+	Name     string
+	Versions []Version
+	Value    Value
+	Doc      string
+}
+
+func (d ConstDef) Pos() token.Pos   { return d.NamePos }
+func (d TypedefDef) Pos() token.Pos { return d.Decl.NamePos }
+func (d EnumDef) Pos() token.Pos    { return d.NamePos }
+func (d StructDef) Pos() token.Pos  { return d.NamePos }
+func (d UnionDef) Pos() token.Pos   { return d.NamePos }
+func (d ProgramDef) Pos() token.Pos { return d.NamePos }
+
+// EnumItem is Name = Value, within an enum.
+type EnumItem struct {
+	NamePos token.Pos
+	Name    string
+	Value   Value
+	Doc     string
+}
+
+// Version is version Name { Procs } = Value, within a program.
+type Version struct {
+	NamePos token.Pos
+	Name    string
+	Procs   []Proc
+	Value   Value
+	Doc     string
+}
+
+// Proc is Result Name(Arg) = Value, within a version.  Arg and Result
+// are nil for void.
+type Proc struct {
+	NamePos token.Pos
+	Name    string
+	Arg     Type
+	Result  Type
+	Value   Value
+	Doc     string
+}
+
+// A DeclKind is the form of a declaration.
+type DeclKind int
+
+const (
+	DeclVoid        DeclKind = iota // void
+	DeclPlain                       // T name
+	DeclFixedArray                  // T name[n]
+	DeclVarArray                    // T name<n>
+	DeclFixedOpaque                 // opaque name[n]
+	DeclVarOpaque                   // opaque name<n>
+	DeclString                      // string name<n>
+	DeclOptional                    // T *name
+)
+
+// A Decl declares a struct field, union arm or typedef.
+type Decl struct {
+	Kind    DeclKind
+	NamePos token.Pos
+
+	// Name is empty for DeclVoid.
+	Name string
+
+	// Type is the declared type, or the element type of an array.
+	// It is nil for DeclVoid, DeclFixedOpaque, DeclVarOpaque and
+	// DeclString.
+	Type Type
+
+	// Size is the size of a fixed array, or the maximum size of a
+	// variable array or string.  It is nil if there is no maximum.
+	Size *Value
+
+	Doc string
+}
+
+// A Type is an IntType, HyperType, FloatType, DoubleType,
+// QuadrupleType, BoolType, EnumType, StructType, UnionType or
+// NamedType.
+type Type interface {
+	typeNode()
+}
+
+type IntType struct {
+	Unsigned bool
+}
+
+type HyperType struct {
+	Unsigned bool
+}
+
+type FloatType struct{}
+
+type DoubleType struct{}
+
+type QuadrupleType struct{}
+
+type BoolType struct{}
+
+// EnumType, StructType and UnionType are anonymous types that are
+// declared inline.
+type EnumType struct {
+	Items []EnumItem
+}
+
+type StructType struct {
+	Fields []Decl
+}
+
+type UnionType struct {
+	Switch Decl
+	Cases  []UnionCase
+
+	// Default is nil if the union has no default arm.
+	Default *Decl
+}
+
+// UnionCase is one or more case labels followed by an arm.
+type UnionCase struct {
+	Values []Value
+	Decl   Decl
+}
+
+// NamedType is a reference to a type by name.
+type NamedType struct {
+	NamePos token.Pos
+	Name    string
+}
+
+func (IntType) typeNode()       {}
+func (HyperType) typeNode()     {}
+func (FloatType) typeNode()     {}
+func (DoubleType) typeNode()    {}
+func (QuadrupleType) typeNode() {}
+func (BoolType) typeNode()      {}
+func (EnumType) typeNode()      {}
+func (StructType) typeNode()    {}
+func (UnionType) typeNode()     {}
+func (NamedType) typeNode()     {}
+
+// A Value is a constant expression, as it appears in const
+// definitions, enum values, array bounds, case labels and program
+// numbers.
+type Value struct {
+	Pos token.Pos
+
+	// Text is the source text of a value that is a single literal
+	// or identifier.  It is empty for other expressions.
+	Text string
+
+	// Ident is set if the value is a single identifier.
+	Ident bool
+
+	// Int is the value of the expression, or nil if it could not be
+	// evaluated because Undef is not a known constant.  A single
+	// identifier may refer to a constant defined later in the spec.
+	Int   *big.Int
+	Undef string
+}
@@ -0,0 +1,301 @@
+package spec
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"math/big"
+)
+
+// A SymbolKind is the kind of name that a Symbol defines.
+type SymbolKind int
+
+const (
+	ConstSymbol    SymbolKind = iota // const definition
+	TypeSymbol                       // typedef, enum, struct or union
+	EnumItemSymbol                   // enum item, including in inline enums
+	ProgramSymbol                    // program
+	VersionSymbol                    // version within a program
+	ProcSymbol                       // procedure within a version
+)
+
+// A Symbol is a name defined in a spec.  Constants, types, enum items
+// and program, version and procedure names share a single scope.
+type Symbol struct {
+	Kind SymbolKind
+	Pos  token.Pos
+}
+
+// Check resolves the names used in s, filling in s.Symbols.  It
+// reports references to undefined types and constants, duplicate
+// definitions and fields, duplicate case labels within a union, and
+// duplicate program, version and procedure numbers.  Types and
+// constants defined outside the spec are taken from cfg, which may be
+// nil.  Errors are returned as a scanner.ErrorList.
+func Check(s *Spec, cfg *Config) error {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	c := &checker{s: s, cfg: cfg, progs: make(map[string]token.Pos)}
+	s.Symbols = make(map[string]Symbol)
+	for _, d := range s.Defs {
+		c.define(d)
+	}
+	for _, d := range s.Defs {
+		c.check(d)
+	}
+
+	c.errs.Sort()
+	return c.errs.Err()
+}
+
+type checker struct {
+	s    *Spec
+	cfg  *Config
+	errs scanner.ErrorList
+
+	// progs holds the program numbers seen so far.
+	progs map[string]token.Pos
+}
+
+func (c *checker) errorf(pos token.Pos, format string, args ...interface{}) {
+	c.errs.Add(c.s.Fset.Position(pos), fmt.Sprintf(format, args...))
+}
+
+func (c *checker) add(kind SymbolKind, pos token.Pos, name string) {
+	if prev, ok := c.s.Symbols[name]; ok {
+		c.errorf(pos, "%s redefined, previously defined at %s", name, c.s.Fset.Position(prev.Pos))
+		return
+	}
+	c.s.Symbols[name] = Symbol{kind, pos}
+}
+
+// define adds the names defined by d to the symbol table, including
+// those of enums declared inline.
+func (c *checker) define(d Def) {
+	switch d := d.(type) {
+	case ConstDef:
+		c.add(ConstSymbol, d.NamePos, d.Name)
+	case TypedefDef:
+		if d.Decl.Kind != DeclVoid {
+			c.add(TypeSymbol, d.Decl.NamePos, d.Decl.Name)
+		}
+		c.defineItems(d.Decl.Type)
+	case EnumDef:
+		c.add(TypeSymbol, d.NamePos, d.Name)
+		for _, item := range d.Items {
+			c.add(EnumItemSymbol, item.NamePos, item.Name)
+		}
+	case StructDef:
+		c.add(TypeSymbol, d.NamePos, d.Name)
+		c.defineItems(StructType{d.Fields})
+	case UnionDef:
+		c.add(TypeSymbol, d.NamePos, d.Name)
+		c.defineItems(d.Union)
+	case ProgramDef:
+		c.add(ProgramSymbol, d.NamePos, d.Name)
+		// A procedure may keep its name in other versions of the
+		// program, as long as it keeps its number.
+		type proc struct {
+			vers int
+			n    *big.Int
+		}
+		procs := make(map[string]proc)
+		for vi, v := range d.Versions {
+			c.add(VersionSymbol, v.NamePos, v.Name)
+			for _, p := range v.Procs {
+				n := c.s.Value(p.Value)
+				prev, ok := procs[p.Name]
+				if ok && prev.vers != vi && n != nil && prev.n != nil && n.Cmp(prev.n) == 0 {
+					continue
+				}
+				procs[p.Name] = proc{vi, n}
+				c.add(ProcSymbol, p.NamePos, p.Name)
+			}
+		}
+	}
+}
+
+// defineItems adds the items of the enums declared inline in t.
+func (c *checker) defineItems(t Type) {
+	switch t := t.(type) {
+	case EnumType:
+		for _, item := range t.Items {
+			c.add(EnumItemSymbol, item.NamePos, item.Name)
+		}
+	case StructType:
+		for _, f := range t.Fields {
+			c.defineItems(f.Type)
+		}
+	case UnionType:
+		for _, f := range unionFields(t) {
+			c.defineItems(f.Type)
+		}
+	}
+}
+
+// unionFields returns the switch and arm declarations of a union.
+func unionFields(u UnionType) []Decl {
+	fields := []Decl{u.Switch}
+	for _, uc := range u.Cases {
+		fields = append(fields, uc.Decl)
+	}
+	if u.Default != nil {
+		fields = append(fields, *u.Default)
+	}
+	return fields
+}
+
+func (c *checker) check(d Def) {
+	switch d := d.(type) {
+	case ConstDef:
+		c.checkValue(d.Value)
+	case TypedefDef:
+		c.checkDecl(d.Decl)
+	case EnumDef:
+		c.checkType(EnumType{d.Items})
+	case StructDef:
+		c.checkType(StructType{d.Fields})
+	case UnionDef:
+		c.checkUnion(d.Union)
+	case ProgramDef:
+		c.checkProgram(d)
+	}
+}
+
+func (c *checker) checkDecl(d Decl) {
+	if d.Type != nil {
+		c.checkType(d.Type)
+	}
+	if d.Size != nil {
+		c.checkValue(*d.Size)
+	}
+}
+
+func (c *checker) checkType(t Type) {
+	switch t := t.(type) {
+	case NamedType:
+		if sym, ok := c.s.Symbols[t.Name]; ok {
+			if sym.Kind != TypeSymbol {
+				c.errorf(t.NamePos, "%s is not a type", t.Name)
+			}
+		} else if !c.cfg.Types[t.Name] {
+			c.errorf(t.NamePos, "undefined type %s", t.Name)
+		}
+	case EnumType:
+		for _, item := range t.Items {
+			c.checkValue(item.Value)
+		}
+	case StructType:
+		c.checkFields(t.Fields)
+	case UnionType:
+		c.checkUnion(t)
+	}
+}
+
+// checkFields checks the declarations of a struct or union, whose
+// names must be distinct.
+func (c *checker) checkFields(fields []Decl) {
+	seen := make(map[string]token.Pos)
+	for _, f := range fields {
+		if f.Kind == DeclVoid {
+			continue
+		}
+		if prev, ok := seen[f.Name]; ok {
+			c.errorf(f.NamePos, "duplicate field %s, previously declared at %s", f.Name, c.s.Fset.Position(prev))
+		} else {
+			seen[f.Name] = f.NamePos
+		}
+	}
+	for _, f := range fields {
+		c.checkDecl(f)
+	}
+}
+
+func (c *checker) checkUnion(u UnionType) {
+	c.checkFields(unionFields(u))
+
+	seen := make(map[string]token.Pos)
+	for _, uc := range u.Cases {
+		for _, v := range uc.Values {
+			if !c.checkValue(v) {
+				continue
+			}
+			key := c.valueKey(v)
+			if prev, ok := seen[key]; ok {
+				c.errorf(v.Pos, "duplicate case %s, previously used at %s", valueText(v), c.s.Fset.Position(prev))
+			} else {
+				seen[key] = v.Pos
+			}
+		}
+	}
+}
+
+func (c *checker) checkProgram(d ProgramDef) {
+	c.checkUnique(c.progs, "program", d.Value)
+
+	versions := make(map[string]token.Pos)
+	for _, v := range d.Versions {
+		c.checkUnique(versions, "version", v.Value)
+
+		procs := make(map[string]token.Pos)
+		for _, p := range v.Procs {
+			c.checkUnique(procs, "procedure", p.Value)
+			if p.Arg != nil {
+				c.checkType(p.Arg)
+			}
+			if p.Result != nil {
+				c.checkType(p.Result)
+			}
+		}
+	}
+}
+
+// checkUnique reports a program, version or procedure number that
+// already appears in seen.
+func (c *checker) checkUnique(seen map[string]token.Pos, what string, v Value) {
+	key := c.valueKey(v)
+	if prev, ok := seen[key]; ok {
+		c.errorf(v.Pos, "duplicate %s number %s, previously used at %s", what, valueText(v), c.s.Fset.Position(prev))
+		return
+	}
+	seen[key] = v.Pos
+}
+
+// checkValue reports a constant value that refers to an undefined
+// name, or to a name that is not a constant.  It returns false if v
+// is invalid.
+func (c *checker) checkValue(v Value) bool {
+	if !v.Ident || v.Int != nil {
+		return true
+	}
+	if sym, ok := c.s.Symbols[v.Text]; ok {
+		if sym.Kind != ConstSymbol && sym.Kind != EnumItemSymbol {
+			c.errorf(v.Pos, "%s is not a constant", v.Text)
+			return false
+		}
+		return true
+	}
+	if _, ok := c.cfg.Consts[v.Text]; ok {
+		return true
+	}
+	c.errorf(v.Pos, "undefined constant %s", v.Text)
+	return false
+}
+
+// valueKey returns a key that is equal for values that are known to
+// be equal.
+func (c *checker) valueKey(v Value) string {
+	if n := c.s.Value(v); n != nil {
+		return n.String()
+	}
+	return v.Text
+}
+
+func valueText(v Value) string {
+	if v.Text != "" {
+		return v.Text
+	}
+	return v.Int.String()
+}
@@ -0,0 +1,176 @@
+package spec
+
+import (
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// A comment is a comment read by the lexer.
+type comment struct {
+	pos, end token.Pos
+	text     string
+
+	// trailing is set if the comment follows a token on the same
+	// line.
+	trailing bool
+}
+
+// commentText strips the comment markers from a comment, along with
+// the leading * of each line of a block comment.
+func commentText(lit string) string {
+	if strings.HasPrefix(lit, "//") {
+		return strings.TrimSpace(lit[2:])
+	}
+
+	lit = strings.TrimSuffix(strings.TrimPrefix(lit, "/*"), "*/")
+	var lines []string
+	for _, line := range strings.Split(lit, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimSpace(strings.TrimPrefix(line, "*"))
+		if line == "" && len(lines) == 0 {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// groupComments merges comments on consecutive lines that do not
+// follow other tokens, so that a block of // comments becomes one.
+func groupComments(file *token.File, comments []comment) []comment {
+	var groups []comment
+	for _, c := range comments {
+		if n := len(groups); n > 0 && !c.trailing && !groups[n-1].trailing &&
+			file.Line(c.pos) <= file.Line(groups[n-1].end)+1 {
+			groups[n-1].end = c.end
+			groups[n-1].text += "\n" + c.text
+			continue
+		}
+		groups = append(groups, c)
+	}
+	return groups
+}
+
+// walkDocs calls fn with the position and Doc field of every named
+// node in s, in source order.
+func walkDocs(s *Spec, fn func(pos token.Pos, doc *string)) {
+	for idx, d := range s.Defs {
+		switch d := d.(type) {
+		case ConstDef:
+			fn(d.NamePos, &d.Doc)
+			s.Defs[idx] = d
+		case TypedefDef:
+			walkDeclDocs(&d.Decl, fn)
+			s.Defs[idx] = d
+		case EnumDef:
+			fn(d.NamePos, &d.Doc)
+			for j := range d.Items {
+				fn(d.Items[j].NamePos, &d.Items[j].Doc)
+			}
+			s.Defs[idx] = d
+		case StructDef:
+			fn(d.NamePos, &d.Doc)
+			for j := range d.Fields {
+				walkDeclDocs(&d.Fields[j], fn)
+			}
+			s.Defs[idx] = d
+		case UnionDef:
+			fn(d.NamePos, &d.Doc)
+			var t Type = d.Union
+			walkTypeDocs(&t, fn)
+			d.Union = t.(UnionType)
+			s.Defs[idx] = d
+		case ProgramDef:
+			fn(d.NamePos, &d.Doc)
+			for j := range d.Versions {
+				v := &d.Versions[j]
+				fn(v.NamePos, &v.Doc)
+				for k := range v.Procs {
+					fn(v.Procs[k].NamePos, &v.Procs[k].Doc)
+				}
+			}
+			s.Defs[idx] = d
+		}
+	}
+}
+
+func walkDeclDocs(d *Decl, fn func(pos token.Pos, doc *string)) {
+	if d.Kind != DeclVoid {
+		fn(d.NamePos, &d.Doc)
+	}
+	walkTypeDocs(&d.Type, fn)
+}
+
+func walkTypeDocs(t *Type, fn func(pos token.Pos, doc *string)) {
+	switch tt := (*t).(type) {
+	case EnumType:
+		for j := range tt.Items {
+			fn(tt.Items[j].NamePos, &tt.Items[j].Doc)
+		}
+	case StructType:
+		for j := range tt.Fields {
+			walkDeclDocs(&tt.Fields[j], fn)
+		}
+	case UnionType:
+		walkDeclDocs(&tt.Switch, fn)
+		for j := range tt.Cases {
+			walkDeclDocs(&tt.Cases[j].Decl, fn)
+		}
+		if tt.Default != nil {
+			walkDeclDocs(tt.Default, fn)
+		}
+		*t = tt
+	}
+}
+
+// attachComments fills in the Doc fields of s.  A comment group that
+// ends on the line before a name is attached to the first name on that
+// line; a comment that follows a name on the same line is attached to
+// the last name before it.
+func attachComments(s *Spec, file *token.File, comments []comment) {
+	names := make(map[int][]token.Pos)
+	walkDocs(s, func(pos token.Pos, doc *string) {
+		line := file.Line(pos)
+		names[line] = append(names[line], pos)
+	})
+	for _, ps := range names {
+		sort.Slice(ps, func(a, b int) bool { return ps[a] < ps[b] })
+	}
+
+	leading := make(map[token.Pos]string)
+	trailing := make(map[token.Pos]string)
+	for _, g := range groupComments(file, comments) {
+		if g.text == "" {
+			continue
+		}
+		if g.trailing {
+			var last token.Pos
+			for _, p := range names[file.Line(g.pos)] {
+				if p < g.pos {
+					last = p
+				}
+			}
+			if last.IsValid() {
+				trailing[last] = g.text
+			}
+			continue
+		}
+		if ps := names[file.Line(g.end)+1]; len(ps) > 0 {
+			leading[ps[0]] = g.text
+		}
+	}
+
+	walkDocs(s, func(pos token.Pos, doc *string) {
+		var parts []string
+		for _, text := range []string{leading[pos], trailing[pos]} {
+			if text != "" {
+				parts = append(parts, text)
+			}
+		}
+		*doc = strings.Join(parts, "\n")
+	})
+}
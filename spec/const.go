@@ -0,0 +1,119 @@
+package spec
+
+import (
+	"go/token"
+	"math/big"
+)
+
+// builtinConsts are the constants that every spec can refer to.
+var builtinConsts = map[string]*big.Int{
+	"TRUE":  big.NewInt(1),
+	"FALSE": big.NewInt(0),
+}
+
+func literalValue(pos token.Pos, lit string) Value {
+	// Malformed literals have already been reported by the scanner.
+	n, ok := new(big.Int).SetString(lit, 0)
+	if !ok {
+		n = nil
+	}
+	return Value{Pos: pos, Text: lit, Int: n}
+}
+
+func (l *lexer) identValue(pos token.Pos, ident string) Value {
+	v := Value{Pos: pos, Text: ident, Ident: true}
+	for _, consts := range []map[string]*big.Int{l.consts, builtinConsts, l.extConsts} {
+		n, ok := consts[ident]
+		if ok && n != nil {
+			v.Int = n
+			return v
+		}
+	}
+	v.Undef = ident
+	return v
+}
+
+// defineConst records the value of a const definition or enum item,
+// so that later expressions can refer to it.
+func (l *lexer) defineConst(ident string, v Value) {
+	if v.Int != nil {
+		l.consts[ident] = v.Int
+	}
+}
+
+func (l *lexer) unaryValue(pos token.Pos, op byte, x Value) Value {
+	res := Value{Pos: pos, Undef: x.Undef}
+	if x.Int == nil {
+		return res
+	}
+
+	switch op {
+	case '-':
+		res.Int = new(big.Int).Neg(x.Int)
+	case '+':
+		res.Int = x.Int
+	}
+	return res
+}
+
+func (l *lexer) binaryValue(op string, x, y Value) Value {
+	res := Value{Pos: x.Pos}
+	if x.Int == nil {
+		res.Undef = x.Undef
+		return res
+	}
+	if y.Int == nil {
+		res.Undef = y.Undef
+		return res
+	}
+
+	n := new(big.Int)
+	switch op {
+	case "+":
+		n.Add(x.Int, y.Int)
+	case "-":
+		n.Sub(x.Int, y.Int)
+	case "*":
+		n.Mul(x.Int, y.Int)
+	case "/", "%":
+		if y.Int.Sign() == 0 {
+			l.errorf(y.Pos, "division by zero in constant expression")
+			return Value{Pos: x.Pos, Int: big.NewInt(0)}
+		}
+		// Quo and Rem truncate towards zero, as in C and Go.
+		if op == "/" {
+			n.Quo(x.Int, y.Int)
+		} else {
+			n.Rem(x.Int, y.Int)
+		}
+	case "<<", ">>":
+		if y.Int.Sign() < 0 || y.Int.Cmp(big.NewInt(64)) >= 0 {
+			l.errorf(y.Pos, "invalid shift count %s", y.Int)
+			return Value{Pos: x.Pos, Int: big.NewInt(0)}
+		}
+		if op == "<<" {
+			n.Lsh(x.Int, uint(y.Int.Uint64()))
+		} else {
+			n.Rsh(x.Int, uint(y.Int.Uint64()))
+		}
+	case "&":
+		n.And(x.Int, y.Int)
+	case "|":
+		n.Or(x.Int, y.Int)
+	case "^":
+		n.Xor(x.Int, y.Int)
+	}
+	res.Int = n
+	return res
+}
+
+// exprValue checks a complete constant expression.  A single
+// identifier may refer to a constant that is declared later, but an
+// identifier within a larger expression must already be defined.
+func (l *lexer) exprValue(v Value) Value {
+	if v.Int == nil && !v.Ident {
+		l.errorf(v.Pos, "undefined constant %s in expression", v.Undef)
+		v.Int = big.NewInt(0)
+	}
+	return v
+}
@@ -0,0 +1,609 @@
+package spec
+
+import (
+	"bytes"
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// The preprocessor implements the subset of the C preprocessor that
+// .x files rely on: #include, object-like #define and #undef, and
+// #if/#ifdef/#ifndef/#elif/#else/#endif conditionals.  Lines starting
+// with % are passed through to C code by rpcgen; they have no meaning
+// for Go output, so they are dropped.
+//
+// Directives and skipped lines are replaced by empty lines, so that
+// line numbers within each file are preserved.  Macro expansion is
+// done by the lexer, which applies the #define and #undef directives
+// recorded here as it reaches their place in the output.
+
+const maxIncludeDepth = 32
+
+type lineInfo struct {
+	offset   int
+	filename string
+	line     int
+}
+
+// A macroChange records a #define, or an #undef if undef is set, that
+// takes effect from offset in the output.
+type macroChange struct {
+	offset int
+	name   string
+	value  string
+	undef  bool
+}
+
+type condState struct {
+	// active is true if lines in the current branch are kept.
+	active bool
+
+	// taken is true if some branch of this conditional was active.
+	taken bool
+
+	// parentActive is true if the enclosing region is active.
+	parentActive bool
+
+	seenElse bool
+	pos      token.Position
+}
+
+type preprocessor struct {
+	includePath []string
+	errs        scanner.ErrorList
+
+	// defines holds the macros defined at the current line, for
+	// conditionals; macros holds the changes to them, for the lexer.
+	defines map[string]string
+	macros  []macroChange
+
+	out   bytes.Buffer
+	lines []lineInfo
+	depth int
+}
+
+func newPreprocessor(includePath []string, defines map[string]string) *preprocessor {
+	pp := &preprocessor{
+		includePath: includePath,
+		defines:     make(map[string]string),
+	}
+	for k, v := range defines {
+		pp.defines[k] = v
+	}
+	return pp
+}
+
+// addLineInfo records that the output from the current offset onward
+// comes from filename, starting at line.
+func (pp *preprocessor) addLineInfo(filename string, line int) {
+	pp.lines = append(pp.lines, lineInfo{pp.out.Len(), filename, line})
+}
+
+func (pp *preprocessor) errorf(pos token.Position, format string, args ...interface{}) {
+	pp.errs.Add(pos, fmt.Sprintf(format, args...))
+}
+
+func (pp *preprocessor) include(from, spec string, pos token.Position) {
+	filename, ok := pp.findInclude(from, spec)
+	if !ok {
+		pp.errorf(pos, "cannot find include file %s", spec)
+		return
+	}
+
+	if pp.depth >= maxIncludeDepth {
+		pp.errorf(pos, "#include nested too deeply")
+		return
+	}
+
+	src, err := ioutil.ReadFile(filename)
+	if err != nil {
+		pp.errorf(pos, "%v", err)
+		return
+	}
+
+	pp.depth++
+	pp.file(filename, src)
+	pp.depth--
+}
+
+func (pp *preprocessor) file(filename string, src []byte) {
+	pp.addLineInfo(filename, 1)
+
+	var conds []condState
+	active := func() bool {
+		return len(conds) == 0 || conds[len(conds)-1].active
+	}
+
+	// directiveComment is set if the open block comment started on
+	// a directive or % line, in which case it is dropped with that
+	// line.
+	inComment, directiveComment := false, false
+	lines := strings.Split(string(src), "\n")
+	for n := 0; n < len(lines); n++ {
+		line := lines[n]
+		pos := token.Position{Filename: filename, Line: n + 1, Column: 1}
+
+		startsInComment := inComment
+		inComment = endsInComment(line, inComment)
+
+		if startsInComment && directiveComment {
+			// Blank the comment, keeping the columns of the
+			// rest of the line.
+			end := strings.Index(line, "*/")
+			if end < 0 {
+				line = ""
+			} else {
+				line = strings.Repeat(" ", end+2) + line[end+2:]
+				directiveComment = false
+			}
+		}
+
+		trimmed := strings.TrimLeft(line, " \t")
+		if startsInComment || (!strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(trimmed, "%")) {
+			if active() {
+				pp.out.WriteString(line)
+			}
+			if n != len(lines)-1 {
+				pp.out.WriteString("\n")
+			}
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "%") {
+			directiveComment = inComment
+			pp.out.WriteString("\n")
+			continue
+		}
+
+		// Join directive lines ending with a backslash.
+		directive := trimmed[1:]
+		for strings.HasSuffix(directive, "\\") && n+1 < len(lines) {
+			directive = directive[:len(directive)-1] + " " + lines[n+1]
+			inComment = endsInComment(lines[n+1], inComment)
+			pp.out.WriteString("\n")
+			n++
+		}
+		directiveComment = inComment
+		directive = stripComments(directive)
+
+		name, rest := splitDirective(directive)
+		switch name {
+		case "if", "ifdef", "ifndef":
+			c := condState{parentActive: active(), pos: pos}
+			if c.parentActive {
+				switch name {
+				case "if":
+					c.active = pp.evalCond(rest, pos)
+				case "ifdef":
+					_, c.active = pp.defines[pp.macroName(rest, pos)]
+				case "ifndef":
+					_, defined := pp.defines[pp.macroName(rest, pos)]
+					c.active = !defined
+				}
+			}
+			c.taken = c.active
+			conds = append(conds, c)
+
+		case "elif":
+			if len(conds) == 0 {
+				pp.errorf(pos, "#elif without #if")
+				break
+			}
+			c := &conds[len(conds)-1]
+			if c.seenElse {
+				pp.errorf(pos, "#elif after #else")
+			}
+			c.active = c.parentActive && !c.taken && pp.evalCond(rest, pos)
+			c.taken = c.taken || c.active
+
+		case "else":
+			if len(conds) == 0 {
+				pp.errorf(pos, "#else without #if")
+				break
+			}
+			c := &conds[len(conds)-1]
+			if c.seenElse {
+				pp.errorf(pos, "duplicate #else")
+			}
+			c.seenElse = true
+			c.active = c.parentActive && !c.taken
+			c.taken = true
+
+		case "endif":
+			if len(conds) == 0 {
+				pp.errorf(pos, "#endif without #if")
+				break
+			}
+			conds = conds[:len(conds)-1]
+
+		case "include":
+			if active() {
+				pp.include(filename, rest, pos)
+
+				// Resume the including file on the next line.
+				pp.out.WriteString("\n")
+				pp.addLineInfo(filename, n+2)
+				continue
+			}
+
+		default:
+			if active() {
+				pp.directive(name, rest, pos)
+			}
+		}
+
+		if n != len(lines)-1 {
+			pp.out.WriteString("\n")
+		}
+	}
+
+	for _, c := range conds {
+		pp.errorf(c.pos, "unterminated conditional directive")
+	}
+}
+
+// directive handles the directives that are only processed in
+// active regions.
+func (pp *preprocessor) directive(name, rest string, pos token.Position) {
+	switch name {
+	case "define":
+		macro, value := splitDirective(rest)
+		if macro == "" || !isIdent(macro) {
+			pp.errorf(pos, "invalid macro name in #define")
+			return
+		}
+		if strings.HasPrefix(rest[len(macro):], "(") {
+			pp.errorf(pos, "function-like macro %s is not supported", macro)
+			return
+		}
+		pp.defines[macro] = value
+		pp.macros = append(pp.macros, macroChange{pp.out.Len(), macro, value, false})
+
+	case "undef":
+		macro := pp.macroName(rest, pos)
+		delete(pp.defines, macro)
+		pp.macros = append(pp.macros, macroChange{pp.out.Len(), macro, "", true})
+
+	case "error":
+		pp.errorf(pos, "#error %s", rest)
+
+	case "pragma", "ident", "":
+		// Ignored.
+
+	default:
+		pp.errorf(pos, "unknown directive #%s", name)
+	}
+}
+
+func (pp *preprocessor) macroName(s string, pos token.Position) string {
+	s = strings.TrimSpace(s)
+	if !isIdent(s) {
+		pp.errorf(pos, "invalid macro name %q", s)
+	}
+	return s
+}
+
+func (pp *preprocessor) findInclude(from, spec string) (string, bool) {
+	spec = strings.TrimSpace(spec)
+	if len(spec) < 2 {
+		return "", false
+	}
+
+	var dirs []string
+	switch {
+	case spec[0] == '"' && spec[len(spec)-1] == '"':
+		dirs = append([]string{filepath.Dir(from)}, pp.includePath...)
+	case spec[0] == '<' && spec[len(spec)-1] == '>':
+		dirs = pp.includePath
+	default:
+		return "", false
+	}
+
+	name := spec[1 : len(spec)-1]
+	if filepath.IsAbs(name) {
+		_, err := os.Stat(name)
+		return name, err == nil
+	}
+
+	for _, dir := range dirs {
+		path := filepath.Join(dir, name)
+		_, err := os.Stat(path)
+		if err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// evalCond evaluates the expression of an #if or #elif directive.
+func (pp *preprocessor) evalCond(expr string, pos token.Position) bool {
+	fset := token.NewFileSet()
+	f := fset.AddFile("", -1, len(expr))
+
+	var toks []condToken
+	var s scanner.Scanner
+	s.Init(f, []byte(expr), func(_ token.Position, msg string) {
+		pp.errorf(pos, "#if: %s", msg)
+	}, 0)
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+		toks = append(toks, condToken{tok, lit})
+	}
+
+	p := condParser{pp: pp, toks: toks}
+	v, err := p.or()
+	if err == nil && p.pos < len(toks) {
+		err = fmt.Errorf("unexpected %s", toks[p.pos])
+	}
+	if err != nil {
+		pp.errorf(pos, "#if: %v", err)
+		return false
+	}
+	return v != 0
+}
+
+type condToken struct {
+	tok token.Token
+	lit string
+}
+
+func (t condToken) String() string {
+	if t.lit != "" {
+		return t.lit
+	}
+	return t.tok.String()
+}
+
+// condParser evaluates #if expressions made of integers, macros,
+// defined(), parentheses and the !, comparison, && and || operators.
+type condParser struct {
+	pp   *preprocessor
+	toks []condToken
+	pos  int
+
+	// expanding guards against recursive macro definitions.
+	expanding map[string]bool
+}
+
+func (p *condParser) peek() token.Token {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos].tok
+	}
+	return token.EOF
+}
+
+func (p *condParser) next() condToken {
+	t := condToken{tok: token.EOF}
+	if p.pos < len(p.toks) {
+		t = p.toks[p.pos]
+		p.pos++
+	}
+	return t
+}
+
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (p *condParser) or() (int64, error) {
+	v, err := p.and()
+	for err == nil && p.peek() == token.LOR {
+		p.next()
+		var w int64
+		w, err = p.and()
+		v = boolInt(v != 0 || w != 0)
+	}
+	return v, err
+}
+
+func (p *condParser) and() (int64, error) {
+	v, err := p.cmp()
+	for err == nil && p.peek() == token.LAND {
+		p.next()
+		var w int64
+		w, err = p.cmp()
+		v = boolInt(v != 0 && w != 0)
+	}
+	return v, err
+}
+
+func (p *condParser) cmp() (int64, error) {
+	v, err := p.unary()
+	for err == nil {
+		op := p.peek()
+		switch op {
+		case token.EQL, token.NEQ, token.LSS, token.GTR, token.LEQ, token.GEQ:
+		default:
+			return v, nil
+		}
+		p.next()
+
+		var w int64
+		w, err = p.unary()
+		switch op {
+		case token.EQL:
+			v = boolInt(v == w)
+		case token.NEQ:
+			v = boolInt(v != w)
+		case token.LSS:
+			v = boolInt(v < w)
+		case token.GTR:
+			v = boolInt(v > w)
+		case token.LEQ:
+			v = boolInt(v <= w)
+		case token.GEQ:
+			v = boolInt(v >= w)
+		}
+	}
+	return v, err
+}
+
+func (p *condParser) unary() (int64, error) {
+	switch p.peek() {
+	case token.NOT:
+		p.next()
+		v, err := p.unary()
+		return boolInt(v == 0), err
+	case token.SUB:
+		p.next()
+		v, err := p.unary()
+		return -v, err
+	}
+	return p.primary()
+}
+
+func (p *condParser) primary() (int64, error) {
+	t := p.next()
+	switch t.tok {
+	case token.INT:
+		return strconv.ParseInt(t.lit, 0, 64)
+
+	case token.LPAREN:
+		v, err := p.or()
+		if err != nil {
+			return 0, err
+		}
+		if p.next().tok != token.RPAREN {
+			return 0, fmt.Errorf("missing )")
+		}
+		return v, nil
+
+	case token.IDENT:
+		if t.lit == "defined" {
+			paren := p.peek() == token.LPAREN
+			if paren {
+				p.next()
+			}
+			name := p.next()
+			if name.tok != token.IDENT {
+				return 0, fmt.Errorf("expected macro name after defined")
+			}
+			if paren && p.next().tok != token.RPAREN {
+				return 0, fmt.Errorf("missing )")
+			}
+			_, ok := p.pp.defines[name.lit]
+			return boolInt(ok), nil
+		}
+
+		// As in C, undefined macros evaluate to zero.
+		val, ok := p.pp.defines[t.lit]
+		if !ok || strings.TrimSpace(val) == "" || p.expanding[t.lit] {
+			return 0, nil
+		}
+
+		if p.expanding == nil {
+			p.expanding = make(map[string]bool)
+		}
+		p.expanding[t.lit] = true
+		defer delete(p.expanding, t.lit)
+
+		sub := &condParser{pp: p.pp, expanding: p.expanding}
+		fset := token.NewFileSet()
+		var s scanner.Scanner
+		s.Init(fset.AddFile("", -1, len(val)), []byte(val), nil, 0)
+		for {
+			_, tok, lit := s.Scan()
+			if tok == token.EOF {
+				break
+			}
+			if tok == token.SEMICOLON && lit == "\n" {
+				continue
+			}
+			sub.toks = append(sub.toks, condToken{tok, lit})
+		}
+		v, err := sub.or()
+		if err == nil && sub.pos < len(sub.toks) {
+			err = fmt.Errorf("cannot evaluate macro %s", t.lit)
+		}
+		return v, err
+	}
+
+	if t.tok == token.EOF {
+		return 0, fmt.Errorf("unexpected end of expression")
+	}
+	return 0, fmt.Errorf("unexpected %s", t)
+}
+
+// splitDirective splits s into its first word and the remainder.
+func splitDirective(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	end := 0
+	for end < len(s) && (isIdentByte(s[end]) || (end > 0 && s[end] >= '0' && s[end] <= '9')) {
+		end++
+	}
+	return s[:end], strings.TrimSpace(s[end:])
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdent(s string) bool {
+	if s == "" || !isIdentByte(s[0]) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if !isIdentByte(s[i]) && !(s[i] >= '0' && s[i] <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// stripComments removes C comments from a directive line.
+func stripComments(s string) string {
+	for {
+		start := strings.Index(s, "/*")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(s[start+2:], "*/")
+		if end < 0 {
+			s = s[:start]
+			break
+		}
+		s = s[:start] + " " + s[start+2+end+2:]
+	}
+	if idx := strings.Index(s, "//"); idx >= 0 {
+		s = s[:idx]
+	}
+	return s
+}
+
+// endsInComment reports whether a block comment is still open at the
+// end of line, given whether one was open at its start.
+func endsInComment(line string, inComment bool) bool {
+	for i := 0; i < len(line); i++ {
+		if inComment {
+			if strings.HasPrefix(line[i:], "*/") {
+				inComment = false
+				i++
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line[i:], "//") {
+			break
+		}
+		if strings.HasPrefix(line[i:], "/*") {
+			inComment = true
+			i++
+		}
+	}
+	return inComment
+}
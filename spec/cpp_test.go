@@ -0,0 +1,68 @@
+package spec
+
+import (
+	"testing"
+)
+
+// arraySizes parses src and returns the size of the first field of
+// each struct.
+func arraySizes(t *testing.T, src string) []int64 {
+	t.Helper()
+
+	s, err := Parse("test.x", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sizes []int64
+	for _, d := range s.Defs {
+		if d, ok := d.(StructDef); ok {
+			sizes = append(sizes, s.Value(*d.Fields[0].Size).Int64())
+		}
+	}
+	return sizes
+}
+
+// Macros are expanded with the definition in effect where they are
+// used.
+func TestMacroRedefine(t *testing.T) {
+	sizes := arraySizes(t, `
+struct a { int x[N]; };
+#define N 10
+struct b { int x[N]; };
+#undef N
+#define N 20
+struct c { int x[N]; };
+#undef N
+const N = 5;
+struct d { int x[N]; };
+`)
+	want := []int64{5, 10, 20, 5}
+	if len(sizes) != len(want) {
+		t.Fatalf("got sizes %v, want %v", sizes, want)
+	}
+	for i := range want {
+		if sizes[i] != want[i] {
+			t.Errorf("got sizes %v, want %v", sizes, want)
+			break
+		}
+	}
+}
+
+// A block comment that starts on a directive line is dropped along
+// with the directive, up to its end on a later line.
+func TestDirectiveComment(t *testing.T) {
+	sizes := arraySizes(t, `
+#define X 1 /* a
+ b */
+#define Y 2 /* c \
+ d
+ e */ struct a { int x[X]; };
+%/* f
+% g */
+struct b { int x[Y]; };
+`)
+	if len(sizes) != 2 || sizes[0] != 1 || sizes[1] != 2 {
+		t.Errorf("got sizes %v, want [1 2]", sizes)
+	}
+}
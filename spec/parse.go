@@ -0,0 +1,78 @@
+package spec
+
+import (
+	"go/token"
+	"io/ioutil"
+	"math/big"
+)
+
+// Config holds the options for parsing a spec.
+type Config struct {
+	// IncludePath holds the directories searched by #include.
+	// Quoted includes are first looked up next to the including
+	// file.
+	IncludePath []string
+
+	// Defines holds the macros that are predefined for the
+	// preprocessor.
+	Defines map[string]string
+
+	// Consts holds the values of constants defined outside the spec,
+	// such as in other specs, for use in constant expressions.  A
+	// nil value marks a constant whose value is not known.
+	Consts map[string]*big.Int
+
+	// Types holds the names of types defined outside the spec, for
+	// Check.
+	Types map[string]bool
+
+	// Debug prints each token as it is read.
+	Debug bool
+}
+
+// ParseFile reads, preprocesses and parses a .x file.  Errors in the
+// spec are returned as a scanner.ErrorList.  cfg may be nil.
+//
+// If the spec has syntax errors, the definitions that could be parsed
+// are returned along with the errors, so that they can be checked too.
+func ParseFile(filename string, cfg *Config) (*Spec, error) {
+	src, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(filename, src, cfg)
+}
+
+// Parse preprocesses and parses the contents of a .x file.
+func Parse(filename string, src []byte, cfg *Config) (*Spec, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	pp := newPreprocessor(cfg.IncludePath, cfg.Defines)
+	pp.file(filename, src)
+	if len(pp.errs) > 0 {
+		pp.errs.Sort()
+		return nil, pp.errs.Err()
+	}
+
+	src = pp.out.Bytes()
+	fset := token.NewFileSet()
+	f := fset.AddFile(filename, -1, len(src))
+	for _, li := range pp.lines {
+		f.AddLineColumnInfo(li.offset, li.filename, li.line, 1)
+	}
+
+	l := &lexer{debug: cfg.Debug, extConsts: cfg.Consts}
+	l.init(fset, f, src, cfg.Defines, pp.macros)
+	xdrParse(l)
+
+	s := &Spec{
+		Fset:   fset,
+		Defs:   l.defs,
+		Consts: l.consts,
+	}
+	attachComments(s, f, l.comments)
+	l.errs.Sort()
+	return s, l.errs.Err()
+}
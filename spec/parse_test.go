@@ -0,0 +1,130 @@
+package spec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	s, err := Parse("test.x", []byte(`const MAX = 8;
+typedef opaque fhandle<MAX>;
+enum color { RED = 1, GREEN = 2 };
+struct point {
+  int x;
+  color c;
+};
+union result switch (color c) {
+case RED:
+  point p;
+default:
+  void;
+};
+program P {
+  version V {
+    result GET(fhandle) = 1;
+  } = 1;
+} = 0x20000001;
+`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.Defs) != 6 {
+		t.Fatalf("got %d definitions, want 6", len(s.Defs))
+	}
+	lines := []int{1, 2, 3, 4, 8, 14}
+	for i, d := range s.Defs {
+		if line := s.Fset.Position(d.Pos()).Line; line != lines[i] {
+			t.Errorf("definition %d is on line %d, want %d", i, line, lines[i])
+		}
+	}
+
+	c := s.Defs[0].(ConstDef)
+	if c.Name != "MAX" || c.Value.Int.Int64() != 8 {
+		t.Errorf("got const %+v", c)
+	}
+
+	td := s.Defs[1].(TypedefDef)
+	if td.Decl.Name != "fhandle" || td.Decl.Kind != DeclVarOpaque || td.Decl.Size.Text != "MAX" {
+		t.Errorf("got typedef %+v", td.Decl)
+	}
+
+	e := s.Defs[2].(EnumDef)
+	if e.Name != "color" || len(e.Items) != 2 || e.Items[1].Name != "GREEN" || e.Items[1].Value.Int.Int64() != 2 {
+		t.Errorf("got enum %+v", e)
+	}
+
+	st := s.Defs[3].(StructDef)
+	if st.Name != "point" || len(st.Fields) != 2 || st.Fields[1].Type.(NamedType).Name != "color" {
+		t.Errorf("got struct %+v", st)
+	}
+	if _, ok := st.Fields[0].Type.(IntType); !ok {
+		t.Errorf("got field %+v", st.Fields[0])
+	}
+
+	u := s.Defs[4].(UnionDef)
+	if u.Name != "result" || u.Union.Switch.Name != "c" || len(u.Union.Cases) != 1 ||
+		u.Union.Cases[0].Values[0].Text != "RED" || u.Union.Default == nil || u.Union.Default.Kind != DeclVoid {
+		t.Errorf("got union %+v", u)
+	}
+
+	p := s.Defs[5].(ProgramDef)
+	if p.Name != "P" || p.Value.Int.Int64() != 0x20000001 || len(p.Versions) != 1 {
+		t.Fatalf("got program %+v", p)
+	}
+	proc := p.Versions[0].Procs[0]
+	if proc.Name != "GET" || proc.Arg.(NamedType).Name != "fhandle" || proc.Result.(NamedType).Name != "result" {
+		t.Errorf("got procedure %+v", proc)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse("test.x", []byte("struct s { int a };\nconst C = ;\n"), nil)
+	if err == nil {
+		t.Fatal("syntax errors not reported")
+	}
+	if got := err.Error(); !strings.HasPrefix(got, "test.x:1:") {
+		t.Errorf("got error %q", got)
+	}
+}
+
+// The rpcgen forms used by upstream specs: unsigned on its own,
+// struct, enum and union before a type name, and procedures that keep
+// their name and number across versions.
+func TestRpcgenForms(t *testing.T) {
+	s, err := Parse("test.x", []byte(`enum e { A = 1 };
+struct s { unsigned a; enum e b; };
+union u switch (unsigned d) { case 1: struct s x; default: void; };
+program P {
+  version V1 { union u GET(struct s) = 1; } = 1;
+  version V2 { union u GET(struct s) = 1; } = 2;
+} = 0x20000001;
+`), nil)
+	if err == nil {
+		err = Check(s, nil)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := s.Defs[1].(StructDef)
+	if st.Fields[0].Type != (IntType{true}) || st.Fields[1].Type.(NamedType).Name != "e" {
+		t.Errorf("got struct %+v", st)
+	}
+	proc := s.Defs[3].(ProgramDef).Versions[1].Procs[0]
+	if proc.Arg.(NamedType).Name != "s" || proc.Result.(NamedType).Name != "u" {
+		t.Errorf("got procedure %+v", proc)
+	}
+
+	s, err = Parse("test.x", []byte(`program P {
+  version V1 { void GET(void) = 1; } = 1;
+  version V2 { void GET(void) = 2; } = 2;
+} = 0x20000001;
+`), nil)
+	if err == nil {
+		err = Check(s, nil)
+	}
+	if err == nil || !strings.Contains(err.Error(), "test.x:3:21: GET redefined") {
+		t.Errorf("renumbered procedure: got %v", err)
+	}
+}
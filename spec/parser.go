@@ -0,0 +1,1112 @@
+// Code generated by goyacc -o parser.go -p xdr -v  xdr.y. DO NOT EDIT.
+
+//line xdr.y:2
+package spec
+
+import __yyfmt__ "fmt"
+
+//line xdr.y:2
+
+import "go/token"
+
+//line xdr.y:7
+type xdrSymType struct {
+	yys           int
+	decl          Decl
+	typespec      Type
+	str           string
+	bool          bool
+	enumItem      EnumItem
+	enumItems     []EnumItem
+	decls         []Decl
+	typeUnion     UnionType
+	unionCases    unionCases
+	unionCaseList []UnionCase
+	unionCase     UnionCase
+	values        []Value
+	proc          Proc
+	procs         []Proc
+	version       Version
+	versions      []Version
+	value         Value
+	size          *Value
+	pos           token.Pos
+}
+
+const KWCONST = 57346
+const KWTYPEDEF = 57347
+const KWENUM = 57348
+const KWSTRUCT = 57349
+const KWUNION = 57350
+const KWSWITCH = 57351
+const KWCASE = 57352
+const KWDEFAULT = 57353
+const KWVOID = 57354
+const KWOPAQUE = 57355
+const KWSTRING = 57356
+const KWUNSIGNED = 57357
+const KWINT = 57358
+const KWHYPER = 57359
+const KWFLOAT = 57360
+const KWDOUBLE = 57361
+const KWQUADRUPLE = 57362
+const KWBOOL = 57363
+const KWPROGRAM = 57364
+const KWVERSION = 57365
+const CONST = 57366
+const IDENT = 57367
+const LSHIFT = 57368
+const RSHIFT = 57369
+const UNARY = 57370
+
+var xdrToknames = [...]string{
+	"$end",
+	"error",
+	"$unk",
+	"KWCONST",
+	"KWTYPEDEF",
+	"KWENUM",
+	"KWSTRUCT",
+	"KWUNION",
+	"KWSWITCH",
+	"KWCASE",
+	"KWDEFAULT",
+	"KWVOID",
+	"KWOPAQUE",
+	"KWSTRING",
+	"KWUNSIGNED",
+	"KWINT",
+	"KWHYPER",
+	"KWFLOAT",
+	"KWDOUBLE",
+	"KWQUADRUPLE",
+	"KWBOOL",
+	"KWPROGRAM",
+	"KWVERSION",
+	"CONST",
+	"IDENT",
+	"LSHIFT",
+	"RSHIFT",
+	"'='",
+	"';'",
+	"'<'",
+	"'>'",
+	"'['",
+	"']'",
+	"'{'",
+	"'}'",
+	"','",
+	"':'",
+	"'*'",
+	"'+'",
+	"'-'",
+	"'/'",
+	"'%'",
+	"'&'",
+	"'|'",
+	"'^'",
+	"UNARY",
+	"'('",
+	"')'",
+}
+
+var xdrStatenames = [...]string{}
+
+const xdrEofCode = 1
+const xdrErrCode = 2
+const xdrInitialStackSize = 16
+
+//line xdr.y:284
+
+// unionCases holds the arms of a union while it is being parsed.
+type unionCases struct {
+	cases []UnionCase
+	def   *Decl
+}
+
+// sizeOf returns a pointer to a copy of v, for use as Decl.Size.
+func sizeOf(v Value) *Value {
+	return &v
+}
+
+//line yacctab:1
+var xdrExca = [...]int16{
+	-1, 1,
+	1, -1,
+	-2, 0,
+	-1, 28,
+	16, 48,
+	17, 48,
+	-2, 43,
+	-1, 68,
+	16, 47,
+	17, 47,
+	-2, 0,
+	-1, 134,
+	35, 61,
+	-2, 0,
+	-1, 136,
+	16, 47,
+	17, 47,
+	-2, 0,
+}
+
+const xdrPrivate = 57344
+
+const xdrLast = 266
+
+var xdrAct = [...]uint8{
+	15, 146, 73, 74, 14, 66, 98, 99, 167, 116,
+	162, 69, 137, 95, 75, 76, 96, 97, 95, 93,
+	94, 96, 97, 100, 101, 102, 98, 99, 127, 79,
+	78, 164, 160, 149, 98, 99, 132, 77, 95, 93,
+	94, 96, 97, 100, 101, 102, 95, 93, 94, 96,
+	97, 100, 59, 102, 38, 75, 76, 85, 86, 47,
+	108, 81, 82, 83, 130, 84, 44, 39, 49, 57,
+	79, 78, 106, 89, 91, 46, 49, 46, 77, 110,
+	51, 103, 104, 105, 95, 93, 94, 96, 97, 48,
+	113, 111, 112, 63, 64, 98, 99, 117, 118, 119,
+	120, 121, 122, 123, 124, 125, 126, 95, 93, 94,
+	96, 97, 100, 98, 99, 55, 109, 169, 60, 60,
+	62, 58, 60, 54, 170, 95, 93, 94, 96, 97,
+	165, 163, 45, 158, 155, 150, 135, 148, 29, 30,
+	31, 115, 114, 92, 147, 153, 151, 28, 72, 71,
+	20, 21, 22, 23, 157, 159, 90, 27, 70, 37,
+	29, 30, 31, 148, 166, 53, 18, 16, 17, 28,
+	13, 168, 20, 21, 22, 23, 154, 145, 128, 27,
+	87, 29, 30, 31, 56, 52, 156, 147, 129, 88,
+	28, 67, 61, 20, 21, 22, 23, 41, 40, 36,
+	27, 50, 35, 34, 33, 29, 30, 31, 32, 152,
+	143, 18, 16, 17, 28, 161, 131, 20, 21, 22,
+	23, 29, 30, 31, 27, 42, 43, 18, 16, 17,
+	28, 52, 5, 20, 21, 22, 23, 4, 140, 6,
+	27, 11, 7, 8, 9, 10, 142, 138, 3, 2,
+	1, 107, 80, 136, 144, 141, 139, 134, 133, 12,
+	68, 65, 19, 26, 25, 24,
+}
+
+var xdrPact = [...]int16{
+	-32768, 237, -32768, -32768, -32768, -32768, 141, 215, 183, 179,
+	178, 177, 174, -32768, 130, 29, 173, 172, -32768, 209,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, 41,
+	34, 176, 43, 42, 222, 156, 35, -32768, 89, 167,
+	88, 92, -32768, -32768, -32768, -32768, 166, -32768, -32768, -32768,
+	-32768, -32768, -36, 129, 120, 119, -10, -32768, -10, -32768,
+	31, -32768, -10, -32768, -32768, 22, -32768, 152, 154, 215,
+	-32768, -32768, -32768, 114, 0, -32768, -32768, -10, -10, -10,
+	37, 83, -32768, 48, 58, -32768, 166, -10, -32768, 113,
+	112, -39, -32768, -10, -10, -10, -10, -10, -10, -10,
+	-10, -10, -10, -20, -32768, -32768, 150, -32768, 163, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, 30, -25, -25, -32768,
+	-32768, -32768, 46, 46, 87, 8, 69, -32768, 192, 2,
+	-32768, 107, -32768, -23, 236, -32768, 175, -32768, -4, -32768,
+	106, 199, -10, 148, -32768, 105, 161, -32768, -32768, 215,
+	-32768, 104, -10, -5, 191, -32768, -37, 102, -32768, -6,
+	-32768, 101, 132, -32768, -32768, -32768, -40, 143, 93, 95,
+	-32768,
+}
+
+var xdrPgo = [...]int16{
+	0, 4, 0, 265, 264, 263, 1, 52, 2, 3,
+	262, 5, 132, 261, 89, 260, 80, 258, 257, 256,
+	255, 254, 253, 252, 251, 250, 249, 248, 237, 232,
+}
+
+var xdrR1 = [...]int8{
+	0, 25, 25, 26, 26, 26, 26, 1, 1, 1,
+	1, 1, 1, 1, 1, 7, 7, 8, 9, 9,
+	9, 9, 9, 9, 9, 9, 9, 9, 9, 9,
+	9, 9, 9, 2, 2, 2, 2, 2, 2, 2,
+	2, 2, 2, 2, 2, 2, 2, 10, 10, 3,
+	12, 13, 13, 11, 4, 14, 15, 15, 15, 5,
+	16, 17, 17, 18, 18, 18, 19, 20, 20, 28,
+	27, 27, 27, 27, 29, 23, 23, 24, 22, 22,
+	22, 21, 6, 6,
+}
+
+var xdrR2 = [...]int8{
+	0, 0, 2, 1, 1, 1, 2, 2, 5, 3,
+	5, 3, 3, 3, 1, 2, 3, 1, 1, 1,
+	3, 2, 2, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 2, 2, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 2, 2, 2, 0, 1, 2,
+	3, 1, 3, 3, 2, 3, 0, 3, 3, 2,
+	7, 1, 5, 0, 2, 3, 3, 3, 4, 5,
+	3, 4, 4, 4, 8, 0, 2, 8, 0, 2,
+	3, 8, 1, 1,
+}
+
+var xdrChk = [...]int16{
+	-32768, -25, -26, -27, -28, -29, 2, 5, 6, 7,
+	8, 4, 22, 29, -1, -2, 13, 14, 12, -10,
+	18, 19, 20, 21, -3, -4, -5, 25, 15, 6,
+	7, 8, 25, 25, 25, 25, 25, 29, 25, 38,
+	25, 25, 16, 17, 25, -12, 34, 25, -14, 34,
+	25, -16, 9, -12, -14, -16, 28, 34, 32, -7,
+	30, 25, 32, -7, -7, -13, -11, 25, -15, 47,
+	29, 29, 29, -8, -9, 24, 25, 47, 40, 39,
+	-23, -8, 31, -8, -8, 35, 36, 28, 35, -1,
+	2, -1, 29, 39, 40, 38, 41, 42, 26, 27,
+	43, 44, 45, -9, -9, -9, 35, -24, 23, 33,
+	31, 33, -11, -8, 29, 29, 48, -9, -9, -9,
+	-9, -9, -9, -9, -9, -9, -9, 48, 28, 25,
+	34, 24, 34, -17, -18, 29, -22, 35, 11, -19,
+	2, -20, 10, 35, -21, 2, -6, 12, -2, 37,
+	29, -1, 10, -8, 28, 29, 25, -1, 29, -8,
+	37, 24, 47, 29, 37, 29, -6, 48, 28, 24,
+	29,
+}
+
+var xdrDef = [...]int8{
+	1, -2, 2, 3, 4, 5, 0, 47, 0, 0,
+	0, 0, 0, 6, 0, 0, 0, 0, 14, 0,
+	35, 36, 37, 38, 39, 40, 41, 42, -2, 0,
+	0, 0, 0, 0, 0, 0, 0, 70, 7, 0,
+	0, 0, 33, 34, 44, 49, 0, 45, 54, 56,
+	46, 59, 0, 0, 0, 0, 0, 75, 0, 9,
+	0, 13, 0, 11, 12, 0, 51, 0, -2, 47,
+	71, 72, 73, 0, 17, 18, 19, 0, 0, 0,
+	0, 0, 15, 0, 0, 50, 0, 0, 55, 0,
+	0, 0, 69, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 21, 22, 0, 76, 0, 8,
+	16, 10, 52, 53, 57, 58, 0, 23, 24, 25,
+	26, 27, 28, 29, 30, 31, 32, 20, 0, 0,
+	63, 0, 78, 0, -2, 74, -2, 60, 0, 64,
+	0, 47, 0, 0, 79, 0, 0, 82, 83, 47,
+	65, 0, 0, 0, 0, 80, 0, 0, 66, 0,
+	67, 0, 47, 62, 68, 77, 0, 0, 0, 0,
+	81,
+}
+
+var xdrTok1 = [...]int8{
+	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 42, 43, 3,
+	47, 48, 38, 39, 36, 40, 3, 41, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 37, 29,
+	30, 28, 31, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 32, 3, 33, 45, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 34, 44, 35,
+}
+
+var xdrTok2 = [...]int8{
+	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
+	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
+	22, 23, 24, 25, 26, 27, 46,
+}
+
+var xdrTok3 = [...]int8{
+	0,
+}
+
+var xdrErrorMessages = [...]struct {
+	state int
+	token int
+	msg   string
+}{}
+
+//line yaccpar:1
+
+/*	parser for yacc output	*/
+
+var (
+	xdrDebug        = 0
+	xdrErrorVerbose = false
+)
+
+type xdrLexer interface {
+	Lex(lval *xdrSymType) int
+	Error(s string)
+}
+
+type xdrParser interface {
+	Parse(xdrLexer) int
+	Lookahead() int
+}
+
+type xdrParserImpl struct {
+	lval  xdrSymType
+	stack [xdrInitialStackSize]xdrSymType
+	char  int
+}
+
+func (p *xdrParserImpl) Lookahead() int {
+	return p.char
+}
+
+func xdrNewParser() xdrParser {
+	return &xdrParserImpl{}
+}
+
+const xdrFlag = -32768
+
+func xdrTokname(c int) string {
+	if c >= 1 && c-1 < len(xdrToknames) {
+		if xdrToknames[c-1] != "" {
+			return xdrToknames[c-1]
+		}
+	}
+	return __yyfmt__.Sprintf("tok-%v", c)
+}
+
+func xdrStatname(s int) string {
+	if s >= 0 && s < len(xdrStatenames) {
+		if xdrStatenames[s] != "" {
+			return xdrStatenames[s]
+		}
+	}
+	return __yyfmt__.Sprintf("state-%v", s)
+}
+
+func xdrErrorMessage(state, lookAhead int) string {
+	const TOKSTART = 4
+
+	if !xdrErrorVerbose {
+		return "syntax error"
+	}
+
+	for _, e := range xdrErrorMessages {
+		if e.state == state && e.token == lookAhead {
+			return "syntax error: " + e.msg
+		}
+	}
+
+	res := "syntax error: unexpected " + xdrTokname(lookAhead)
+
+	// To match Bison, suggest at most four expected tokens.
+	expected := make([]int, 0, 4)
+
+	// Look for shiftable tokens.
+	base := int(xdrPact[state])
+	for tok := TOKSTART; tok-1 < len(xdrToknames); tok++ {
+		if n := base + tok; n >= 0 && n < xdrLast && int(xdrChk[int(xdrAct[n])]) == tok {
+			if len(expected) == cap(expected) {
+				return res
+			}
+			expected = append(expected, tok)
+		}
+	}
+
+	if xdrDef[state] == -2 {
+		i := 0
+		for xdrExca[i] != -1 || int(xdrExca[i+1]) != state {
+			i += 2
+		}
+
+		// Look for tokens that we accept or reduce.
+		for i += 2; xdrExca[i] >= 0; i += 2 {
+			tok := int(xdrExca[i])
+			if tok < TOKSTART || xdrExca[i+1] == 0 {
+				continue
+			}
+			if len(expected) == cap(expected) {
+				return res
+			}
+			expected = append(expected, tok)
+		}
+
+		// If the default action is to accept or reduce, give up.
+		if xdrExca[i+1] != 0 {
+			return res
+		}
+	}
+
+	for i, tok := range expected {
+		if i == 0 {
+			res += ", expecting "
+		} else {
+			res += " or "
+		}
+		res += xdrTokname(tok)
+	}
+	return res
+}
+
+func xdrlex1(lex xdrLexer, lval *xdrSymType) (char, token int) {
+	token = 0
+	char = lex.Lex(lval)
+	if char <= 0 {
+		token = int(xdrTok1[0])
+		goto out
+	}
+	if char < len(xdrTok1) {
+		token = int(xdrTok1[char])
+		goto out
+	}
+	if char >= xdrPrivate {
+		if char < xdrPrivate+len(xdrTok2) {
+			token = int(xdrTok2[char-xdrPrivate])
+			goto out
+		}
+	}
+	for i := 0; i < len(xdrTok3); i += 2 {
+		token = int(xdrTok3[i+0])
+		if token == char {
+			token = int(xdrTok3[i+1])
+			goto out
+		}
+	}
+
+out:
+	if token == 0 {
+		token = int(xdrTok2[1]) /* unknown char */
+	}
+	if xdrDebug >= 3 {
+		__yyfmt__.Printf("lex %s(%d)\n", xdrTokname(token), uint(char))
+	}
+	return char, token
+}
+
+func xdrParse(xdrlex xdrLexer) int {
+	return xdrNewParser().Parse(xdrlex)
+}
+
+func (xdrrcvr *xdrParserImpl) Parse(xdrlex xdrLexer) int {
+	var xdrn int
+	var xdrVAL xdrSymType
+	var xdrDollar []xdrSymType
+	_ = xdrDollar // silence set and not used
+	xdrS := xdrrcvr.stack[:]
+
+	Nerrs := 0   /* number of errors */
+	Errflag := 0 /* error recovery flag */
+	xdrstate := 0
+	xdrrcvr.char = -1
+	xdrtoken := -1 // xdrrcvr.char translated into internal numbering
+	defer func() {
+		// Make sure we report no lookahead when not parsing.
+		xdrstate = -1
+		xdrrcvr.char = -1
+		xdrtoken = -1
+	}()
+	xdrp := -1
+	goto xdrstack
+
+ret0:
+	return 0
+
+ret1:
+	return 1
+
+xdrstack:
+	/* put a state and value onto the stack */
+	if xdrDebug >= 4 {
+		__yyfmt__.Printf("char %v in %v\n", xdrTokname(xdrtoken), xdrStatname(xdrstate))
+	}
+
+	xdrp++
+	if xdrp >= len(xdrS) {
+		nyys := make([]xdrSymType, len(xdrS)*2)
+		copy(nyys, xdrS)
+		xdrS = nyys
+	}
+	xdrS[xdrp] = xdrVAL
+	xdrS[xdrp].yys = xdrstate
+
+xdrnewstate:
+	xdrn = int(xdrPact[xdrstate])
+	if xdrn <= xdrFlag {
+		goto xdrdefault /* simple state */
+	}
+	if xdrrcvr.char < 0 {
+		xdrrcvr.char, xdrtoken = xdrlex1(xdrlex, &xdrrcvr.lval)
+	}
+	xdrn += xdrtoken
+	if xdrn < 0 || xdrn >= xdrLast {
+		goto xdrdefault
+	}
+	xdrn = int(xdrAct[xdrn])
+	if int(xdrChk[xdrn]) == xdrtoken { /* valid shift */
+		xdrrcvr.char = -1
+		xdrtoken = -1
+		xdrVAL = xdrrcvr.lval
+		xdrstate = xdrn
+		if Errflag > 0 {
+			Errflag--
+		}
+		goto xdrstack
+	}
+
+xdrdefault:
+	/* default state action */
+	xdrn = int(xdrDef[xdrstate])
+	if xdrn == -2 {
+		if xdrrcvr.char < 0 {
+			xdrrcvr.char, xdrtoken = xdrlex1(xdrlex, &xdrrcvr.lval)
+		}
+
+		/* look through exception table */
+		xi := 0
+		for {
+			if xdrExca[xi+0] == -1 && int(xdrExca[xi+1]) == xdrstate {
+				break
+			}
+			xi += 2
+		}
+		for xi += 2; ; xi += 2 {
+			xdrn = int(xdrExca[xi+0])
+			if xdrn < 0 || xdrn == xdrtoken {
+				break
+			}
+		}
+		xdrn = int(xdrExca[xi+1])
+		if xdrn < 0 {
+			goto ret0
+		}
+	}
+	if xdrn == 0 {
+		/* error ... attempt to resume parsing */
+		switch Errflag {
+		case 0: /* brand new error */
+			xdrlex.Error(xdrErrorMessage(xdrstate, xdrtoken))
+			Nerrs++
+			if xdrDebug >= 1 {
+				__yyfmt__.Printf("%s", xdrStatname(xdrstate))
+				__yyfmt__.Printf(" saw %s\n", xdrTokname(xdrtoken))
+			}
+			fallthrough
+
+		case 1, 2: /* incompletely recovered error ... try again */
+			Errflag = 3
+
+			/* find a state where "error" is a legal shift action */
+			for xdrp >= 0 {
+				xdrn = int(xdrPact[xdrS[xdrp].yys]) + xdrErrCode
+				if xdrn >= 0 && xdrn < xdrLast {
+					xdrstate = int(xdrAct[xdrn]) /* simulate a shift of "error" */
+					if int(xdrChk[xdrstate]) == xdrErrCode {
+						goto xdrstack
+					}
+				}
+
+				/* the current p has no shift on "error", pop stack */
+				if xdrDebug >= 2 {
+					__yyfmt__.Printf("error recovery pops state %d\n", xdrS[xdrp].yys)
+				}
+				xdrp--
+			}
+			/* there is no state on the stack with an error shift ... abort */
+			goto ret1
+
+		case 3: /* no shift yet; clobber input char */
+			if xdrDebug >= 2 {
+				__yyfmt__.Printf("error recovery discards %s\n", xdrTokname(xdrtoken))
+			}
+			if xdrtoken == xdrEofCode {
+				goto ret1
+			}
+			xdrrcvr.char = -1
+			xdrtoken = -1
+			goto xdrnewstate /* try again in the same state */
+		}
+	}
+
+	/* reduction by production xdrn */
+	if xdrDebug >= 2 {
+		__yyfmt__.Printf("reduce %v in:\n\t%v\n", xdrn, xdrStatname(xdrstate))
+	}
+
+	xdrnt := xdrn
+	xdrpt := xdrp
+	_ = xdrpt // guard against "declared and not used"
+
+	xdrp -= int(xdrR2[xdrn])
+	// xdrp is now the index of $0. Perform the default action. Iff the
+	// reduced production is ε, $1 is possibly out of range.
+	if xdrp+1 >= len(xdrS) {
+		nyys := make([]xdrSymType, len(xdrS)*2)
+		copy(nyys, xdrS)
+		xdrS = nyys
+	}
+	xdrVAL = xdrS[xdrp+1]
+
+	/* consult goto table to find next state */
+	xdrn = int(xdrR1[xdrn])
+	xdrg := int(xdrPgo[xdrn])
+	xdrj := xdrg + xdrS[xdrp].yys + 1
+
+	if xdrj >= xdrLast {
+		xdrstate = int(xdrAct[xdrg])
+	} else {
+		xdrstate = int(xdrAct[xdrj])
+		if int(xdrChk[xdrstate]) != -xdrn {
+			xdrstate = int(xdrAct[xdrg])
+		}
+	}
+	// dummy call; replaced with literal code
+	switch xdrnt {
+
+	case 7:
+		xdrDollar = xdrS[xdrpt-2 : xdrpt+1]
+//line xdr.y:106
+		{
+			xdrVAL.decl = Decl{Kind: DeclPlain, NamePos: xdrDollar[2].pos, Name: xdrDollar[2].str, Type: xdrDollar[1].typespec}
+		}
+	case 8:
+		xdrDollar = xdrS[xdrpt-5 : xdrpt+1]
+//line xdr.y:108
+		{
+			xdrVAL.decl = Decl{Kind: DeclFixedArray, NamePos: xdrDollar[2].pos, Name: xdrDollar[2].str, Type: xdrDollar[1].typespec, Size: sizeOf(xdrDollar[4].value)}
+		}
+	case 9:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:110
+		{
+			xdrVAL.decl = Decl{Kind: DeclVarArray, NamePos: xdrDollar[2].pos, Name: xdrDollar[2].str, Type: xdrDollar[1].typespec, Size: xdrDollar[3].size}
+		}
+	case 10:
+		xdrDollar = xdrS[xdrpt-5 : xdrpt+1]
+//line xdr.y:112
+		{
+			xdrVAL.decl = Decl{Kind: DeclFixedOpaque, NamePos: xdrDollar[2].pos, Name: xdrDollar[2].str, Size: sizeOf(xdrDollar[4].value)}
+		}
+	case 11:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:114
+		{
+			xdrVAL.decl = Decl{Kind: DeclVarOpaque, NamePos: xdrDollar[2].pos, Name: xdrDollar[2].str, Size: xdrDollar[3].size}
+		}
+	case 12:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:116
+		{
+			xdrVAL.decl = Decl{Kind: DeclString, NamePos: xdrDollar[2].pos, Name: xdrDollar[2].str, Size: xdrDollar[3].size}
+		}
+	case 13:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:118
+		{
+			xdrVAL.decl = Decl{Kind: DeclOptional, NamePos: xdrDollar[3].pos, Name: xdrDollar[3].str, Type: xdrDollar[1].typespec}
+		}
+	case 14:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:120
+		{
+			xdrVAL.decl = Decl{Kind: DeclVoid, NamePos: xdrDollar[1].pos}
+		}
+	case 15:
+		xdrDollar = xdrS[xdrpt-2 : xdrpt+1]
+//line xdr.y:123
+		{
+			xdrVAL.size = nil
+		}
+	case 16:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:125
+		{
+			xdrVAL.size = sizeOf(xdrDollar[2].value)
+		}
+	case 17:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:128
+		{
+			xdrVAL.value = xdrlex.(*lexer).exprValue(xdrDollar[1].value)
+		}
+	case 18:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:131
+		{
+			xdrVAL.value = literalValue(xdrDollar[1].pos, xdrDollar[1].str)
+		}
+	case 19:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:133
+		{
+			xdrVAL.value = xdrlex.(*lexer).identValue(xdrDollar[1].pos, xdrDollar[1].str)
+		}
+	case 20:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:135
+		{
+			xdrVAL.value = xdrDollar[2].value
+			xdrVAL.value.Pos = xdrDollar[1].pos
+		}
+	case 21:
+		xdrDollar = xdrS[xdrpt-2 : xdrpt+1]
+//line xdr.y:137
+		{
+			xdrVAL.value = xdrlex.(*lexer).unaryValue(xdrDollar[1].pos, '-', xdrDollar[2].value)
+		}
+	case 22:
+		xdrDollar = xdrS[xdrpt-2 : xdrpt+1]
+//line xdr.y:139
+		{
+			xdrVAL.value = xdrlex.(*lexer).unaryValue(xdrDollar[1].pos, '+', xdrDollar[2].value)
+		}
+	case 23:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:141
+		{
+			xdrVAL.value = xdrlex.(*lexer).binaryValue("+", xdrDollar[1].value, xdrDollar[3].value)
+		}
+	case 24:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:143
+		{
+			xdrVAL.value = xdrlex.(*lexer).binaryValue("-", xdrDollar[1].value, xdrDollar[3].value)
+		}
+	case 25:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:145
+		{
+			xdrVAL.value = xdrlex.(*lexer).binaryValue("*", xdrDollar[1].value, xdrDollar[3].value)
+		}
+	case 26:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:147
+		{
+			xdrVAL.value = xdrlex.(*lexer).binaryValue("/", xdrDollar[1].value, xdrDollar[3].value)
+		}
+	case 27:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:149
+		{
+			xdrVAL.value = xdrlex.(*lexer).binaryValue("%", xdrDollar[1].value, xdrDollar[3].value)
+		}
+	case 28:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:151
+		{
+			xdrVAL.value = xdrlex.(*lexer).binaryValue("<<", xdrDollar[1].value, xdrDollar[3].value)
+		}
+	case 29:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:153
+		{
+			xdrVAL.value = xdrlex.(*lexer).binaryValue(">>", xdrDollar[1].value, xdrDollar[3].value)
+		}
+	case 30:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:155
+		{
+			xdrVAL.value = xdrlex.(*lexer).binaryValue("&", xdrDollar[1].value, xdrDollar[3].value)
+		}
+	case 31:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:157
+		{
+			xdrVAL.value = xdrlex.(*lexer).binaryValue("|", xdrDollar[1].value, xdrDollar[3].value)
+		}
+	case 32:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:159
+		{
+			xdrVAL.value = xdrlex.(*lexer).binaryValue("^", xdrDollar[1].value, xdrDollar[3].value)
+		}
+	case 33:
+		xdrDollar = xdrS[xdrpt-2 : xdrpt+1]
+//line xdr.y:162
+		{
+			xdrVAL.typespec = IntType{xdrDollar[1].bool}
+		}
+	case 34:
+		xdrDollar = xdrS[xdrpt-2 : xdrpt+1]
+//line xdr.y:164
+		{
+			xdrVAL.typespec = HyperType{xdrDollar[1].bool}
+		}
+	case 35:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:166
+		{
+			xdrVAL.typespec = FloatType{}
+		}
+	case 36:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:168
+		{
+			xdrVAL.typespec = DoubleType{}
+		}
+	case 37:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:170
+		{
+			xdrVAL.typespec = QuadrupleType{}
+		}
+	case 38:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:172
+		{
+			xdrVAL.typespec = BoolType{}
+		}
+	case 39:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:174
+		{
+			xdrVAL.typespec = xdrDollar[1].typespec
+		}
+	case 40:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:176
+		{
+			xdrVAL.typespec = xdrDollar[1].typespec
+		}
+	case 41:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:178
+		{
+			xdrVAL.typespec = xdrDollar[1].typespec
+		}
+	case 42:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:180
+		{
+			xdrVAL.typespec = NamedType{xdrDollar[1].pos, xdrDollar[1].str}
+		}
+	case 43:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:182
+		{
+			xdrVAL.typespec = IntType{true}
+		}
+	case 44:
+		xdrDollar = xdrS[xdrpt-2 : xdrpt+1]
+//line xdr.y:184
+		{
+			xdrVAL.typespec = NamedType{xdrDollar[2].pos, xdrDollar[2].str}
+		}
+	case 45:
+		xdrDollar = xdrS[xdrpt-2 : xdrpt+1]
+//line xdr.y:186
+		{
+			xdrVAL.typespec = NamedType{xdrDollar[2].pos, xdrDollar[2].str}
+		}
+	case 46:
+		xdrDollar = xdrS[xdrpt-2 : xdrpt+1]
+//line xdr.y:188
+		{
+			xdrVAL.typespec = NamedType{xdrDollar[2].pos, xdrDollar[2].str}
+		}
+	case 47:
+		xdrDollar = xdrS[xdrpt-0 : xdrpt+1]
+//line xdr.y:190
+		{
+			xdrVAL.bool = false
+		}
+	case 48:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:190
+		{
+			xdrVAL.bool = true
+		}
+	case 49:
+		xdrDollar = xdrS[xdrpt-2 : xdrpt+1]
+//line xdr.y:193
+		{
+			xdrVAL.typespec = EnumType{xdrDollar[2].enumItems}
+		}
+	case 50:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:196
+		{
+			xdrVAL.enumItems = xdrDollar[2].enumItems
+		}
+	case 51:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:199
+		{
+			xdrVAL.enumItems = []EnumItem{xdrDollar[1].enumItem}
+		}
+	case 52:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:201
+		{
+			xdrVAL.enumItems = append(xdrDollar[1].enumItems, xdrDollar[3].enumItem)
+		}
+	case 53:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:204
+		{
+			xdrlex.(*lexer).defineConst(xdrDollar[1].str, xdrDollar[3].value)
+			xdrVAL.enumItem = EnumItem{NamePos: xdrDollar[1].pos, Name: xdrDollar[1].str, Value: xdrDollar[3].value}
+		}
+	case 54:
+		xdrDollar = xdrS[xdrpt-2 : xdrpt+1]
+//line xdr.y:210
+		{
+			xdrVAL.typespec = StructType{xdrDollar[2].decls}
+		}
+	case 55:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:213
+		{
+			xdrVAL.decls = xdrDollar[2].decls
+		}
+	case 56:
+		xdrDollar = xdrS[xdrpt-0 : xdrpt+1]
+//line xdr.y:215
+		{
+			xdrVAL.decls = nil
+		}
+	case 57:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:216
+		{
+			xdrVAL.decls = append(xdrDollar[1].decls, xdrDollar[2].decl)
+		}
+	case 58:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:218
+		{
+			xdrVAL.decls = xdrDollar[1].decls
+		}
+	case 59:
+		xdrDollar = xdrS[xdrpt-2 : xdrpt+1]
+//line xdr.y:221
+		{
+			xdrVAL.typespec = xdrDollar[2].typeUnion
+		}
+	case 60:
+		xdrDollar = xdrS[xdrpt-7 : xdrpt+1]
+//line xdr.y:224
+		{
+			xdrVAL.typeUnion = UnionType{Switch: xdrDollar[3].decl, Cases: xdrDollar[6].unionCases.cases, Default: xdrDollar[6].unionCases.def}
+		}
+	case 61:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:227
+		{
+			xdrVAL.unionCases = unionCases{xdrDollar[1].unionCaseList, nil}
+		}
+	case 62:
+		xdrDollar = xdrS[xdrpt-5 : xdrpt+1]
+//line xdr.y:229
+		{
+			def := xdrDollar[4].decl
+			xdrVAL.unionCases = unionCases{xdrDollar[1].unionCaseList, &def}
+		}
+	case 63:
+		xdrDollar = xdrS[xdrpt-0 : xdrpt+1]
+//line xdr.y:234
+		{
+			xdrVAL.unionCaseList = nil
+		}
+	case 64:
+		xdrDollar = xdrS[xdrpt-2 : xdrpt+1]
+//line xdr.y:235
+		{
+			xdrVAL.unionCaseList = append(xdrDollar[1].unionCaseList, xdrDollar[2].unionCase)
+		}
+	case 65:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:237
+		{
+			xdrVAL.unionCaseList = xdrDollar[1].unionCaseList
+		}
+	case 66:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:240
+		{
+			xdrVAL.unionCase = UnionCase{xdrDollar[1].values, xdrDollar[2].decl}
+		}
+	case 67:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:243
+		{
+			xdrVAL.values = []Value{xdrDollar[2].value}
+		}
+	case 68:
+		xdrDollar = xdrS[xdrpt-4 : xdrpt+1]
+//line xdr.y:245
+		{
+			xdrVAL.values = append(xdrDollar[1].values, xdrDollar[3].value)
+		}
+	case 69:
+		xdrDollar = xdrS[xdrpt-5 : xdrpt+1]
+//line xdr.y:248
+		{
+			xdrlex.(*lexer).defineConst(xdrDollar[2].str, xdrDollar[4].value)
+			xdrlex.(*lexer).define(ConstDef{NamePos: xdrDollar[2].pos, Name: xdrDollar[2].str, Value: xdrDollar[4].value})
+		}
+	case 70:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:254
+		{
+			xdrlex.(*lexer).define(TypedefDef{xdrDollar[2].decl})
+		}
+	case 71:
+		xdrDollar = xdrS[xdrpt-4 : xdrpt+1]
+//line xdr.y:256
+		{
+			xdrlex.(*lexer).define(EnumDef{NamePos: xdrDollar[2].pos, Name: xdrDollar[2].str, Items: xdrDollar[3].enumItems})
+		}
+	case 72:
+		xdrDollar = xdrS[xdrpt-4 : xdrpt+1]
+//line xdr.y:258
+		{
+			xdrlex.(*lexer).define(StructDef{NamePos: xdrDollar[2].pos, Name: xdrDollar[2].str, Fields: xdrDollar[3].decls})
+		}
+	case 73:
+		xdrDollar = xdrS[xdrpt-4 : xdrpt+1]
+//line xdr.y:260
+		{
+			xdrlex.(*lexer).define(UnionDef{NamePos: xdrDollar[2].pos, Name: xdrDollar[2].str, Union: xdrDollar[3].typeUnion})
+		}
+	case 74:
+		xdrDollar = xdrS[xdrpt-8 : xdrpt+1]
+//line xdr.y:263
+		{
+			xdrlex.(*lexer).define(ProgramDef{NamePos: xdrDollar[2].pos, Name: xdrDollar[2].str, Versions: xdrDollar[4].versions, Value: literalValue(xdrDollar[7].pos, xdrDollar[7].str)})
+		}
+	case 75:
+		xdrDollar = xdrS[xdrpt-0 : xdrpt+1]
+//line xdr.y:265
+		{
+			xdrVAL.versions = nil
+		}
+	case 76:
+		xdrDollar = xdrS[xdrpt-2 : xdrpt+1]
+//line xdr.y:266
+		{
+			xdrVAL.versions = append(xdrDollar[1].versions, xdrDollar[2].version)
+		}
+	case 77:
+		xdrDollar = xdrS[xdrpt-8 : xdrpt+1]
+//line xdr.y:269
+		{
+			xdrVAL.version = Version{NamePos: xdrDollar[2].pos, Name: xdrDollar[2].str, Procs: xdrDollar[4].procs, Value: literalValue(xdrDollar[7].pos, xdrDollar[7].str)}
+		}
+	case 78:
+		xdrDollar = xdrS[xdrpt-0 : xdrpt+1]
+//line xdr.y:271
+		{
+			xdrVAL.procs = nil
+		}
+	case 79:
+		xdrDollar = xdrS[xdrpt-2 : xdrpt+1]
+//line xdr.y:272
+		{
+			xdrVAL.procs = append(xdrDollar[1].procs, xdrDollar[2].proc)
+		}
+	case 80:
+		xdrDollar = xdrS[xdrpt-3 : xdrpt+1]
+//line xdr.y:274
+		{
+			xdrVAL.procs = xdrDollar[1].procs
+		}
+	case 81:
+		xdrDollar = xdrS[xdrpt-8 : xdrpt+1]
+//line xdr.y:277
+		{
+			xdrVAL.proc = Proc{NamePos: xdrDollar[2].pos, Name: xdrDollar[2].str, Arg: xdrDollar[4].typespec, Result: xdrDollar[1].typespec, Value: literalValue(xdrDollar[7].pos, xdrDollar[7].str)}
+		}
+	case 82:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:280
+		{
+			xdrVAL.typespec = nil
+		}
+	case 83:
+		xdrDollar = xdrS[xdrpt-1 : xdrpt+1]
+//line xdr.y:282
+		{
+			xdrVAL.typespec = xdrDollar[1].typespec
+		}
+	}
+	goto xdrstack /* stack new state and value */
+}
@@ -0,0 +1,376 @@
+package spec
+
+//go:generate goyacc -o parser.go -p xdr -v "" xdr.y
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+)
+
+type lexer struct {
+	s    xdrScanner
+	fset *token.FileSet
+
+	// pos is the position of the most recently returned token,
+	// used to report syntax errors.
+	pos token.Pos
+
+	errs  scanner.ErrorList
+	debug bool
+
+	// defines holds the object-like macros defined at the current
+	// token, and macros the #define and #undef directives that
+	// follow it; pending holds the remaining tokens of a macro being
+	// expanded.
+	defines map[string]string
+	macros  []macroChange
+	pending []pendingToken
+
+	// consts holds the values of the constants and enum items
+	// parsed so far, for evaluating constant expressions;
+	// extConsts holds those defined outside the spec.
+	consts    map[string]*big.Int
+	extConsts map[string]*big.Int
+
+	// defs holds the top-level definitions parsed so far.
+	defs []Def
+
+	// comments holds the comments read so far; line is the line of
+	// the most recent token, to tell which comments trail a token.
+	comments []comment
+	line     int
+}
+
+type pendingToken struct {
+	pos token.Pos
+	tok int
+	lit string
+}
+
+const (
+	eof = 0
+
+	// tokIllegal is returned for text that is not a token, after
+	// reporting it; tokComment is returned for comments.
+	tokIllegal = -1
+	tokComment = -2
+)
+
+// keywords maps the reserved words of RFC 4506 and of RPC program
+// definitions onto their tokens.  Every other word, including Go
+// keywords such as type and func, is an identifier.
+var keywords = map[string]int{
+	"bool":      KWBOOL,
+	"case":      KWCASE,
+	"const":     KWCONST,
+	"default":   KWDEFAULT,
+	"double":    KWDOUBLE,
+	"enum":      KWENUM,
+	"float":     KWFLOAT,
+	"hyper":     KWHYPER,
+	"int":       KWINT,
+	"opaque":    KWOPAQUE,
+	"program":   KWPROGRAM,
+	"quadruple": KWQUADRUPLE,
+	"string":    KWSTRING,
+	"struct":    KWSTRUCT,
+	"switch":    KWSWITCH,
+	"typedef":   KWTYPEDEF,
+	"union":     KWUNION,
+	"unsigned":  KWUNSIGNED,
+	"version":   KWVERSION,
+	"void":      KWVOID,
+}
+
+func init() {
+	xdrErrorVerbose = true
+}
+
+func (l *lexer) init(fset *token.FileSet, f *token.File, src []byte, defines map[string]string, macros []macroChange) {
+	l.fset = fset
+	l.defines = make(map[string]string)
+	for k, v := range defines {
+		l.defines[k] = v
+	}
+	l.macros = macros
+	l.consts = make(map[string]*big.Int)
+	l.s.init(f, src, func(pos token.Pos, msg string) {
+		l.errs.Add(fset.Position(pos), msg)
+	})
+}
+
+func (l *lexer) Lex(lval *xdrSymType) int {
+	for {
+		tok := l.lex(lval)
+		if tok >= 0 {
+			return tok
+		}
+	}
+}
+
+// lex returns the next token, or -1 if the scanned token is not valid
+// and should be skipped.
+func (l *lexer) lex(lval *xdrSymType) int {
+	pos, tok, lit := l.scan()
+	l.pos = pos
+	lval.pos = pos
+	if tok == eof {
+		return eof
+	}
+
+	if l.debug {
+		fmt.Printf("pos=%v, lit=%v\n", l.fset.Position(pos), lit)
+	}
+
+	switch tok {
+	case IDENT:
+		if kw, ok := keywords[lit]; ok {
+			return kw
+		}
+		lval.str = lit
+	case CONST:
+		lval.str = lit
+	}
+	return tok
+}
+
+// scan returns the next token, expanding macros.
+func (l *lexer) scan() (token.Pos, int, string) {
+	if len(l.pending) > 0 {
+		t := l.pending[0]
+		l.pending = l.pending[1:]
+		return t.pos, t.tok, t.lit
+	}
+
+	pos, tok, lit := l.s.scan()
+	for tok == tokComment {
+		l.comments = append(l.comments, comment{
+			pos:      pos,
+			end:      pos + token.Pos(len(lit)),
+			text:     commentText(lit),
+			trailing: l.fset.File(pos).Line(pos) == l.line,
+		})
+		pos, tok, lit = l.s.scan()
+	}
+	if tok != eof {
+		l.line = l.fset.File(pos).Line(pos)
+	}
+
+	off := l.s.file.Offset(pos)
+	for len(l.macros) > 0 && l.macros[0].offset <= off {
+		m := l.macros[0]
+		l.macros = l.macros[1:]
+		if m.undef {
+			delete(l.defines, m.name)
+		} else {
+			l.defines[m.name] = m.value
+		}
+	}
+
+	if tok == IDENT {
+		if _, ok := l.defines[lit]; ok {
+			l.pending = l.expand(pos, lit, nil)
+			return l.scan()
+		}
+	}
+	return pos, tok, lit
+}
+
+// expand returns the tokens of macro name, recursively expanding
+// any macros it refers to.  The expanded tokens all take the position
+// of the macro use.
+func (l *lexer) expand(pos token.Pos, name string, expanding []string) []pendingToken {
+	for _, e := range expanding {
+		if e == name {
+			l.errs.Add(l.fset.Position(pos), fmt.Sprintf("recursive macro %s", name))
+			return nil
+		}
+	}
+	expanding = append(expanding, name)
+
+	val := l.defines[name]
+	fset := token.NewFileSet()
+	var s xdrScanner
+	s.init(fset.AddFile(name, -1, len(val)), []byte(val), func(_ token.Pos, msg string) {
+		l.errs.Add(l.fset.Position(pos), fmt.Sprintf("in macro %s: %s", name, msg))
+	})
+
+	var res []pendingToken
+	for {
+		_, tok, lit := s.scan()
+		if tok == eof {
+			break
+		}
+		if tok == tokComment {
+			continue
+		}
+		if _, ok := l.defines[lit]; ok && tok == IDENT {
+			res = append(res, l.expand(pos, lit, expanding)...)
+			continue
+		}
+		res = append(res, pendingToken{pos, tok, lit})
+	}
+	return res
+}
+
+func (l *lexer) define(d Def) {
+	l.defs = append(l.defs, d)
+}
+
+func (l *lexer) errorf(pos token.Pos, format string, args ...interface{}) {
+	l.errs.Add(l.fset.Position(pos), fmt.Sprintf(format, args...))
+}
+
+func (l *lexer) Error(e string) {
+	l.errs.Add(l.fset.Position(l.pos), e)
+}
+
+// An xdrScanner splits a .x file into tokens, following the lexical
+// rules of RFC 4506: identifiers are a letter or underscore followed by
+// letters, digits and underscores, constants are decimal, hexadecimal
+// (0x) or octal (leading 0) integers, and comments are /* */ or //.
+// It records the line starts in its token.File.
+type xdrScanner struct {
+	file *token.File
+	src  []byte
+	err  func(pos token.Pos, msg string)
+
+	// off is the offset of the next unread byte.
+	off int
+}
+
+func (s *xdrScanner) init(file *token.File, src []byte, err func(pos token.Pos, msg string)) {
+	s.file = file
+	s.src = src
+	s.err = err
+	s.off = 0
+}
+
+// peek returns the byte n bytes past the next unread one, or 0 at the
+// end of the input.
+func (s *xdrScanner) peek(n int) byte {
+	if s.off+n < len(s.src) {
+		return s.src[s.off+n]
+	}
+	return 0
+}
+
+func (s *xdrScanner) advance() {
+	if s.src[s.off] == '\n' {
+		s.file.AddLine(s.off + 1)
+	}
+	s.off++
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// scan returns the next token: IDENT, CONST, LSHIFT, RSHIFT, a single
+// character, tokComment, tokIllegal or eof.  The literal is the text of
+// the token.
+func (s *xdrScanner) scan() (token.Pos, int, string) {
+	for s.off < len(s.src) && isSpace(s.src[s.off]) {
+		s.advance()
+	}
+
+	start := s.off
+	pos := s.file.Pos(start)
+	if start >= len(s.src) {
+		return pos, eof, ""
+	}
+
+	c := s.src[start]
+	switch {
+	case isLetter(c):
+		for s.off < len(s.src) && (isLetter(s.src[s.off]) || isDigit(s.src[s.off])) {
+			s.advance()
+		}
+		return pos, IDENT, string(s.src[start:s.off])
+
+	case isDigit(c):
+		// Scan letters too, so that 12ab is one malformed
+		// constant rather than a constant and an identifier.
+		for s.off < len(s.src) && (isLetter(s.src[s.off]) || isDigit(s.src[s.off])) {
+			s.advance()
+		}
+		lit := string(s.src[start:s.off])
+		if !validConst(lit) {
+			s.err(pos, fmt.Sprintf("invalid constant %s", lit))
+			return pos, tokIllegal, lit
+		}
+		return pos, CONST, lit
+
+	case c == '/' && s.peek(1) == '*':
+		for s.off+1 < len(s.src) && !(s.src[s.off] == '*' && s.src[s.off+1] == '/') {
+			s.advance()
+		}
+		if s.off+1 >= len(s.src) {
+			s.off = len(s.src)
+			s.err(pos, "comment not terminated")
+			return pos, tokIllegal, string(s.src[start:])
+		}
+		s.off += 2
+		return pos, tokComment, string(s.src[start:s.off])
+
+	case c == '/' && s.peek(1) == '/':
+		for s.off < len(s.src) && s.src[s.off] != '\n' {
+			s.advance()
+		}
+		return pos, tokComment, string(s.src[start:s.off])
+
+	case c == '<' && s.peek(1) == '<':
+		s.off += 2
+		return pos, LSHIFT, "<<"
+
+	case c == '>' && s.peek(1) == '>':
+		s.off += 2
+		return pos, RSHIFT, ">>"
+	}
+
+	s.advance()
+	switch c {
+	case '=', ';', ':', '<', '>', '[', ']', '{', '}', ',', '(', ')',
+		'*', '+', '-', '/', '%', '&', '|', '^':
+		return pos, int(c), string(c)
+	}
+
+	r, size := utf8.DecodeRune(s.src[start:])
+	s.off = start + size
+	s.err(pos, fmt.Sprintf("illegal character %#U", r))
+	return pos, tokIllegal, string(s.src[start:s.off])
+}
+
+// validConst reports whether lit is a decimal, hexadecimal or octal
+// constant.
+func validConst(lit string) bool {
+	digits := "0123456789"
+	switch {
+	case lit == "0":
+		return true
+	case len(lit) > 2 && lit[0] == '0' && (lit[1] == 'x' || lit[1] == 'X'):
+		lit = lit[2:]
+		digits = "0123456789abcdefABCDEF"
+	case lit[0] == '0':
+		lit = lit[1:]
+		digits = "01234567"
+	}
+	for i := 0; i < len(lit); i++ {
+		if strings.IndexByte(digits, lit[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
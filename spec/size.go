@@ -0,0 +1,113 @@
+package spec
+
+import (
+	"math/big"
+)
+
+// FixedSize returns the size in bytes of the XDR encoding of t, and
+// whether it is the same for every value of t.  Unions, optional data,
+// variable-length arrays and types defined outside s have no fixed
+// size.
+func (s *Spec) FixedSize(t Type) (*big.Int, bool) {
+	return s.fixedSize(t, make(map[string]bool))
+}
+
+// DeclFixedSize is like FixedSize, for the type declared by d.
+func (s *Spec) DeclFixedSize(d Decl) (*big.Int, bool) {
+	return s.declFixedSize(d, make(map[string]bool))
+}
+
+// TypeDef returns the definition of the named type, or nil if it is
+// not defined in s.
+func (s *Spec) TypeDef(name string) Def {
+	for _, d := range s.Defs {
+		switch d := d.(type) {
+		case TypedefDef:
+			if d.Decl.Kind != DeclVoid && d.Decl.Name == name {
+				return d
+			}
+		case EnumDef:
+			if d.Name == name {
+				return d
+			}
+		case StructDef:
+			if d.Name == name {
+				return d
+			}
+		case UnionDef:
+			if d.Name == name {
+				return d
+			}
+		}
+	}
+	return nil
+}
+
+// fixedSize computes the size of t.  visiting holds the named types
+// whose size is being computed, since recursive types have no fixed
+// size.
+func (s *Spec) fixedSize(t Type, visiting map[string]bool) (*big.Int, bool) {
+	switch t := t.(type) {
+	case IntType, FloatType, BoolType, EnumType:
+		return big.NewInt(4), true
+	case HyperType, DoubleType:
+		return big.NewInt(8), true
+	case QuadrupleType:
+		return big.NewInt(16), true
+	case StructType:
+		return s.fieldsFixedSize(t.Fields, visiting)
+	case NamedType:
+		if visiting[t.Name] {
+			return nil, false
+		}
+		visiting[t.Name] = true
+		defer delete(visiting, t.Name)
+
+		switch d := s.TypeDef(t.Name).(type) {
+		case TypedefDef:
+			return s.declFixedSize(d.Decl, visiting)
+		case EnumDef:
+			return big.NewInt(4), true
+		case StructDef:
+			return s.fieldsFixedSize(d.Fields, visiting)
+		}
+	}
+	return nil, false
+}
+
+func (s *Spec) fieldsFixedSize(fields []Decl, visiting map[string]bool) (*big.Int, bool) {
+	n := new(big.Int)
+	for _, f := range fields {
+		sz, ok := s.declFixedSize(f, visiting)
+		if !ok {
+			return nil, false
+		}
+		n.Add(n, sz)
+	}
+	return n, true
+}
+
+func (s *Spec) declFixedSize(d Decl, visiting map[string]bool) (*big.Int, bool) {
+	switch d.Kind {
+	case DeclVoid:
+		return new(big.Int), true
+	case DeclPlain:
+		return s.fixedSize(d.Type, visiting)
+	case DeclFixedArray:
+		n := s.Value(*d.Size)
+		sz, ok := s.fixedSize(d.Type, visiting)
+		if n == nil || !ok {
+			return nil, false
+		}
+		return new(big.Int).Mul(n, sz), true
+	case DeclFixedOpaque:
+		n := s.Value(*d.Size)
+		if n == nil {
+			return nil, false
+		}
+		// Opaque data is padded to a multiple of 4 bytes.
+		sz := new(big.Int).Add(n, big.NewInt(3))
+		return sz.And(sz, big.NewInt(^3)), true
+	}
+	return nil, false
+}
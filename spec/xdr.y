@@ -0,0 +1,295 @@
+%{
+package spec
+
+import "go/token"
+%}
+
+%union {
+  decl Decl;
+  typespec Type;
+  str string;
+  bool bool;
+  enumItem EnumItem;
+  enumItems []EnumItem;
+  decls []Decl;
+  typeUnion UnionType;
+  unionCases unionCases;
+  unionCaseList []UnionCase;
+  unionCase UnionCase;
+  values []Value;
+  proc Proc;
+  procs []Proc;
+  version Version;
+  versions []Version;
+  value Value;
+  size *Value;
+  pos token.Pos;
+}
+
+%token KWCONST
+%token KWTYPEDEF
+%token KWENUM
+%token KWSTRUCT
+%token KWUNION
+%token KWSWITCH
+%token KWCASE
+%token KWDEFAULT
+%token KWVOID
+%token KWOPAQUE
+%token KWSTRING
+%token KWUNSIGNED
+%token KWINT
+%token KWHYPER
+%token KWFLOAT
+%token KWDOUBLE
+%token KWQUADRUPLE
+%token KWBOOL
+%token KWPROGRAM
+%token KWVERSION
+%token <str> CONST
+%token <str> IDENT
+%token LSHIFT
+%token RSHIFT
+%token '='
+%token ';'
+%token '<'
+%token '>'
+%token '['
+%token ']'
+%token '{'
+%token '}'
+%token ','
+%token ':'
+%token '*'
+%token '+'
+%token '-'
+%token '/'
+%token '%'
+%token '&'
+%token '|'
+%token '^'
+
+%left '|'
+%left '^'
+%left '&'
+%left LSHIFT RSHIFT
+%left '+' '-'
+%left '*' '/' '%'
+%right UNARY
+
+%type <decl> decl
+%type <typespec> typespec enumtypespec structtypespec uniontypespec typespecopt
+%type <size> varlen
+%type <value> val expr
+%type <bool> maybeunsig
+%type <enumItem> enumitem
+%type <enumItems> enumbody enumitems
+%type <decls> structbody structdecls
+%type <typeUnion> unionbody
+%type <unionCases> unioncasesdef
+%type <unionCaseList> unioncases
+%type <unionCase> unioncase
+%type <values> caselist
+%type <proc> progcall
+%type <procs> progcalls
+%type <versions> progvers
+%type <version> progver
+
+%%
+
+spec: | spec defn
+
+defn: typedef | constdef | progdef
+| error ';'
+
+decl: typespec IDENT
+  { $$ = Decl{Kind: DeclPlain, NamePos: $<pos>2, Name: $2, Type: $1} }
+| typespec IDENT '[' val ']'
+  { $$ = Decl{Kind: DeclFixedArray, NamePos: $<pos>2, Name: $2, Type: $1, Size: sizeOf($4)} }
+| typespec IDENT varlen
+  { $$ = Decl{Kind: DeclVarArray, NamePos: $<pos>2, Name: $2, Type: $1, Size: $3} }
+| KWOPAQUE IDENT '[' val ']'
+  { $$ = Decl{Kind: DeclFixedOpaque, NamePos: $<pos>2, Name: $2, Size: sizeOf($4)} }
+| KWOPAQUE IDENT varlen
+  { $$ = Decl{Kind: DeclVarOpaque, NamePos: $<pos>2, Name: $2, Size: $3} }
+| KWSTRING IDENT varlen
+  { $$ = Decl{Kind: DeclString, NamePos: $<pos>2, Name: $2, Size: $3} }
+| typespec '*' IDENT
+  { $$ = Decl{Kind: DeclOptional, NamePos: $<pos>3, Name: $3, Type: $1} }
+| KWVOID
+  { $$ = Decl{Kind: DeclVoid, NamePos: $<pos>1} }
+
+varlen: '<' '>'
+  { $$ = nil }
+| '<' val '>'
+  { $$ = sizeOf($2) }
+
+val: expr
+  { $$ = xdrlex.(*lexer).exprValue($1) }
+
+expr: CONST
+  { $$ = literalValue($<pos>1, $1) }
+| IDENT
+  { $$ = xdrlex.(*lexer).identValue($<pos>1, $1) }
+| '(' expr ')'
+  { $$ = $2; $$.Pos = $<pos>1 }
+| '-' expr %prec UNARY
+  { $$ = xdrlex.(*lexer).unaryValue($<pos>1, '-', $2) }
+| '+' expr %prec UNARY
+  { $$ = xdrlex.(*lexer).unaryValue($<pos>1, '+', $2) }
+| expr '+' expr
+  { $$ = xdrlex.(*lexer).binaryValue("+", $1, $3) }
+| expr '-' expr
+  { $$ = xdrlex.(*lexer).binaryValue("-", $1, $3) }
+| expr '*' expr
+  { $$ = xdrlex.(*lexer).binaryValue("*", $1, $3) }
+| expr '/' expr
+  { $$ = xdrlex.(*lexer).binaryValue("/", $1, $3) }
+| expr '%' expr
+  { $$ = xdrlex.(*lexer).binaryValue("%", $1, $3) }
+| expr LSHIFT expr
+  { $$ = xdrlex.(*lexer).binaryValue("<<", $1, $3) }
+| expr RSHIFT expr
+  { $$ = xdrlex.(*lexer).binaryValue(">>", $1, $3) }
+| expr '&' expr
+  { $$ = xdrlex.(*lexer).binaryValue("&", $1, $3) }
+| expr '|' expr
+  { $$ = xdrlex.(*lexer).binaryValue("|", $1, $3) }
+| expr '^' expr
+  { $$ = xdrlex.(*lexer).binaryValue("^", $1, $3) }
+
+typespec: maybeunsig KWINT
+  { $$ = IntType{$1} }
+| maybeunsig KWHYPER
+  { $$ = HyperType{$1} }
+| KWFLOAT
+  { $$ = FloatType{} }
+| KWDOUBLE
+  { $$ = DoubleType{} }
+| KWQUADRUPLE
+  { $$ = QuadrupleType{} }
+| KWBOOL
+  { $$ = BoolType{} }
+| enumtypespec
+  { $$ = $1 }
+| structtypespec
+  { $$ = $1 }
+| uniontypespec
+  { $$ = $1 }
+| IDENT
+  { $$ = NamedType{$<pos>1, $1} }
+| KWUNSIGNED
+  { $$ = IntType{true} }
+| KWENUM IDENT
+  { $$ = NamedType{$<pos>2, $2} }
+| KWSTRUCT IDENT
+  { $$ = NamedType{$<pos>2, $2} }
+| KWUNION IDENT
+  { $$ = NamedType{$<pos>2, $2} }
+
+maybeunsig: { $$ = false } | KWUNSIGNED { $$ = true }
+
+enumtypespec: KWENUM enumbody
+  { $$ = EnumType{$2} }
+
+enumbody: '{' enumitems '}'
+  { $$ = $2 }
+
+enumitems: enumitem
+  { $$ = []EnumItem{$1} }
+| enumitems ',' enumitem
+  { $$ = append($1, $3) }
+
+enumitem: IDENT '=' val
+  {
+    xdrlex.(*lexer).defineConst($1, $3)
+    $$ = EnumItem{NamePos: $<pos>1, Name: $1, Value: $3}
+  }
+
+structtypespec: KWSTRUCT structbody
+  { $$ = StructType{$2} }
+
+structbody: '{' structdecls '}'
+  { $$ = $2 }
+
+structdecls: { $$ = nil } | structdecls decl ';'
+  { $$ = append($1, $2) }
+| structdecls error ';'
+  { $$ = $1 }
+
+uniontypespec: KWUNION unionbody
+  { $$ = $2 }
+
+unionbody: KWSWITCH '(' decl ')' '{' unioncasesdef '}'
+  { $$ = UnionType{Switch: $3, Cases: $6.cases, Default: $6.def} }
+
+unioncasesdef: unioncases
+  { $$ = unionCases{$1, nil} }
+| unioncases KWDEFAULT ':' decl ';'
+  {
+    def := $4
+    $$ = unionCases{$1, &def}
+  }
+
+unioncases: { $$ = nil } | unioncases unioncase
+  { $$ = append($1, $2) }
+| unioncases error ';'
+  { $$ = $1 }
+
+unioncase: caselist decl ';'
+  { $$ = UnionCase{$1, $2} }
+
+caselist: KWCASE val ':'
+  { $$ = []Value{$2} }
+| caselist KWCASE val ':'
+  { $$ = append($1, $3) }
+
+constdef: KWCONST IDENT '=' val ';'
+  {
+    xdrlex.(*lexer).defineConst($2, $4)
+    xdrlex.(*lexer).define(ConstDef{NamePos: $<pos>2, Name: $2, Value: $4})
+  }
+
+typedef: KWTYPEDEF decl ';'
+  { xdrlex.(*lexer).define(TypedefDef{$2}) }
+| KWENUM IDENT enumbody ';'
+  { xdrlex.(*lexer).define(EnumDef{NamePos: $<pos>2, Name: $2, Items: $3}) }
+| KWSTRUCT IDENT structbody ';'
+  { xdrlex.(*lexer).define(StructDef{NamePos: $<pos>2, Name: $2, Fields: $3}) }
+| KWUNION IDENT unionbody ';'
+  { xdrlex.(*lexer).define(UnionDef{NamePos: $<pos>2, Name: $2, Union: $3}) }
+
+progdef: KWPROGRAM IDENT '{' progvers '}' '=' CONST ';'
+  { xdrlex.(*lexer).define(ProgramDef{NamePos: $<pos>2, Name: $2, Versions: $4, Value: literalValue($<pos>7, $7)}) }
+
+progvers: { $$ = nil } | progvers progver
+  { $$ = append($1, $2) }
+
+progver: KWVERSION IDENT '{' progcalls '}' '=' CONST ';'
+  { $$ = Version{NamePos: $<pos>2, Name: $2, Procs: $4, Value: literalValue($<pos>7, $7)} }
+
+progcalls: { $$ = nil } | progcalls progcall
+  { $$ = append($1, $2) }
+| progcalls error ';'
+  { $$ = $1 }
+
+progcall: typespecopt IDENT '(' typespecopt ')' '=' CONST ';'
+  { $$ = Proc{NamePos: $<pos>2, Name: $2, Arg: $4, Result: $1, Value: literalValue($<pos>7, $7)} }
+
+typespecopt: KWVOID
+  { $$ = nil }
+| typespec
+  { $$ = $1 }
+
+%%
+
+// unionCases holds the arms of a union while it is being parsed.
+type unionCases struct {
+	cases []UnionCase
+	def   *Decl
+}
+
+// sizeOf returns a pointer to a copy of v, for use as Decl.Size.
+func sizeOf(v Value) *Value {
+	return &v
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"text/template"
+
+	"github.com/zeldovich/go-rpcgen/spec"
+)
+
+// Code is generated by executing a text/template over the parsed spec.
+// The body of the output file is the result, to which go-rpcgen adds
+// the package clause and the imports of the packages in goPackages.
+// The built-in template just places the built-in code for every
+// definition; -template replaces it with a user-supplied one, which
+// can wrap that code or generate its own.
+
+// defaultTemplate generates the built-in output.
+const defaultTemplate = `{{range .Defs}}{{xdr .}}{{end}}{{progNames}}`
+
+// templateData is the value that templates are executed with.  The
+// fields of spec.Spec, such as Defs, are promoted.
+type templateData struct {
+	*spec.Spec
+
+	// Package is the name of the output package, given by -p.
+	Package string
+
+	// Input is the name of the input file.
+	Input string
+}
+
+// templateFuncs returns the functions that templates may call.
+func (f *specFile) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		// goName returns the Go name of a type, enum item,
+		// field, or program, version or procedure.
+		"goName": i,
+
+		// constName returns the Go name of a const definition.
+		"constName": constName,
+
+		// kind returns "const", "typedef", "enum", "struct",
+		// "union" or "program".
+		"kind": defKind,
+
+		// goType returns the Go type of an XDR type, or "" for
+		// void.
+		"goType": func(t spec.Type) string {
+			if t == nil {
+				return ""
+			}
+			return f.typespec(t).goType()
+		},
+
+		// declType returns the Go type of a field or typedef, or
+		// "" for void.
+		"declType": func(d spec.Decl) string {
+			if v, ok := f.decl(d).(declName); ok {
+				return v.t.goType()
+			}
+			return ""
+		},
+
+		// value returns the Go rendering of a constant value.
+		"value": f.goValue,
+
+		// xdr returns the built-in code for a definition.
+		"xdr": func(d spec.Def) string {
+			return capture(func() { emitDef(f.goDef(d)) })
+		},
+
+		// progNames returns ProcName, which names the procedures
+		// of every program passed to xdr.
+		"progNames": func() string {
+			return capture(emitProgNames)
+		},
+	}
+}
+
+// capture returns the code that emit writes to out, rather than
+// writing it.  Type definitions still go to the -t file, if any.
+func capture(emit func()) string {
+	var buf bytes.Buffer
+	saveOut, saveTout := out, tout
+	defer func() {
+		out, tout = saveOut, saveTout
+	}()
+
+	out = &buf
+	if saveTout == saveOut {
+		tout = &buf
+	}
+	emit()
+	return buf.String()
+}
+
+func defKind(d spec.Def) string {
+	switch d.(type) {
+	case spec.ConstDef:
+		return "const"
+	case spec.TypedefDef:
+		return "typedef"
+	case spec.EnumDef:
+		return "enum"
+	case spec.StructDef:
+		return "struct"
+	case spec.UnionDef:
+		return "union"
+	case spec.ProgramDef:
+		return "program"
+	}
+	return ""
+}
+
+// loadTemplate returns the template given by -template, or the
+// built-in one.
+func (f *specFile) loadTemplate(filename string) (*template.Template, error) {
+	if filename == "" {
+		return template.New("default").Funcs(f.templateFuncs()).Parse(defaultTemplate)
+	}
+
+	text, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return template.New(filepath.Base(filename)).Funcs(f.templateFuncs()).Parse(string(text))
+}
+
+// execTemplate executes the template given by -template, or the
+// built-in one, writing the result to out.
+func (f *specFile) execTemplate(filename string) error {
+	tmpl, err := f.loadTemplate(filename)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(out, templateData{f.Spec, *outputPackage, *inputFile})
+}
@@ -3,6 +3,7 @@ package xdr
 import (
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
 	"math"
 )
@@ -63,6 +64,10 @@ func (xs *XdrState) SetError(s string) {
 	xs.err = errors.New(s)
 }
 
+func (xs *XdrState) SetErrorf(format string, args ...interface{}) {
+	xs.err = fmt.Errorf(format, args...)
+}
+
 func (xs *XdrState) Error() error {
 	return xs.err
 }
@@ -171,6 +176,47 @@ func XdrU64(xs *XdrState, v *uint64) {
 	}
 }
 
+func XdrFloat32(xs *XdrState, v *float32) {
+	if xs.err != nil {
+		return
+	}
+
+	var buf [4]byte
+	if xs.Encoding() {
+		binary.BigEndian.PutUint32(buf[:], math.Float32bits(*v))
+	}
+	xdrRW(xs, buf[:])
+	if xs.Decoding() {
+		*v = math.Float32frombits(binary.BigEndian.Uint32(buf[:]))
+	}
+}
+
+func XdrFloat64(xs *XdrState, v *float64) {
+	if xs.err != nil {
+		return
+	}
+
+	var buf [8]byte
+	if xs.Encoding() {
+		binary.BigEndian.PutUint64(buf[:], math.Float64bits(*v))
+	}
+	xdrRW(xs, buf[:])
+	if xs.Decoding() {
+		*v = math.Float64frombits(binary.BigEndian.Uint64(buf[:]))
+	}
+}
+
+// XdrQuadruple encodes a 128-bit IEEE quadruple-precision value.
+// Go has no native float128, so the value is kept as its raw
+// big-endian byte representation.
+func XdrQuadruple(xs *XdrState, v *[16]byte) {
+	if xs.err != nil {
+		return
+	}
+
+	xdrRW(xs, v[:])
+}
+
 func XdrVarArray(xs *XdrState, maxlen int, v *[]byte) {
 	if xs.err != nil {
 		return
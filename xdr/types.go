@@ -1,5 +1,10 @@
 package xdr
 
+import (
+	"context"
+	"net"
+)
+
 type Xdrable interface {
 	Xdr(xs *XdrState)
 }
@@ -9,21 +14,73 @@ type Uint32 uint32
 type Int32 int32
 type Uint64 uint64
 type Int64 int64
+type Float32 float32
+type Float64 float64
+type Quadruple [16]byte
 type Void struct{}
 
 const TRUE Bool = true
 const FALSE Bool = false
 
-func (v *Bool) Xdr(xs *XdrState)   { XdrBool(xs, (*bool)(v)) }
-func (v *Uint32) Xdr(xs *XdrState) { XdrU32(xs, (*uint32)(v)) }
-func (v *Int32) Xdr(xs *XdrState)  { XdrS32(xs, (*int32)(v)) }
-func (v *Uint64) Xdr(xs *XdrState) { XdrU64(xs, (*uint64)(v)) }
-func (v *Int64) Xdr(xs *XdrState)  { XdrS64(xs, (*int64)(v)) }
-func (v *Void) Xdr(xs *XdrState)   {}
+func (v *Bool) Xdr(xs *XdrState)      { XdrBool(xs, (*bool)(v)) }
+func (v *Uint32) Xdr(xs *XdrState)    { XdrU32(xs, (*uint32)(v)) }
+func (v *Int32) Xdr(xs *XdrState)     { XdrS32(xs, (*int32)(v)) }
+func (v *Uint64) Xdr(xs *XdrState)    { XdrU64(xs, (*uint64)(v)) }
+func (v *Int64) Xdr(xs *XdrState)     { XdrS64(xs, (*int64)(v)) }
+func (v *Float32) Xdr(xs *XdrState)   { XdrFloat32(xs, (*float32)(v)) }
+func (v *Float64) Xdr(xs *XdrState)   { XdrFloat64(xs, (*float64)(v)) }
+func (v *Quadruple) Xdr(xs *XdrState) { XdrQuadruple(xs, (*[16]byte)(v)) }
+func (v *Void) Xdr(xs *XdrState)      {}
+
+// A Sizer reports the size of its XDR encoding.  Generated types
+// implement Sizer.
+type Sizer interface {
+	XdrSize() int
+}
+
+func (v *Bool) XdrSize() int      { return 4 }
+func (v *Uint32) XdrSize() int    { return 4 }
+func (v *Int32) XdrSize() int     { return 4 }
+func (v *Uint64) XdrSize() int    { return 8 }
+func (v *Int64) XdrSize() int     { return 8 }
+func (v *Float32) XdrSize() int   { return 4 }
+func (v *Float64) XdrSize() int   { return 8 }
+func (v *Quadruple) XdrSize() int { return 16 }
+func (v *Void) XdrSize() int      { return 0 }
 
 type ProcRegistration struct {
 	Prog    uint32
 	Vers    uint32
 	Proc    uint32
 	Handler func(args *XdrState) (res Xdrable, err error)
+
+	// ContextHandler is used instead of Handler, if set.  It is
+	// generated for handlers compiled with -handler-context.
+	ContextHandler func(ctx context.Context, call *CallInfo, args *XdrState) (res Xdrable, err error)
+}
+
+// OpaqueAuth is an RPC credential or verifier.
+type OpaqueAuth struct {
+	Flavor uint32
+	Body   []byte
+}
+
+// CallInfo describes the RPC call being handled by a ContextHandler.
+type CallInfo struct {
+	Xid  uint32
+	Prog uint32
+	Vers uint32
+	Proc uint32
+	Cred OpaqueAuth
+	Verf OpaqueAuth
+
+	// Peer is the address of the caller, if known.
+	Peer net.Addr
+}
+
+// A ProcCaller issues RPC calls.  Generated client stubs are built on a
+// ProcCaller, and pass the numbers of their program and version with
+// each call, so that a ProcCaller can check that it serves them.
+type ProcCaller interface {
+	CallProc(ctx context.Context, prog, vers, proc uint32, args Xdrable, res Xdrable) error
 }